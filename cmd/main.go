@@ -3,6 +3,8 @@ package main
 import (
 	"context"
 	"log"
+	"os"
+	"strconv"
 
 	"github.com/kiriyms/dungeon-master-mcp/prompts"
 	"github.com/kiriyms/dungeon-master-mcp/resources"
@@ -25,10 +27,32 @@ func main() {
 	tools.RegisterCombatTools(server)
 	log.Println("Registered Tools: combat management, damage calculation, legendary actions")
 
+	// Keep a deeper or shallower undo history, if one is configured
+	if depth := os.Getenv(tools.UndoDepthEnv); depth != "" {
+		n, err := strconv.Atoi(depth)
+		if err == nil {
+			err = tools.SetUndoDepth(n)
+		}
+		if err != nil {
+			log.Fatalf("Invalid %s: %v", tools.UndoDepthEnv, err)
+		}
+		log.Printf("Undo history keeps the last %d actions", n)
+	}
+
+	// Load extra stat blocks from a directory of JSON files, if one is configured
+	if dir := os.Getenv(resources.MonsterDirEnv); dir != "" {
+		loaded, err := resources.LoadMonsterDir(dir)
+		if err != nil {
+			log.Fatalf("Loading monsters: %v", err)
+		}
+		log.Printf("Loaded %d monster stat blocks from %s", loaded, dir)
+	}
+
 	// Register all SRD resources
 	// These provide monster stat blocks, damage rules, condition definitions, etc.
 	resources.RegisterCombatResources(server)
-	log.Println("Registered Resources: monster stats, SRD rules, condition definitions")
+	resources.SetCombatStateSource(tools.CombatStateJSON)
+	log.Println("Registered Resources: monster stats, SRD rules, condition definitions, live combat state")
 
 	// Register all DM assistance prompts
 	// These guide the DM through complex combat scenarios
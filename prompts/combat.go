@@ -3,6 +3,7 @@ package prompts
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/kiriyms/dungeon-master-mcp/tools"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -31,6 +32,11 @@ func RegisterCombatPrompts(server *mcp.Server) {
 					Description: "Difficulty class for the save",
 					Required:    true,
 				},
+				{
+					Name:        "consequence",
+					Description: "What happens on a failed save (e.g. 'stunned for 1 minute', 'knocked prone', 'half damage from fireball')",
+					Required:    false,
+				},
 			},
 		},
 		handleResolveSavePrompt,
@@ -146,6 +152,7 @@ func handleResolveSavePrompt(ctx context.Context, req *mcp.GetPromptRequest) (*m
 	monsterID := req.Params.Arguments["monster_id"]
 	saveType := req.Params.Arguments["save_type"]
 	dc := req.Params.Arguments["dc"]
+	consequence := req.Params.Arguments["consequence"]
 
 	// Fetch monster info from combat state
 	cs := tools.GetCombatState()
@@ -179,7 +186,7 @@ Process:
 6. If legendary resistance is not used or unavailable:
    - Apply the full effect of the failed save
 
-Use the make_saving_throw tool to handle the dice roll and legendary resistance decision automatically.`,
+Use the make_saving_throw tool to handle the dice roll. It spends a legendary resistance on a failed save automatically; call it with use_legendary_resistance set to false to let the failure stand instead.`,
 		monster.Name,
 		monster.Name,
 		monsterID,
@@ -193,6 +200,8 @@ Use the make_saving_throw tool to handle the dice roll and legendary resistance
 		monster.LegendaryResistances,
 	)
 
+	content += "\n\n" + legendaryResistanceAdvice(monster, cs.RoundNumber, consequence)
+
 	return &mcp.GetPromptResult{
 		Description: "Instructions for resolving a save with legendary resistance",
 		Messages: []*mcp.PromptMessage{
@@ -204,6 +213,74 @@ Use the make_saving_throw tool to handle the dice roll and legendary resistance
 	}, nil
 }
 
+// Keywords used to judge how badly a failed save hurts the monster
+var (
+	severeConsequences = []string{"stun", "paraly", "petrif", "banish", "polymorph", "dominat", "incapacitat",
+		"unconscious", "hold monster", "disintegrat", "feeblemind", "charm", "restrain", "maze", "forcecage", "death"}
+	minorConsequences = []string{"prone", "half", "push", "deafen", "disadvantage", "slow", "damage"}
+)
+
+// classifyConsequence rates a failed-save consequence as severe, moderate, or minor
+func classifyConsequence(consequence string) string {
+	lower := strings.ToLower(consequence)
+	for _, kw := range severeConsequences {
+		if strings.Contains(lower, kw) {
+			return "severe"
+		}
+	}
+	for _, kw := range minorConsequences {
+		if strings.Contains(lower, kw) {
+			return "minor"
+		}
+	}
+	return "moderate"
+}
+
+// legendaryResistanceAdvice recommends whether to spend a legendary resistance on a
+// failed save, weighing the consequence against the remaining budget and encounter pace
+func legendaryResistanceAdvice(monster *tools.Entity, round int, consequence string) string {
+	remaining := monster.LegendaryResistances
+	hpPercent := float64(monster.CurrentHP) / float64(monster.MaxHP) * 100
+
+	if consequence == "" {
+		consequence = "unspecified effect"
+	}
+	severity := classifyConsequence(consequence)
+
+	advice := fmt.Sprintf(`Legendary Resistance Budget:
+- Consequence of failing: %s (rated %s)
+- Resistances remaining: %d
+- Encounter pace: round %d, monster at %.0f%% HP
+
+Recommendation if the save fails:
+`, consequence, severity, remaining, round, hpPercent)
+
+	switch {
+	case remaining == 0:
+		advice += fmt.Sprintf("No legendary resistances remain, so the failed save stands. Plan %s's next turn around being %s.", monster.Name, consequence)
+	case severity == "severe":
+		advice += fmt.Sprintf("SPEND IT. A %s effect can end %s's part in the fight outright, which is exactly what legendary resistance exists to prevent. %d will remain afterwards.",
+			consequence, monster.Name, remaining-1)
+	case severity == "minor" && remaining == 1 && round <= 3:
+		advice += fmt.Sprintf("HOLD IT. This is the last resistance and it's only round %d; the party still has its best save-or-suck spells in reserve. Take the %s and keep the resistance for something that would actually end the fight: call make_saving_throw with use_legendary_resistance set to false.",
+			round, consequence)
+	case severity == "minor" && hpPercent > 25:
+		advice += fmt.Sprintf("ACCEPT THE EFFECT. %s is a minor setback and %s is still at %.0f%% HP. Resistances are better spent against disabling effects, so call make_saving_throw with use_legendary_resistance set to false.",
+			consequence, monster.Name, hpPercent)
+	case severity == "minor":
+		advice += fmt.Sprintf("SPEND IT ONLY IF the %s would finish %s off; at %.0f%% HP even small effects can decide the fight. Otherwise call make_saving_throw with use_legendary_resistance set to false.",
+			consequence, monster.Name, hpPercent)
+	case remaining >= 2 || round >= 4 || hpPercent <= 50:
+		advice += fmt.Sprintf("SPEND IT. With %d remaining at round %d the budget can absorb this, and a %s effect costs the monster real action economy.",
+			remaining, round, consequence)
+	default:
+		advice += fmt.Sprintf("LEAN TOWARD HOLDING. It's early (round %d) and this is the last resistance; spend it on %s only if losing a turn would leave it exposed to a focused party, and otherwise call make_saving_throw with use_legendary_resistance set to false.",
+			round, consequence)
+	}
+
+	return advice
+}
+
 // handleLegendaryActionPrompt suggests optimal legendary action usage
 func handleLegendaryActionPrompt(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
 	monsterID := req.Params.Arguments["monster_id"]
@@ -547,19 +624,17 @@ Targets: %s
 Save DC: %s
 
 Efficient Resolution Process:
-1. For each target in the list:
-   a. Roll saving throw using make_saving_throw tool
-   b. Record result (success/failure)
-   c. Note if legendary resistance was used
+1. Resolve the whole ability with a single resolve_aoe call:
+   - The damage is rolled once and shared by every target
+   - Failed saves: full damage
+   - Successful saves: half damage, or none with no_damage_on_success
+   - Legendary resistance and resistances are applied automatically
 
-2. Apply effects based on save results:
-   - Failed saves: full effect
-   - Successful saves: usually half damage or no effect
-   - Check ability description for specific outcomes
+2. Use save_for_damage instead for a single target or a target needing a
+   different damage amount
 
-3. Apply damage to all targets using apply_damage tool:
-   - Failed saves: full damage
-   - Successful saves: half damage (if applicable)
+3. For effects without damage, roll make_saving_throw and apply conditions to
+   the targets that fail, checking the ability description for specific outcomes
 
 4. Update combat status using next_turn tool if this ends the current action
 
@@ -570,14 +645,9 @@ Batch Processing Template:
 		saveDC,
 	)
 
-	// Add individual target sections
+	// Add the single batched call
 	content += `
-For each target:
-  make_saving_throw(entity_id: [target_id], save_type: [save_type], dc: [dc])
-  IF save failed:
-    apply_damage(target_id: [target_id], damage: [full_damage], damage_type: [type])
-  ELSE:
-    apply_damage(target_id: [target_id], damage: [half_damage], damage_type: [type])
+resolve_aoe(target_ids: [target_ids], save_type: [save_type], dc: [dc], damage_dice: [dice], damage_type: [type])
 
 This batch approach minimizes tool calls while maintaining accuracy.`
 
@@ -3,7 +3,13 @@ package resources
 import (
 	"context"
 	"encoding/json"
-	"reflect"
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -16,10 +22,12 @@ type MonsterStat struct {
 	Type                  string              `json:"type"`
 	Alignment             string              `json:"alignment"`
 	HP                    int                 `json:"hp"`
+	HitDice               string              `json:"hit_dice,omitempty"`
 	AC                    int                 `json:"ac"`
 	Speed                 map[string]int      `json:"speed"`
 	AbilityScores         map[string]int      `json:"ability_scores"`
 	SavingThrows          map[string]int      `json:"saving_throws"`
+	SaveProficiencies     []string            `json:"save_proficiencies,omitempty"` // proficient saves without a listed bonus
 	Skills                map[string]int      `json:"skills"`
 	DamageResistances     []string            `json:"damage_resistances"`
 	DamageImmunities      []string            `json:"damage_immunities"`
@@ -82,9 +90,9 @@ type DamageRules struct {
 // RegisterCombatResources adds all SRD data resources to the server
 func RegisterCombatResources(server *mcp.Server) {
 	// Resource 1: Monster Stat Block by name
-	server.AddResource(
-		&mcp.Resource{
-			URI:         "monster://stat_block/{name}",
+	server.AddResourceTemplate(
+		&mcp.ResourceTemplate{
+			URITemplate: "monster://stat_block/{name}",
 			Name:        "monster_stat_block",
 			Description: "Retrieve complete SRD stat block for a monster by name",
 			MIMEType:    "application/json",
@@ -141,59 +149,97 @@ func RegisterCombatResources(server *mcp.Server) {
 		&mcp.Resource{
 			URI:         "srd://monsters/list",
 			Name:        "monster_list",
-			Description: "List of all available SRD monsters",
+			Description: "List of all available SRD monsters with CR, XP, type, and stat block URI",
+			MIMEType:    "application/json",
+		},
+		adaptStringHandler(handleMonsterList),
+	)
+
+	// Resource 7: Published combat snapshots
+	server.AddResourceTemplate(
+		&mcp.ResourceTemplate{
+			URITemplate: "combat://snapshot/{id}",
+			Name:        "combat_snapshot",
+			Description: "Read-only combat state published with the publish_snapshot tool",
+			MIMEType:    "application/json",
+		},
+		adaptStringHandler(handleCombatSnapshot),
+	)
+
+	// Resource 8: Monsters grouped by creature type
+	server.AddResource(
+		&mcp.Resource{
+			URI:         "srd://monsters/by_type",
+			Name:        "monsters_by_type",
+			Description: "Catalog monsters grouped by creature type, with CR, XP, and stat block URI",
+			MIMEType:    "application/json",
+		},
+		adaptStringHandler(handleMonstersByType),
+	)
+
+	// Resource 9: A single condition definition by name
+	server.AddResourceTemplate(
+		&mcp.ResourceTemplate{
+			URITemplate: "srd://rules/conditions/{name}",
+			Name:        "condition_by_name",
+			Description: "One D&D 5e condition's definition and mechanical effects, matched case-insensitively",
+			MIMEType:    "application/json",
+		},
+		adaptStringHandler(handleConditionByName),
+	)
+
+	// Resource 10: The live combat state
+	server.AddResource(
+		&mcp.Resource{
+			URI:         "combat://state/current",
+			Name:        "combat_state",
+			Description: "The active combat state as JSON: entities, initiative order, round, and pending effects",
+			MIMEType:    "application/json",
+		},
+		adaptStringHandler(handleCurrentCombatState),
+	)
+
+	// Resource 11: Challenge rating to XP table
+	server.AddResource(
+		&mcp.Resource{
+			URI:         "srd://rules/cr_xp",
+			Name:        "cr_xp",
+			Description: "Experience points awarded for each challenge rating, from CR 0 to CR 30",
+			MIMEType:    "application/json",
+		},
+		adaptStringHandler(handleCRXP),
+	)
+
+	// Resource 12: Monster list narrowed by query parameters
+	server.AddResourceTemplate(
+		&mcp.ResourceTemplate{
+			URITemplate: "srd://monsters/list{?type,cr_min,cr_max,name}",
+			Name:        "monster_search",
+			Description: "Monsters matching a creature type, CR range, and name substring, e.g. srd://monsters/list?type=dragon&cr_min=10",
 			MIMEType:    "application/json",
 		},
 		adaptStringHandler(handleMonsterList),
 	)
 }
 
+// combatStateSource serializes the active combat state, returning nil when no combat
+// is running. The tools package owns the state, and sets this with SetCombatStateSource
+// because resources can't import it.
+var combatStateSource func() ([]byte, error)
+
+// SetCombatStateSource sets where combat://state/current reads the live combat state from
+func SetCombatStateSource(source func() ([]byte, error)) {
+	combatStateSource = source
+}
+
 // adaptStringHandler converts an existing handler that returns (string, error)
-// into the updated `mcp.ResourceHandler` signature.
+// into the `mcp.ResourceHandler` signature, passing it the requested URI and
+// serving its output as JSON text.
 func adaptStringHandler(h func(context.Context, string) (string, error)) mcp.ResourceHandler {
 	return func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
-		// Extract a URI-like string from the request using reflection so the
-		// adapter works with different SDK shapes.
 		var uri string
-		if req != nil {
-			rv := reflect.ValueOf(req)
-			if rv.Kind() == reflect.Ptr {
-				rv = rv.Elem()
-			}
-			if rv.IsValid() {
-				for i := 0; i < rv.NumField(); i++ {
-					f := rv.Field(i)
-					if !f.IsValid() {
-						continue
-					}
-					switch f.Kind() {
-					case reflect.String:
-						s := f.String()
-						if strings.Contains(s, "://") || strings.Contains(s, "/") {
-							uri = s
-						}
-					case reflect.Map:
-						found := false
-						for _, k := range f.MapKeys() {
-							v := f.MapIndex(k)
-							if v.Kind() == reflect.String {
-								s := v.String()
-								if strings.Contains(s, "://") || strings.Contains(s, "/") {
-									uri = s
-									found = true
-									break
-								}
-							}
-						}
-						if found {
-							// uri set above
-						}
-					}
-					if uri != "" {
-						break
-					}
-				}
-			}
+		if req != nil && req.Params != nil {
+			uri = req.Params.URI
 		}
 
 		str, err := h(ctx, uri)
@@ -201,64 +247,25 @@ func adaptStringHandler(h func(context.Context, string) (string, error)) mcp.Res
 			return nil, err
 		}
 
-		// Build result via reflection so we don't depend on exact field names
-		// used by different SDK versions.
-		res := new(mcp.ReadResourceResult)
-		rv := reflect.ValueOf(res).Elem()
-		// Set first []byte field we find, or first string field as fallback.
-		setString := false
-		for i := 0; i < rv.NumField(); i++ {
-			f := rv.Field(i)
-			if !f.CanSet() {
-				continue
-			}
-			switch f.Kind() {
-			case reflect.Slice:
-				if f.Type().Elem().Kind() == reflect.Uint8 {
-					f.SetBytes([]byte(str))
-					setString = true
-				}
-			case reflect.String:
-				if !setString {
-					f.SetString(str)
-					setString = true
-				}
-			}
-			if setString {
-				break
-			}
-		}
-
-		// Try to set a MIME-like field if present.
-		for i := 0; i < rv.NumField(); i++ {
-			f := rv.Field(i)
-			if !f.CanSet() || f.Kind() != reflect.String {
-				continue
-			}
-			name := rv.Type().Field(i).Name
-			lname := strings.ToLower(name)
-			if strings.Contains(lname, "mime") || strings.Contains(lname, "contenttype") || strings.Contains(lname, "type") {
-				f.SetString("application/json")
-				break
-			}
-		}
-
-		return res, nil
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{{
+				URI:      uri,
+				MIMEType: "application/json",
+				Text:     str,
+			}},
+		}, nil
 	}
 }
 
-// handleMonsterStatBlock returns a complete monster stat block
-func handleMonsterStatBlock(ctx context.Context, uri string) (string, error) {
-	// Parse monster name from URI (simplified)
-	// In production, would parse from "monster://stat_block/{name}"
-
-	// Example: Ancient Red Dragon
-	dragon := MonsterStat{
+// monsterCatalog holds every stat block the server can serve, keyed by monster name
+var monsterCatalog = map[string]MonsterStat{
+	"Ancient Red Dragon": {
 		Name:      "Ancient Red Dragon",
 		Size:      "Gargantuan",
 		Type:      "dragon",
 		Alignment: "chaotic evil",
 		HP:        546,
+		HitDice:   "28d20+252",
 		AC:        22,
 		Speed: map[string]int{
 			"walk":  40,
@@ -301,9 +308,24 @@ func handleMonsterStatBlock(ctx context.Context, uri string) (string, error) {
 				DamageType:  "piercing",
 				DamageDice:  "2d10+10",
 			},
+			{
+				Name:        "Claw",
+				AttackBonus: 17,
+				DamageType:  "slashing",
+				DamageDice:  "2d6+10",
+			},
+			{
+				Name:        "Tail",
+				Description: "Reach 20 ft., one target.",
+				AttackBonus: 17,
+				DamageType:  "bludgeoning",
+				DamageDice:  "2d8+10",
+			},
 			{
 				Name:        "Fire Breath",
 				Description: "The dragon exhales fire in a 90-foot cone. Each creature must make a DC 24 Dexterity saving throw, taking 91 (26d6) fire damage on a failed save, or half as much on a successful one.",
+				DamageType:  "fire",
+				DamageDice:  "26d6",
 				SaveDC:      24,
 				SaveType:    "DEX",
 			},
@@ -335,15 +357,14 @@ func handleMonsterStatBlock(ctx context.Context, uri string) (string, error) {
 				SaveType:    "DEX",
 			},
 		},
-	}
-
-	// Additional monsters would be stored in a data structure or loaded from files
-	goblin := MonsterStat{
+	},
+	"Goblin": {
 		Name:      "Goblin",
 		Size:      "Small",
 		Type:      "humanoid",
 		Alignment: "neutral evil",
 		HP:        7,
+		HitDice:   "2d6",
 		AC:        15,
 		Speed: map[string]int{
 			"walk": 30,
@@ -374,22 +395,200 @@ func handleMonsterStatBlock(ctx context.Context, uri string) (string, error) {
 				DamageDice:  "1d6+2",
 			},
 		},
+	},
+}
+
+// GetMonster looks up a monster stat block in the catalog by name
+func GetMonster(name string) (MonsterStat, bool) {
+	monster, ok := monsterCatalog[name]
+	return monster, ok
+}
+
+// Abilities lists the six ability scores in stat block order
+var Abilities = []string{"STR", "DEX", "CON", "INT", "WIS", "CHA"}
+
+// AbilityModifier converts an ability score to its modifier
+func AbilityModifier(score int) int {
+	// Floor division so that odd scores below 10 round down (9 -> -1)
+	if score < 10 {
+		return (score - 11) / 2
+	}
+	return (score - 10) / 2
+}
+
+// ProficiencyBonus derives the monster's proficiency bonus from its challenge rating
+// per the SRD table: +2 up to CR 4, rising by 1 every 4 CR to +9 at CR 29-30
+func (m MonsterStat) ProficiencyBonus() int {
+	level := max(int(math.Ceil(m.ChallengeRating)), 1)
+	return 2 + (level-1)/4
+}
+
+// SaveBonus returns the monster's bonus to saves of the given ability: the listed
+// bonus for a proficient save, the ability modifier plus the proficiency bonus for a
+// save proficiency without one, and the ability modifier otherwise
+func (m MonsterStat) SaveBonus(ability string) (bonus int, proficient bool) {
+	ability = strings.ToUpper(ability)
+	if bonus, ok := m.SavingThrows[ability]; ok {
+		return bonus, true
+	}
+	modifier := AbilityModifier(m.AbilityScores[ability])
+	if slices.Contains(m.SaveProficiencies, ability) {
+		return modifier + m.ProficiencyBonus(), true
+	}
+	return modifier, false
+}
+
+// ProficientSaves returns the total bonus for each of the monster's proficient saves
+func (m MonsterStat) ProficientSaves() map[string]int {
+	saves := make(map[string]int)
+	for _, ability := range Abilities {
+		if bonus, proficient := m.SaveBonus(ability); proficient {
+			saves[ability] = bonus
+		}
+	}
+	return saves
+}
+
+// legendaryResistancePerDay finds the uses in a trait such as "Legendary Resistance (3/Day)"
+var legendaryResistancePerDay = regexp.MustCompile(`(?i)(\d+)\s*/\s*day`)
+
+// LegendaryResistances returns how many legendary resistances the monster has per
+// day, read from its Legendary Resistance trait, or 0 without one. A trait that
+// doesn't give a count has the usual 3.
+func (m MonsterStat) LegendaryResistances() int {
+	for _, trait := range m.Traits {
+		if !strings.HasPrefix(strings.ToLower(trait.Name), "legendary resistance") {
+			continue
+		}
+		if match := legendaryResistancePerDay.FindStringSubmatch(trait.Name + " " + trait.Description); match != nil {
+			count, _ := strconv.Atoi(match[1])
+			return count
+		}
+		return 3
+	}
+	return 0
+}
+
+// crXP is the SRD experience point award for each challenge rating
+var crXP = map[float64]int{
+	0: 10, 0.125: 25, 0.25: 50, 0.5: 100,
+	1: 200, 2: 450, 3: 700, 4: 1100, 5: 1800,
+	6: 2300, 7: 2900, 8: 3900, 9: 5000, 10: 5900,
+	11: 7200, 12: 8400, 13: 10000, 14: 11500, 15: 13000,
+	16: 15000, 17: 18000, 18: 20000, 19: 22000, 20: 25000,
+	21: 33000, 22: 41000, 23: 50000, 24: 62000, 25: 75000,
+	26: 90000, 27: 105000, 28: 120000, 29: 135000, 30: 155000,
+}
+
+// XPForCR returns the experience points a monster of the challenge rating is worth,
+// or 0 for a challenge rating not on the table
+func XPForCR(cr float64) int {
+	return crXP[cr]
+}
+
+// FormatCR writes a challenge rating the way stat blocks do, e.g. 1/4
+func FormatCR(cr float64) string {
+	switch cr {
+	case 0.125:
+		return "1/8"
+	case 0.25:
+		return "1/4"
+	case 0.5:
+		return "1/2"
+	}
+	return fmt.Sprintf("%g", cr)
+}
+
+// handleCRXP returns the CR to XP table in order of challenge rating
+func handleCRXP(ctx context.Context, uri string) (string, error) {
+	type crEntry struct {
+		CR    float64 `json:"cr"`
+		Label string  `json:"label"`
+		XP    int     `json:"xp"`
+	}
+
+	ratings := make([]float64, 0, len(crXP))
+	for cr := range crXP {
+		ratings = append(ratings, cr)
+	}
+	sort.Float64s(ratings)
+	table := make([]crEntry, 0, len(ratings))
+	for _, cr := range ratings {
+		table = append(table, crEntry{CR: cr, Label: FormatCR(cr), XP: crXP[cr]})
+	}
+
+	data, err := json.MarshalIndent(table, "", "  ")
+	if err != nil {
+		return "", err
 	}
 
-	// Simple name matching (production would use proper URI parsing)
-	monsters := map[string]MonsterStat{
-		"Ancient Red Dragon": dragon,
-		"Goblin":             goblin,
+	return string(data), nil
+}
+
+// MonsterFilter narrows the catalog by creature type, challenge rating, and name; nil
+// bounds are open
+type MonsterFilter struct {
+	Type  string
+	MinCR *float64
+	MaxCR *float64
+	Name  string // case-insensitive substring of the monster's name
+}
+
+// Matches reports whether a monster satisfies the filter
+func (f MonsterFilter) Matches(m MonsterStat) bool {
+	if f.Type != "" && !strings.EqualFold(m.Type, f.Type) {
+		return false
+	}
+	if f.MinCR != nil && m.ChallengeRating < *f.MinCR {
+		return false
 	}
+	if f.MaxCR != nil && m.ChallengeRating > *f.MaxCR {
+		return false
+	}
+	if f.Name != "" && !strings.Contains(strings.ToLower(m.Name), strings.ToLower(f.Name)) {
+		return false
+	}
+	return true
+}
 
-	// Return requested monster or dragon as default
-	result := dragon
-	for name, monster := range monsters {
-		if name == uri {
-			result = monster
-			break
+// FilterMonsters returns the catalog monsters matching the filter, sorted by name
+func FilterMonsters(f MonsterFilter) []MonsterStat {
+	matches := []MonsterStat{}
+	for _, monster := range monsterCatalog {
+		if f.Matches(monster) {
+			matches = append(matches, monster)
 		}
 	}
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].Name < matches[j].Name
+	})
+	return matches
+}
+
+// handleMonsterStatBlock returns a complete monster stat block
+func handleMonsterStatBlock(ctx context.Context, uri string) (string, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return "", fmt.Errorf("invalid stat block URI: %w", err)
+	}
+	if parsed.Scheme != "monster" || parsed.Host != "stat_block" {
+		return "", fmt.Errorf("not a stat block URI: %s", uri)
+	}
+	// url.Parse has already decoded escapes like %20 in the path
+	name := strings.TrimPrefix(parsed.Path, "/")
+
+	result, ok := monsterCatalog[name]
+	if !ok {
+		for catalogName, monster := range monsterCatalog {
+			if strings.EqualFold(catalogName, name) {
+				result, ok = monster, true
+				break
+			}
+		}
+	}
+	if !ok {
+		return "", fmt.Errorf("monster not found: %s", name)
+	}
 
 	data, err := json.MarshalIndent(result, "", "  ")
 	if err != nil {
@@ -399,21 +598,22 @@ func handleMonsterStatBlock(ctx context.Context, uri string) (string, error) {
 	return string(data), nil
 }
 
+// SRDDamageRules are the damage multipliers the combat tools apply
+var SRDDamageRules = DamageRules{
+	ResistanceMultiplier:    0.5,
+	VulnerabilityMultiplier: 2.0,
+	ImmunityEffect:          "no damage taken",
+	CriticalMultiplier:      2,
+	ConditionEffects: map[string]string{
+		"resistance":    "Damage of specified type is halved",
+		"vulnerability": "Damage of specified type is doubled",
+		"immunity":      "No damage of specified type is taken",
+	},
+}
+
 // handleDamageRules returns SRD damage calculation rules
 func handleDamageRules(ctx context.Context, uri string) (string, error) {
-	rules := DamageRules{
-		ResistanceMultiplier:    0.5,
-		VulnerabilityMultiplier: 2.0,
-		ImmunityEffect:          "no damage taken",
-		CriticalMultiplier:      2,
-		ConditionEffects: map[string]string{
-			"resistance":    "Damage of specified type is halved",
-			"vulnerability": "Damage of specified type is doubled",
-			"immunity":      "No damage of specified type is taken",
-		},
-	}
-
-	data, err := json.MarshalIndent(rules, "", "  ")
+	data, err := json.MarshalIndent(SRDDamageRules, "", "  ")
 	if err != nil {
 		return "", err
 	}
@@ -427,52 +627,246 @@ type ConditionDefinition struct {
 	Description  string   `json:"description"`
 	Effects      []string `json:"effects"`
 	EndCondition string   `json:"end_condition"`
+	// Conditions like petrified don't expire and end only through specific magic
+	MagicRemovalOnly bool `json:"magic_removal_only,omitempty"`
+	// Roll modifiers the combat tools apply automatically: RollAdvantage or RollDisadvantage
+	OwnAttacks           string            `json:"own_attacks,omitempty"`            // the creature's attack rolls
+	AttacksAgainst       string            `json:"attacks_against,omitempty"`        // attack rolls against the creature
+	RangedAttacksAgainst string            `json:"ranged_attacks_against,omitempty"` // replaces AttacksAgainst for attackers more than 5 feet away
+	AbilityChecks        string            `json:"ability_checks,omitempty"`
+	Saves                map[string]string `json:"saves,omitempty"`               // ability -> RollDisadvantage or SaveAutoFail
+	CritsWithin5Feet     bool              `json:"crits_within_5_feet,omitempty"` // hits from within 5 feet are critical hits
+}
+
+// Roll modifier values used in ConditionDefinition
+const (
+	RollAdvantage    = "advantage"
+	RollDisadvantage = "disadvantage"
+	SaveAutoFail     = "auto_fail"
+)
+
+// strDexAutoFail is the save rule shared by the conditions that leave a creature helpless
+var strDexAutoFail = map[string]string{"STR": SaveAutoFail, "DEX": SaveAutoFail}
+
+// conditionDefinitions is the full SRD condition set in alphabetical order
+var conditionDefinitions = []ConditionDefinition{
+	{
+		Name:        "Blinded",
+		Description: "A blinded creature can't see and automatically fails any ability check that requires sight.",
+		Effects: []string{
+			"Automatically fails ability checks that require sight",
+			"Attack rolls against the creature have advantage",
+			"The creature's attack rolls have disadvantage",
+		},
+		EndCondition:   "End of specified duration or until condition is removed",
+		OwnAttacks:     RollDisadvantage,
+		AttacksAgainst: RollAdvantage,
+	},
+	{
+		Name:        "Charmed",
+		Description: "A charmed creature can't attack the charmer or target the charmer with harmful abilities or magical effects.",
+		Effects: []string{
+			"Can't attack or target the charmer with harmful abilities or magical effects",
+			"The charmer has advantage on ability checks to interact socially with the creature",
+		},
+		EndCondition: "End of specified duration or until condition is removed",
+	},
+	{
+		Name:        "Deafened",
+		Description: "A deafened creature can't hear and automatically fails any ability check that requires hearing.",
+		Effects: []string{
+			"Automatically fails ability checks that require hearing",
+		},
+		EndCondition: "End of specified duration or until condition is removed",
+	},
+	{
+		Name:        "Exhaustion",
+		Description: "Exhaustion is measured in six levels; effects are cumulative.",
+		Effects: []string{
+			"Level 1: Disadvantage on ability checks",
+			"Level 2: Speed halved",
+			"Level 3: Disadvantage on attack rolls and saving throws",
+			"Level 4: Hit point maximum halved",
+			"Level 5: Speed reduced to 0",
+			"Level 6: Death",
+		},
+		EndCondition: "Finishing a long rest reduces exhaustion by one level, with food and drink",
+	},
+	{
+		Name:        "Frightened",
+		Description: "A frightened creature has disadvantage on ability checks and attack rolls while the source of its fear is within line of sight.",
+		Effects: []string{
+			"Disadvantage on ability checks and attack rolls while the source of fear is in sight",
+			"Can't willingly move closer to the source of its fear",
+		},
+		EndCondition:  "End of specified duration or until condition is removed",
+		OwnAttacks:    RollDisadvantage,
+		AbilityChecks: RollDisadvantage,
+	},
+	{
+		Name:        "Grappled",
+		Description: "A grappled creature's speed becomes 0, and it can't benefit from any bonus to its speed.",
+		Effects: []string{
+			"Speed becomes 0",
+			"Ends if the grappler is incapacitated",
+			"Ends if an effect removes the creature from the grappler's reach",
+		},
+		EndCondition: "Escape with an Athletics or Acrobatics check, or the grappler releases or is incapacitated",
+	},
+	{
+		Name:        "Incapacitated",
+		Description: "An incapacitated creature can't take actions or reactions.",
+		Effects: []string{
+			"Can't take actions or reactions",
+		},
+		EndCondition: "End of specified duration or until condition is removed",
+	},
+	{
+		Name:        "Invisible",
+		Description: "An invisible creature is impossible to see without the aid of magic or a special sense.",
+		Effects: []string{
+			"Heavily obscured for the purpose of hiding",
+			"Attack rolls against the creature have disadvantage",
+			"The creature's attack rolls have advantage",
+		},
+		EndCondition:   "End of specified duration or until condition is removed",
+		OwnAttacks:     RollAdvantage,
+		AttacksAgainst: RollDisadvantage,
+	},
+	{
+		Name:        "Paralyzed",
+		Description: "A paralyzed creature is incapacitated and can't move or speak.",
+		Effects: []string{
+			"Automatically fails Strength and Dexterity saving throws",
+			"Attack rolls against the creature have advantage",
+			"Any attack that hits is a critical hit if attacker is within 5 feet",
+		},
+		EndCondition:     "End of specified duration or until condition is removed",
+		AttacksAgainst:   RollAdvantage,
+		Saves:            strDexAutoFail,
+		CritsWithin5Feet: true,
+	},
+	{
+		Name:        "Petrified",
+		Description: "A petrified creature is transformed, along with any nonmagical object it is wearing or carrying, into a solid inanimate substance. It is incapacitated, can't move or speak, and is unaware of its surroundings.",
+		Effects: []string{
+			"Automatically fails Strength and Dexterity saving throws",
+			"Attack rolls against the creature have advantage",
+			"Resistance to all damage",
+			"Immune to poison and disease",
+		},
+		EndCondition:     "Only greater restoration, stone to flesh, or similar magic; it has no duration",
+		MagicRemovalOnly: true,
+		AttacksAgainst:   RollAdvantage,
+		Saves:            strDexAutoFail,
+	},
+	{
+		Name:        "Poisoned",
+		Description: "A poisoned creature has disadvantage on attack rolls and ability checks.",
+		Effects: []string{
+			"Disadvantage on attack rolls",
+			"Disadvantage on ability checks",
+		},
+		EndCondition:  "End of poison duration",
+		OwnAttacks:    RollDisadvantage,
+		AbilityChecks: RollDisadvantage,
+	},
+	{
+		Name:        "Prone",
+		Description: "A prone creature's only movement option is to crawl.",
+		Effects: []string{
+			"Disadvantage on attack rolls",
+			"Attack rolls against creature have advantage if attacker is within 5 feet",
+			"Attack rolls against creature have disadvantage if attacker is more than 5 feet away",
+		},
+		EndCondition:         "Use half movement to stand up",
+		OwnAttacks:           RollDisadvantage,
+		AttacksAgainst:       RollAdvantage,
+		RangedAttacksAgainst: RollDisadvantage,
+	},
+	{
+		Name:        "Restrained",
+		Description: "A restrained creature's speed becomes 0, and it can't benefit from any bonus to its speed.",
+		Effects: []string{
+			"Speed becomes 0",
+			"Attack rolls against the creature have advantage",
+			"The creature's attack rolls have disadvantage",
+			"Disadvantage on Dexterity saving throws",
+		},
+		EndCondition:   "End of specified duration, or escape as the restraining effect allows",
+		OwnAttacks:     RollDisadvantage,
+		AttacksAgainst: RollAdvantage,
+		Saves:          map[string]string{"DEX": RollDisadvantage},
+	},
+	{
+		Name:        "Stunned",
+		Description: "A stunned creature is incapacitated, can't move, and can speak only falteringly.",
+		Effects: []string{
+			"Automatically fails Strength and Dexterity saving throws",
+			"Attack rolls against the creature have advantage",
+		},
+		EndCondition:   "End of specified duration or until condition is removed",
+		AttacksAgainst: RollAdvantage,
+		Saves:          strDexAutoFail,
+	},
+	{
+		Name:        "Unconscious",
+		Description: "An unconscious creature is incapacitated, can't move or speak, and is unaware of its surroundings.",
+		Effects: []string{
+			"Drops whatever it's holding and falls prone",
+			"Automatically fails Strength and Dexterity saving throws",
+			"Attack rolls against the creature have advantage",
+			"Any attack that hits is a critical hit if attacker is within 5 feet",
+		},
+		EndCondition:     "Regaining hit points, or as the effect that caused it allows",
+		AttacksAgainst:   RollAdvantage,
+		Saves:            strDexAutoFail,
+		CritsWithin5Feet: true,
+	},
+}
+
+// GetCondition looks up a condition definition by name, ignoring case
+func GetCondition(name string) (ConditionDefinition, bool) {
+	for _, condition := range conditionDefinitions {
+		if strings.EqualFold(condition.Name, strings.TrimSpace(name)) {
+			return condition, true
+		}
+	}
+	return ConditionDefinition{}, false
+}
+
+// ConditionNames returns the name of every defined condition
+func ConditionNames() []string {
+	names := make([]string, 0, len(conditionDefinitions))
+	for _, condition := range conditionDefinitions {
+		names = append(names, condition.Name)
+	}
+	return names
 }
 
 // handleConditionRules returns all D&D 5e condition definitions
 func handleConditionRules(ctx context.Context, uri string) (string, error) {
-	conditions := []ConditionDefinition{
-		{
-			Name:        "Stunned",
-			Description: "A stunned creature is incapacitated, can't move, and can speak only falteringly.",
-			Effects: []string{
-				"Automatically fails Strength and Dexterity saving throws",
-				"Attack rolls against the creature have advantage",
-			},
-			EndCondition: "End of specified duration or until condition is removed",
-		},
-		{
-			Name:        "Prone",
-			Description: "A prone creature's only movement option is to crawl.",
-			Effects: []string{
-				"Disadvantage on attack rolls",
-				"Attack rolls against creature have advantage if attacker is within 5 feet",
-				"Attack rolls against creature have disadvantage if attacker is more than 5 feet away",
-			},
-			EndCondition: "Use half movement to stand up",
-		},
-		{
-			Name:        "Paralyzed",
-			Description: "A paralyzed creature is incapacitated and can't move or speak.",
-			Effects: []string{
-				"Automatically fails Strength and Dexterity saving throws",
-				"Attack rolls against the creature have advantage",
-				"Any attack that hits is a critical hit if attacker is within 5 feet",
-			},
-			EndCondition: "End of specified duration or until condition is removed",
-		},
-		{
-			Name:        "Poisoned",
-			Description: "A poisoned creature has disadvantage on attack rolls and ability checks.",
-			Effects: []string{
-				"Disadvantage on attack rolls",
-				"Disadvantage on ability checks",
-			},
-			EndCondition: "End of poison duration",
-		},
+	data, err := json.MarshalIndent(conditionDefinitions, "", "  ")
+	if err != nil {
+		return "", err
 	}
 
-	data, err := json.MarshalIndent(conditions, "", "  ")
+	return string(data), nil
+}
+
+// handleConditionByName returns a single condition definition
+func handleConditionByName(ctx context.Context, uri string) (string, error) {
+	name, err := url.PathUnescape(strings.TrimPrefix(uri, "srd://rules/conditions/"))
+	if err != nil {
+		return "", fmt.Errorf("invalid condition name: %w", err)
+	}
+
+	condition, ok := GetCondition(name)
+	if !ok {
+		return "", fmt.Errorf("unknown condition %q; valid conditions are %s", name, strings.Join(ConditionNames(), ", "))
+	}
+
+	data, err := json.MarshalIndent(condition, "", "  ")
 	if err != nil {
 		return "", err
 	}
@@ -566,33 +960,35 @@ func handleLegendaryRules(ctx context.Context, uri string) (string, error) {
 	return string(data), nil
 }
 
-// handleMonsterList returns a list of available monsters
+// handleMonsterList returns the catalog monsters sorted by CR then name, narrowed by
+// the type, cr_min, cr_max, and name query parameters when the URI has them
 func handleMonsterList(ctx context.Context, uri string) (string, error) {
-	monsters := []map[string]interface{}{
-		{
-			"name": "Ancient Red Dragon",
-			"cr":   24,
-			"type": "dragon",
-			"uri":  "monster://stat_block/Ancient%20Red%20Dragon",
-		},
-		{
-			"name": "Goblin",
-			"cr":   0.25,
-			"type": "humanoid",
-			"uri":  "monster://stat_block/Goblin",
-		},
-		{
-			"name": "Beholder",
-			"cr":   13,
-			"type": "aberration",
-			"uri":  "monster://stat_block/Beholder",
-		},
-		{
-			"name": "Lich",
-			"cr":   21,
-			"type": "undead",
-			"uri":  "monster://stat_block/Lich",
-		},
+	type monsterEntry struct {
+		Name string  `json:"name"`
+		CR   float64 `json:"cr"`
+		XP   int     `json:"xp"`
+		Type string  `json:"type"`
+		URI  string  `json:"uri"`
+	}
+
+	filter, err := monsterListFilter(uri)
+	if err != nil {
+		return "", err
+	}
+
+	matches := FilterMonsters(filter)
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].ChallengeRating < matches[j].ChallengeRating
+	})
+	monsters := []monsterEntry{}
+	for _, monster := range matches {
+		monsters = append(monsters, monsterEntry{
+			Name: monster.Name,
+			CR:   monster.ChallengeRating,
+			XP:   XPForCR(monster.ChallengeRating),
+			Type: monster.Type,
+			URI:  "monster://stat_block/" + url.PathEscape(monster.Name),
+		})
 	}
 
 	data, err := json.MarshalIndent(monsters, "", "  ")
@@ -602,3 +998,119 @@ func handleMonsterList(ctx context.Context, uri string) (string, error) {
 
 	return string(data), nil
 }
+
+// monsterListFilter reads a monster list URI's query parameters into a filter
+func monsterListFilter(uri string) (MonsterFilter, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return MonsterFilter{}, fmt.Errorf("invalid monster list URI: %w", err)
+	}
+	query := parsed.Query()
+
+	filter := MonsterFilter{
+		Type: strings.TrimSpace(query.Get("type")),
+		Name: strings.TrimSpace(query.Get("name")),
+	}
+	if filter.MinCR, err = crParam(query, "cr_min"); err != nil {
+		return MonsterFilter{}, err
+	}
+	if filter.MaxCR, err = crParam(query, "cr_max"); err != nil {
+		return MonsterFilter{}, err
+	}
+
+	return filter, nil
+}
+
+// crParam reads a challenge rating query parameter, returning nil when it is absent
+func crParam(query url.Values, param string) (*float64, error) {
+	value := strings.TrimSpace(query.Get(param))
+	if value == "" {
+		return nil, nil
+	}
+	cr, err := parseCR(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q: %w", param, value, err)
+	}
+	return &cr, nil
+}
+
+// parseCR reads a challenge rating written as a decimal or a fraction such as 1/4
+func parseCR(value string) (float64, error) {
+	if num, den, ok := strings.Cut(value, "/"); ok {
+		n, err := strconv.ParseFloat(num, 64)
+		if err != nil {
+			return 0, err
+		}
+		d, err := strconv.ParseFloat(den, 64)
+		if err != nil {
+			return 0, err
+		}
+		if d == 0 {
+			return 0, fmt.Errorf("division by zero")
+		}
+		return n / d, nil
+	}
+	return strconv.ParseFloat(value, 64)
+}
+
+// handleMonstersByType returns the catalog grouped by creature type, groups and
+// members both sorted alphabetically
+func handleMonstersByType(ctx context.Context, uri string) (string, error) {
+	type monsterEntry struct {
+		Name string  `json:"name"`
+		CR   float64 `json:"cr"`
+		XP   int     `json:"xp"`
+		URI  string  `json:"uri"`
+	}
+	type typeGroup struct {
+		Type     string         `json:"type"`
+		Count    int            `json:"count"`
+		Monsters []monsterEntry `json:"monsters"`
+	}
+
+	byType := make(map[string]*typeGroup)
+	for _, monster := range FilterMonsters(MonsterFilter{}) {
+		group := byType[monster.Type]
+		if group == nil {
+			group = &typeGroup{Type: monster.Type}
+			byType[monster.Type] = group
+		}
+		group.Monsters = append(group.Monsters, monsterEntry{
+			Name: monster.Name,
+			CR:   monster.ChallengeRating,
+			XP:   XPForCR(monster.ChallengeRating),
+			URI:  "monster://stat_block/" + url.PathEscape(monster.Name),
+		})
+		group.Count++
+	}
+
+	groups := make([]*typeGroup, 0, len(byType))
+	for _, group := range byType {
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Type < groups[j].Type })
+
+	data, err := json.MarshalIndent(groups, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// handleCurrentCombatState serves the live combat state, or a "no active combat"
+// document before combat starts
+func handleCurrentCombatState(ctx context.Context, uri string) (string, error) {
+	var data []byte
+	if combatStateSource != nil {
+		var err error
+		if data, err = combatStateSource(); err != nil {
+			return "", fmt.Errorf("serializing combat state: %w", err)
+		}
+	}
+	if data == nil {
+		data, _ = json.MarshalIndent(map[string]any{"active": false, "message": "no active combat"}, "", "  ")
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,97 @@
+package resources
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// MonsterDirEnv names the environment variable pointing at a directory of stat block files
+const MonsterDirEnv = "DM_MONSTER_DIR"
+
+// LoadMonsterDir loads every .json file in dir as a single MonsterStat and adds it to
+// the catalog, replacing a built-in stat block of the same name. Files that fail to
+// parse or validate are logged and skipped. It returns the number of monsters loaded.
+func LoadMonsterDir(dir string) (int, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return 0, fmt.Errorf("reading monster directory: %w", err)
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return 0, err
+	}
+
+	loaded := 0
+	for _, path := range paths {
+		monster, err := loadMonsterFile(path)
+		if err != nil {
+			log.Printf("Skipping monster file %s: %v", path, err)
+			continue
+		}
+		monsterCatalog[monster.Name] = monster
+		loaded++
+	}
+	return loaded, nil
+}
+
+// UnloadMonster removes the named stat block from the catalog, e.g. one added by
+// LoadMonsterDir
+func UnloadMonster(name string) {
+	delete(monsterCatalog, name)
+}
+
+// loadMonsterFile reads and validates one stat block file
+func loadMonsterFile(path string) (MonsterStat, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return MonsterStat{}, err
+	}
+
+	var monster MonsterStat
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&monster); err != nil {
+		return MonsterStat{}, fmt.Errorf("not a valid stat block: %w", err)
+	}
+	if err := monster.validate(); err != nil {
+		return MonsterStat{}, err
+	}
+	return monster, nil
+}
+
+// validate checks the fields the combat tools depend on
+func (m MonsterStat) validate() error {
+	if strings.TrimSpace(m.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if m.HP <= 0 {
+		return fmt.Errorf("%s: hit points must be positive", m.Name)
+	}
+	if m.AC <= 0 {
+		return fmt.Errorf("%s: armor class must be positive", m.Name)
+	}
+	if m.ChallengeRating < 0 {
+		return fmt.Errorf("%s: challenge rating can't be negative", m.Name)
+	}
+	for ability := range m.AbilityScores {
+		if !slices.Contains(Abilities, ability) {
+			return fmt.Errorf("%s: unknown ability %s", m.Name, ability)
+		}
+	}
+	for ability := range m.SavingThrows {
+		if !slices.Contains(Abilities, ability) {
+			return fmt.Errorf("%s: unknown saving throw %s", m.Name, ability)
+		}
+	}
+	for _, ability := range m.SaveProficiencies {
+		if !slices.Contains(Abilities, ability) {
+			return fmt.Errorf("%s: unknown saving throw %s", m.Name, ability)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package resources
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxSnapshots bounds how many published snapshots are kept in memory
+	maxSnapshots = 50
+	// snapshotTTL is how long a published snapshot stays readable
+	snapshotTTL = 24 * time.Hour
+)
+
+// storedSnapshot is a serialized combat state published for sharing
+type storedSnapshot struct {
+	data      []byte
+	createdAt time.Time
+}
+
+var (
+	snapshotMu sync.Mutex
+	snapshots  = make(map[string]storedSnapshot)
+)
+
+// PublishSnapshot stores serialized combat state under a newly generated ID and returns it.
+// Expired snapshots are dropped first, then the oldest ones if the store is full.
+func PublishSnapshot(data []byte) (string, error) {
+	buf := make([]byte, 6)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generating snapshot id: %w", err)
+	}
+	id := hex.EncodeToString(buf)
+
+	snapshotMu.Lock()
+	defer snapshotMu.Unlock()
+
+	now := time.Now()
+	for key, snap := range snapshots {
+		if now.Sub(snap.createdAt) > snapshotTTL {
+			delete(snapshots, key)
+		}
+	}
+	for len(snapshots) >= maxSnapshots {
+		oldestID := ""
+		for key, snap := range snapshots {
+			if oldestID == "" || snap.createdAt.Before(snapshots[oldestID].createdAt) {
+				oldestID = key
+			}
+		}
+		delete(snapshots, oldestID)
+	}
+
+	snapshots[id] = storedSnapshot{data: data, createdAt: now}
+	return id, nil
+}
+
+// GetSnapshot returns a published snapshot by ID, if it exists and hasn't expired
+func GetSnapshot(id string) ([]byte, bool) {
+	snapshotMu.Lock()
+	defer snapshotMu.Unlock()
+
+	snap, ok := snapshots[id]
+	if !ok {
+		return nil, false
+	}
+	if time.Since(snap.createdAt) > snapshotTTL {
+		delete(snapshots, id)
+		return nil, false
+	}
+	return snap.data, true
+}
+
+// handleCombatSnapshot serves a published combat snapshot read-only
+func handleCombatSnapshot(ctx context.Context, uri string) (string, error) {
+	id := strings.TrimPrefix(uri, "combat://snapshot/")
+
+	data, ok := GetSnapshot(id)
+	if !ok {
+		return "", fmt.Errorf("snapshot not found or expired: %s", id)
+	}
+
+	return string(data), nil
+}
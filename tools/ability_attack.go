@@ -0,0 +1,148 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kiriyms/dungeon-master-mcp/resources"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// AbilityAttackInput defines resolving a stat block action by attack roll or saving throw
+type AbilityAttackInput struct {
+	EncounterScope
+	AttackerID string `json:"attacker_id"`
+	TargetID   string `json:"target_id"`
+	ActionName string `json:"action_name" jsonschema:"Stat block action, e.g. Claw or Fire Breath"`
+	Mode       string `json:"mode,omitempty" jsonschema:"attack or save (defaults from the action: a save DC without an attack bonus means save)"`
+	// Save-mode options
+	HalfOnSuccess *bool `json:"half_on_success,omitempty" jsonschema:"Whether a successful save takes half damage (defaults to true)"`
+	// Rider applied on a hit or a failed save
+	Condition         string `json:"condition,omitempty" jsonschema:"Condition imposed on a hit or failed save"`
+	ConditionDuration int    `json:"condition_duration,omitempty" jsonschema:"Condition duration in turns, -1 for permanent (defaults to 1)"`
+}
+
+type AbilityAttackOutput struct {
+	Mode             string        `json:"mode" jsonschema:"Resolution path used: attack or save"`
+	Attack           *AttackResult `json:"attack,omitempty"`
+	SaveRoll         int           `json:"save_roll,omitempty"`
+	SaveTotal        int           `json:"save_total,omitempty"`
+	SaveSuccess      bool          `json:"save_success,omitempty"`
+	Damage           int           `json:"damage"`
+	ConditionApplied string        `json:"condition_applied,omitempty"`
+	RemainingHP      int           `json:"remaining_hp"`
+	Message          string        `json:"message"`
+}
+
+func handleAbilityAttack(ctx context.Context, req *mcp.CallToolRequest, input AbilityAttackInput) (*mcp.CallToolResult, AbilityAttackOutput, error) {
+	attacker := combatState.Entities[input.AttackerID]
+	if attacker == nil {
+		return nil, AbilityAttackOutput{}, fmt.Errorf("attacker not found: %s", input.AttackerID)
+	}
+	target := combatState.Entities[input.TargetID]
+	if target == nil {
+		return nil, AbilityAttackOutput{}, fmt.Errorf("target not found: %s", input.TargetID)
+	}
+	if attacker.IsIncapacitated() {
+		return nil, AbilityAttackOutput{}, fmt.Errorf("%s is incapacitated and can't take actions", attacker.Name)
+	}
+
+	monster, ok := resources.GetMonster(attacker.MonsterName)
+	if !ok {
+		return nil, AbilityAttackOutput{}, fmt.Errorf("no stat block loaded for %s", attacker.ID)
+	}
+	var action *resources.MonsterAction
+	for i := range monster.Actions {
+		if strings.EqualFold(monster.Actions[i].Name, input.ActionName) {
+			action = &monster.Actions[i]
+			break
+		}
+	}
+	if action == nil {
+		return nil, AbilityAttackOutput{}, fmt.Errorf("%s has no action named %s", monster.Name, input.ActionName)
+	}
+	if action.AttackBonus == 0 && action.SaveDC == 0 {
+		return nil, AbilityAttackOutput{}, fmt.Errorf("%s has neither an attack bonus nor a save DC", action.Name)
+	}
+	if action.DamageDice == "" && input.Condition == "" {
+		return nil, AbilityAttackOutput{}, fmt.Errorf("%s has no damage dice or rider to resolve", action.Name)
+	}
+	condition := ""
+	if input.Condition != "" {
+		var err error
+		if condition, err = canonicalCondition(input.Condition); err != nil {
+			return nil, AbilityAttackOutput{}, err
+		}
+	}
+
+	mode := strings.ToLower(input.Mode)
+	if mode == "" {
+		mode = "attack"
+		if action.SaveDC > 0 && action.AttackBonus == 0 {
+			mode = "save"
+		}
+	}
+
+	output := AbilityAttackOutput{Mode: mode}
+	affected := false
+	switch mode {
+	case "attack":
+		if action.AttackBonus == 0 {
+			return nil, AbilityAttackOutput{}, fmt.Errorf("%s has no attack bonus; resolve it with mode save", action.Name)
+		}
+		result, err := resolveAttack(attacker, target, *action, false)
+		if err != nil {
+			return nil, AbilityAttackOutput{}, err
+		}
+		output.Attack = &result
+		output.Damage = result.Damage
+		output.Message = fmt.Sprintf("Resolved by attack roll: %s", result.Message)
+		affected = result.Hit
+
+	case "save":
+		if action.SaveDC == 0 || action.SaveType == "" {
+			return nil, AbilityAttackOutput{}, fmt.Errorf("%s has no save DC; resolve it with mode attack", action.Name)
+		}
+		save := rollSavingThrow(target, action.SaveType, action.SaveDC)
+		output.SaveRoll = save.Roll
+		output.SaveTotal = save.Total
+		output.SaveSuccess = save.Success
+		output.Message = fmt.Sprintf("Resolved by %s save: %s", action.SaveType, save.describe(target, action.SaveDC))
+		affected = !save.Success
+
+		halfOnSuccess := input.HalfOnSuccess == nil || *input.HalfOnSuccess
+		if action.DamageDice != "" && (affected || halfOnSuccess) {
+			roll, err := rollDice(action.DamageDice, 1)
+			if err != nil {
+				return nil, AbilityAttackOutput{}, err
+			}
+			damage := roll.Total
+			if save.Success {
+				damage /= 2
+			}
+			dealt, modifier := applyDamage(target, damage, action.DamageType)
+			attacker.DamageDealt += dealt
+			output.Damage = dealt
+			output.Message += fmt.Sprintf(". %s takes %d %s damage%s", target.Name, dealt, action.DamageType, modifier)
+		}
+
+	default:
+		return nil, AbilityAttackOutput{}, fmt.Errorf("unknown mode %s: use attack or save", input.Mode)
+	}
+
+	if affected && condition != "" {
+		duration := input.ConditionDuration
+		if duration == 0 {
+			duration = 1
+		}
+		target.Conditions[condition] = duration
+		target.setConditionSource(condition, attacker.ID)
+		output.ConditionApplied = condition
+		output.Message += fmt.Sprintf(". %s is now %s", target.Name, condition)
+	}
+
+	output.RemainingHP = target.CurrentHP
+	output.Message += fmt.Sprintf(". %d HP remaining.", target.CurrentHP)
+	return nil, output, nil
+}
@@ -0,0 +1,36 @@
+package tools
+
+import (
+	"context"
+	"testing"
+)
+
+func TestAbilityAttackRiderOnly(t *testing.T) {
+	ctx := context.Background()
+	loadTestMonster(t, "Test Grappler", `{"name": "Test Grappler", "size": "Large", "type": "monstrosity", "hp": 30, "ac": 12,
+		"actions": [{"name": "Grab", "attack_bonus": 30}]}`)
+	startTestCombat(t,
+		EntityInit{ID: "fighter", Name: "Fighter", Initiative: 10, HP: 30, AC: 16},
+		EntityInit{ID: "grappler", Name: "Grappler", Initiative: 15, HP: 30, AC: 12, IsMonster: true, MonsterName: "Test Grappler"},
+	)
+
+	if _, _, err := handleAbilityAttack(ctx, nil, AbilityAttackInput{AttackerID: "grappler", TargetID: "fighter", ActionName: "Grab", Condition: "grapled"}); err == nil {
+		t.Error("an unknown rider condition was accepted")
+	}
+
+	// +30 only misses on a natural 1
+	for range 20 {
+		_, output, err := handleAbilityAttack(ctx, nil, AbilityAttackInput{AttackerID: "grappler", TargetID: "fighter", ActionName: "Grab", Condition: "Grappled"})
+		if err != nil {
+			t.Fatalf("ability_attack: %v", err)
+		}
+		if !output.Attack.Hit {
+			continue
+		}
+		if output.ConditionApplied != "grappled" || output.Damage != 0 || output.RemainingHP != 30 {
+			t.Errorf("condition %q, damage %d, HP %d; want the fighter grappled and unharmed", output.ConditionApplied, output.Damage, output.RemainingHP)
+		}
+		return
+	}
+	t.Fatal("the grappler never hit")
+}
@@ -0,0 +1,105 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ActionEconomy is an entity's remaining action economy for the turn
+type ActionEconomy struct {
+	Action            bool `json:"action" jsonschema:"Whether the action is still available"`
+	BonusAction       bool `json:"bonus_action"`
+	Reaction          bool `json:"reaction"`
+	MovementRemaining int  `json:"movement_remaining" jsonschema:"Feet of movement left this turn"`
+}
+
+// actionEconomy summarizes what the entity has left to spend this turn
+func (e *Entity) actionEconomy() ActionEconomy {
+	speed, _ := effectiveSpeed(e)
+	incapacitated := e.IsIncapacitated()
+	return ActionEconomy{
+		Action:            !e.ActionUsed && !incapacitated,
+		BonusAction:       !e.BonusActionUsed && !incapacitated,
+		Reaction:          !e.ReactionUsed && !incapacitated,
+		MovementRemaining: max(speed-e.MovementUsed, 0),
+	}
+}
+
+// refreshActions restores the entity's action economy to a fresh-turn state
+func (e *Entity) refreshActions() {
+	e.ActionUsed = false
+	e.BonusActionUsed = false
+	e.ReactionUsed = false
+	e.MovementUsed = 0
+	e.Disengaged = false
+}
+
+// RefreshActionsInput defines an action-economy reset
+type RefreshActionsInput struct {
+	EncounterScope
+	EntityID string `json:"entity_id"`
+}
+
+type RefreshActionsOutput struct {
+	Available ActionEconomy `json:"available"`
+	Message   string        `json:"message"`
+}
+
+func handleRefreshActions(ctx context.Context, req *mcp.CallToolRequest, input RefreshActionsInput) (*mcp.CallToolResult, RefreshActionsOutput, error) {
+	entity := combatState.Entities[input.EntityID]
+	if entity == nil {
+		return nil, RefreshActionsOutput{}, fmt.Errorf("entity not found: %s", input.EntityID)
+	}
+
+	entity.refreshActions()
+	combatState.logEvent("%s's action economy was manually refreshed", entity.Name)
+
+	message := fmt.Sprintf("%s has a fresh action, bonus action, reaction, and %d ft of movement.", entity.Name, entity.Speed)
+	if entity.IsIncapacitated() {
+		message += " It is incapacitated, so it still can't take actions or reactions."
+	}
+
+	return nil, RefreshActionsOutput{
+		Available: entity.actionEconomy(),
+		Message:   message,
+	}, nil
+}
+
+// UseReactionInput defines spending a creature's reaction
+type UseReactionInput struct {
+	EncounterScope
+	EntityID string `json:"entity_id"`
+	Reaction string `json:"reaction,omitempty" jsonschema:"What the reaction is used for, e.g. Shield or Hellish Rebuke"`
+}
+
+type UseReactionOutput struct {
+	Available ActionEconomy `json:"available"`
+	Message   string        `json:"message"`
+}
+
+func handleUseReaction(ctx context.Context, req *mcp.CallToolRequest, input UseReactionInput) (*mcp.CallToolResult, UseReactionOutput, error) {
+	entity := combatState.Entities[input.EntityID]
+	if entity == nil {
+		return nil, UseReactionOutput{}, fmt.Errorf("entity not found: %s", input.EntityID)
+	}
+	if entity.IsIncapacitated() {
+		return nil, UseReactionOutput{}, fmt.Errorf("%s is incapacitated and can't take reactions", entity.Name)
+	}
+	if entity.ReactionUsed {
+		return nil, UseReactionOutput{}, fmt.Errorf("%s has already used its reaction this round", entity.Name)
+	}
+
+	entity.ReactionUsed = true
+	reaction := input.Reaction
+	if reaction == "" {
+		reaction = "a reaction"
+	}
+	combatState.logEvent("%s uses its reaction: %s", entity.Name, reaction)
+
+	return nil, UseReactionOutput{
+		Available: entity.actionEconomy(),
+		Message:   fmt.Sprintf("%s uses its reaction for %s. It gets it back at the start of its next turn.", entity.Name, reaction),
+	}, nil
+}
@@ -0,0 +1,81 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// insertIntoTurnOrder places an entity at its place in initiative order, breaking
+// ties the same way as a full sort, and keeps the current turn on the same
+// combatant. It returns the entity's index in the order.
+func (cs *CombatState) insertIntoTurnOrder(e *Entity) int {
+	index := len(cs.TurnOrder)
+	for i, id := range cs.TurnOrder {
+		if cs.actsBefore(e, cs.Entities[id]) {
+			index = i
+			break
+		}
+	}
+	cs.TurnOrder = slices.Insert(cs.TurnOrder, index, e.ID)
+	if index <= cs.CurrentTurn && len(cs.TurnOrder) > 1 {
+		cs.CurrentTurn++
+	}
+	return index
+}
+
+// AddEntityInput defines a combatant joining an ongoing combat
+type AddEntityInput struct {
+	EncounterScope
+	Entity EntityInit `json:"entity" jsonschema:"The new combatant, with the same fields as in start_combat"`
+}
+
+type AddEntityOutput struct {
+	Position      int      `json:"position" jsonschema:"1-based place in the initiative order"`
+	TurnOrder     []string `json:"turn_order"`
+	ActsThisRound bool     `json:"acts_this_round" jsonschema:"Whether the newcomer's turn is still to come this round"`
+	Corrections   []string `json:"corrections,omitempty" jsonschema:"HP values that were clamped on import"`
+	Message       string   `json:"message"`
+}
+
+func handleAddEntity(ctx context.Context, req *mcp.CallToolRequest, input AddEntityInput) (*mcp.CallToolResult, AddEntityOutput, error) {
+	if input.Entity.ID == "" {
+		return nil, AddEntityOutput{}, fmt.Errorf("entity ID is required")
+	}
+	if combatState.Entities[input.Entity.ID] != nil {
+		return nil, AddEntityOutput{}, fmt.Errorf("entity already in combat: %s", input.Entity.ID)
+	}
+
+	if err := input.Entity.checkHPMode(); err != nil {
+		return nil, AddEntityOutput{}, err
+	}
+
+	entity, corrections := newEntity(input.Entity, combatState.RoundNumber)
+	// A newcomer to a group takes the group's initiative slot
+	if entity.GroupID != "" {
+		if initiative, ok := combatState.groupInitiative(entity.GroupID); ok {
+			entity.InitiativeRoll = initiative
+		}
+	}
+	combatState.Entities[entity.ID] = entity
+	index := combatState.insertIntoTurnOrder(entity)
+	combatState.logEvent("%s joined the combat at initiative %d", entity.Name, entity.InitiativeRoll)
+
+	actsThisRound := index > combatState.CurrentTurn
+	when := "acts this round"
+	if !actsThisRound {
+		when = "first acts next round"
+	}
+
+	return nil, AddEntityOutput{
+		Position:      index + 1,
+		TurnOrder:     combatState.TurnOrder,
+		ActsThisRound: actsThisRound,
+		Corrections:   corrections,
+		Message: fmt.Sprintf("%s joins at initiative %d, position %d of %d, and %s. It is still %s's turn.",
+			entity.Name, entity.InitiativeRoll, index+1, len(combatState.TurnOrder), when,
+			combatState.Entities[combatState.TurnOrder[combatState.CurrentTurn]].Name),
+	}, nil
+}
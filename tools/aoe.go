@@ -0,0 +1,124 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ResolveAOEInput defines an area-of-effect ability that every target saves against
+type ResolveAOEInput struct {
+	EncounterScope
+	TargetIDs         []string `json:"target_ids" jsonschema:"Every creature caught in the area"`
+	SaveType          string   `json:"save_type" jsonschema:"STR, DEX, CON, INT, WIS, CHA"`
+	DC                int      `json:"dc" jsonschema:"Difficulty class"`
+	DamageDice        string   `json:"damage_dice,omitempty" jsonschema:"Damage dice rolled once and shared by every target, e.g. 8d6"`
+	Damage            int      `json:"damage,omitempty" jsonschema:"Pre-rolled full damage, used when damage_dice is omitted"`
+	DamageType        string   `json:"damage_type"`
+	NoDamageOnSuccess bool     `json:"no_damage_on_success,omitempty" jsonschema:"A successful save negates the damage instead of halving it"`
+	SourceID          string   `json:"source_id,omitempty" jsonschema:"Creature using the ability, credited on the damage leaderboard"`
+	RollConcentration bool     `json:"roll_concentration,omitempty" jsonschema:"Roll concentrating targets' CON saves automatically instead of reporting the DC to roll"`
+	// Targets not listed have no cover
+	Cover map[string]string `json:"cover,omitempty" jsonschema:"Cover per target ID: half (+2 to DEX saves), three_quarters (+5 to DEX saves), or total (no damage)"`
+}
+
+type ResolveAOEOutput struct {
+	FullDamage int                   `json:"full_damage" jsonschema:"Damage each target faces before its save"`
+	DamageRoll *DiceRoll             `json:"damage_roll,omitempty"`
+	Results    []SaveForDamageOutput `json:"results" jsonschema:"Each target's save and damage, in the order given"`
+	TotalDealt int                   `json:"total_dealt"`
+	Downed     []string              `json:"downed,omitempty" jsonschema:"Targets dropped to 0 HP"`
+	Message    string                `json:"message"`
+}
+
+func handleResolveAOE(ctx context.Context, req *mcp.CallToolRequest, input ResolveAOEInput) (*mcp.CallToolResult, ResolveAOEOutput, error) {
+	if len(input.TargetIDs) == 0 {
+		return nil, ResolveAOEOutput{}, fmt.Errorf("target_ids is required")
+	}
+	targets := make([]*Entity, 0, len(input.TargetIDs))
+	for i, id := range input.TargetIDs {
+		target := combatState.Entities[id]
+		if target == nil {
+			return nil, ResolveAOEOutput{}, fmt.Errorf("target not found: %s", id)
+		}
+		if slices.Contains(input.TargetIDs[:i], id) {
+			return nil, ResolveAOEOutput{}, fmt.Errorf("target listed twice: %s", id)
+		}
+		targets = append(targets, target)
+	}
+	cover := make(map[string]string, len(input.Cover))
+	for id, c := range input.Cover {
+		if !slices.Contains(input.TargetIDs, id) {
+			return nil, ResolveAOEOutput{}, fmt.Errorf("cover given for %s, who isn't a target", id)
+		}
+		var err error
+		if cover[id], err = parseCover(c); err != nil {
+			return nil, ResolveAOEOutput{}, err
+		}
+	}
+	source := combatState.Entities[input.SourceID]
+	if input.SourceID != "" && source == nil {
+		return nil, ResolveAOEOutput{}, fmt.Errorf("source not found: %s", input.SourceID)
+	}
+
+	output := ResolveAOEOutput{FullDamage: input.Damage, Results: []SaveForDamageOutput{}}
+	if input.DamageDice != "" {
+		roll, err := rollDice(input.DamageDice, 1)
+		if err != nil {
+			return nil, ResolveAOEOutput{}, err
+		}
+		output.FullDamage = roll.Total
+		output.DamageRoll = &roll
+	}
+	if output.FullDamage <= 0 {
+		return nil, ResolveAOEOutput{}, fmt.Errorf("give damage or damage_dice")
+	}
+
+	lines, downed := []string{}, []string{}
+	for _, target := range targets {
+		result := combatState.saveAgainstDamage(target, source, input.SaveType, input.DC, output.FullDamage, input.DamageType, input.NoDamageOnSuccess, input.RollConcentration, cover[target.ID])
+		output.Results = append(output.Results, result)
+		output.TotalDealt += result.FinalDamage
+		if (result.IsUnconscious || result.InstantDeath) && result.HPBefore > 0 {
+			output.Downed = append(output.Downed, target.ID)
+			downed = append(downed, target.Name)
+		}
+
+		outcome := "fail"
+		if result.Save.Success {
+			outcome = "save"
+		}
+		if result.Save.UsedLegendaryResistance {
+			outcome += " (legendary resistance)"
+		}
+		if result.Save.Cover == coverTotal {
+			lines = append(lines, fmt.Sprintf("- %s: total cover, unaffected", target.Name))
+			continue
+		}
+		if result.Save.CoverBonus != 0 {
+			outcome += fmt.Sprintf(" (%+d %s)", result.Save.CoverBonus, describeCover(result.Save.Cover))
+		}
+		line := fmt.Sprintf("- %s: %d vs DC %d %s, %d damage, HP %d -> %d",
+			target.Name, result.Save.Total, input.DC, outcome, result.FinalDamage, result.HPBefore, result.RemainingHP)
+		if result.InstantDeath {
+			line += ", killed by massive damage"
+		}
+		lines = append(lines, line)
+	}
+
+	message := fmt.Sprintf("%d %s damage vs DC %d %s save", output.FullDamage, input.DamageType, input.DC, strings.ToUpper(input.SaveType))
+	if output.DamageRoll != nil {
+		message += fmt.Sprintf(" (rolled %s: %v)", input.DamageDice, output.DamageRoll.Rolls)
+	}
+	message += fmt.Sprintf(" against %d targets, %d damage dealt:\n%s", len(targets), output.TotalDealt, strings.Join(lines, "\n"))
+	if len(downed) > 0 {
+		message += fmt.Sprintf("\nDropped to 0 HP: %s.", strings.Join(downed, ", "))
+	}
+	output.Message = message
+	combatState.logEvent("Area effect: %d %s damage to %d targets (DC %d %s save)", output.TotalDealt, input.DamageType, len(targets), input.DC, strings.ToUpper(input.SaveType))
+
+	return nil, output, nil
+}
@@ -0,0 +1,429 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+
+	"github.com/kiriyms/dungeon-master-mcp/resources"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// AttackResult describes a single resolved attack roll and the damage it dealt
+type AttackResult struct {
+	AttackerID    string    `json:"attacker_id"`
+	Action        string    `json:"action"`
+	Roll          int       `json:"roll" jsonschema:"natural d20 result"`
+	Bonus         int       `json:"bonus"`
+	Total         int       `json:"total"`
+	TargetAC      int       `json:"target_ac"`
+	Hit           bool      `json:"hit"`
+	Critical      bool      `json:"critical"`
+	NaturalTwenty bool      `json:"natural_twenty" jsonschema:"A natural 20 always hits and crits"`
+	NaturalOne    bool      `json:"natural_one" jsonschema:"A natural 1 always misses"`
+	Damage        int       `json:"damage" jsonschema:"final damage after resistances"`
+	DamageType    string    `json:"damage_type,omitempty"`
+	DamageRoll    *DiceRoll `json:"damage_roll,omitempty"`
+	// How resistances and other damage modifiers changed the rolled damage
+	DamageSteps []DamageStep `json:"damage_steps,omitempty"`
+	// Rule that bypassed the normal attack roll, e.g. auto_hit for Magic Missile
+	SpecialRule string `json:"special_rule,omitempty"`
+	RollMode    string `json:"roll_mode,omitempty" jsonschema:"advantage or disadvantage, when the d20 was rolled twice"`
+	Rolls       []int  `json:"rolls,omitempty" jsonschema:"both d20s when rolled with advantage or disadvantage"`
+	RollReason  string `json:"roll_reason,omitempty" jsonschema:"What gave the roll advantage or disadvantage, e.g. disadvantage from poisoned"`
+	Revealed    bool   `json:"revealed,omitempty" jsonschema:"The attacker was hidden and gave away its position by attacking"`
+	Cover       string `json:"cover,omitempty" jsonschema:"Cover the target had: half, three_quarters, or total"`
+	CoverBonus  int    `json:"cover_bonus,omitempty" jsonschema:"AC the cover added, included in target_ac"`
+	Message     string `json:"message"`
+	// Every source counted, from conditions and declared sources; any advantage and any
+	// disadvantage cancel out to a straight roll
+	AdvantageFrom    []string `json:"advantage_from,omitempty"`
+	DisadvantageFrom []string `json:"disadvantage_from,omitempty"`
+}
+
+// isAttack reports whether a stat block action is an attack roll that deals damage.
+// Effects like breath weapons have a save DC and no attack bonus; anything else with
+// damage dice is an attack, including one with a +0 bonus.
+func isAttack(action resources.MonsterAction) bool {
+	if action.DamageDice == "" {
+		return false
+	}
+	return action.SaveDC == 0 || action.AttackBonus != 0
+}
+
+// attackAction finds the attack an entity makes, preferring the named action and
+// otherwise taking the first attack in its stat block
+func attackAction(e *Entity, actionName string) (resources.MonsterAction, error) {
+	monster, ok := resources.GetMonster(e.MonsterName)
+	if !ok {
+		return resources.MonsterAction{}, fmt.Errorf("no stat block loaded for %s", e.ID)
+	}
+
+	for _, action := range monster.Actions {
+		if !isAttack(action) {
+			continue
+		}
+		if actionName == "" || strings.EqualFold(action.Name, actionName) {
+			return action, nil
+		}
+	}
+
+	if actionName != "" {
+		return resources.MonsterAction{}, fmt.Errorf("%s has no attack named %s", e.MonsterName, actionName)
+	}
+	return resources.MonsterAction{}, fmt.Errorf("%s has no attack actions", e.MonsterName)
+}
+
+// attackOptions are the circumstances of an attack beyond the attacker, target, and action
+type attackOptions struct {
+	AutoHit      bool
+	Advantage    bool
+	Disadvantage bool
+	Cover        string // from parseCover
+	// Reasons the DM declares, such as flanking or long range
+	AdvantageSources    []string
+	DisadvantageSources []string
+}
+
+// modifiers combines the declared advantage and disadvantage with what the creatures'
+// conditions and hiding impose, returning the condition that makes a hit critical
+func (opts attackOptions) modifiers(attacker, target *Entity, action resources.MonsterAction) (rollModifiers, string) {
+	modifiers, autoCrit := attackModifiers(attacker, target, action)
+	if opts.Advantage {
+		modifiers.add(resources.RollAdvantage, "circumstance")
+	}
+	if opts.Disadvantage {
+		modifiers.add(resources.RollDisadvantage, "circumstance")
+	}
+	for _, source := range opts.AdvantageSources {
+		if source = strings.ToLower(strings.TrimSpace(source)); source != "" {
+			modifiers.add(resources.RollAdvantage, source)
+		}
+	}
+	for _, source := range opts.DisadvantageSources {
+		if source = strings.ToLower(strings.TrimSpace(source)); source != "" {
+			modifiers.add(resources.RollDisadvantage, source)
+		}
+	}
+	if attacker.Hidden {
+		modifiers.add(resources.RollAdvantage, "hidden")
+	}
+	if target.Hidden {
+		modifiers.add(resources.RollDisadvantage, "target hidden")
+	}
+	return modifiers, autoCrit
+}
+
+// resolveAttack rolls to hit against the target's AC and, on a hit, rolls the damage
+// (doubling the dice on a natural 20) and applies it to the target. An auto-hit
+// attack such as Magic Missile skips the roll entirely and can't crit.
+func resolveAttack(attacker, target *Entity, action resources.MonsterAction, autoHit bool) (AttackResult, error) {
+	return resolveAttackWith(attacker, target, action, attackOptions{AutoHit: autoHit})
+}
+
+// resolveAttackWith resolves an attack like resolveAttack, also applying advantage and
+// disadvantage. Conditions on either creature apply as the SRD describes (see
+// attackModifiers); a hidden attacker attacks with advantage and is revealed by
+// attacking, and an attack against a hidden target is made with disadvantage.
+func resolveAttackWith(attacker, target *Entity, action resources.MonsterAction, opts attackOptions) (AttackResult, error) {
+	// Total cover means the target can't be targeted at all
+	if opts.Cover == coverTotal {
+		return AttackResult{
+			AttackerID:  attacker.ID,
+			Action:      action.Name,
+			TargetAC:    target.AC,
+			DamageType:  action.DamageType,
+			SpecialRule: "total cover: automatic miss",
+			Cover:       coverTotal,
+			Message:     fmt.Sprintf("%s's %s misses %s automatically: the target has total cover", attacker.Name, action.Name, target.Name),
+		}, nil
+	}
+
+	modifiers, autoCrit := opts.modifiers(attacker, target, action)
+	revealed := attacker.Hidden
+	attacker.Hidden = false
+
+	result, err := rollAttack(attacker, target, action, opts.AutoHit, modifiers, autoCrit, opts.Cover)
+	if err != nil {
+		return AttackResult{}, err
+	}
+	if revealed {
+		result.Revealed = true
+		result.Message += fmt.Sprintf(". %s is no longer hidden", attacker.Name)
+	}
+	return result, nil
+}
+
+// rollAttack makes the attack roll and applies any damage. A hit becomes a critical
+// hit when autoCrit names the target's condition that allows it, e.g. paralyzed, and
+// the target's cover raises the AC the roll must meet.
+func rollAttack(attacker, target *Entity, action resources.MonsterAction, autoHit bool, modifiers rollModifiers, autoCrit string, cover string) (AttackResult, error) {
+	if autoHit {
+		damageRoll, err := rollDice(action.DamageDice, 1)
+		if err != nil {
+			return AttackResult{}, err
+		}
+		finalDamage, modifier, steps := applyDamageSteps(target, damageRoll.Total, action.DamageType)
+		attacker.DamageDealt += finalDamage
+		return AttackResult{
+			AttackerID:  attacker.ID,
+			Action:      action.Name,
+			TargetAC:    target.AC,
+			Hit:         true,
+			Damage:      finalDamage,
+			DamageType:  action.DamageType,
+			DamageRoll:  &damageRoll,
+			DamageSteps: steps,
+			SpecialRule: "auto_hit: no attack roll",
+			Message:     fmt.Sprintf("%s's %s automatically hits %s for %d %s damage%s", attacker.Name, action.Name, target.Name, finalDamage, action.DamageType, modifier),
+		}, nil
+	}
+
+	roll, rolls, mode := modifiers.roll()
+	total := roll + action.AttackBonus
+	ac := target.AC + coverBonus(cover)
+	hit := roll == 20 || (roll != 1 && total >= ac)
+
+	result := AttackResult{
+		AttackerID:    attacker.ID,
+		Action:        action.Name,
+		Roll:          roll,
+		Bonus:         action.AttackBonus,
+		Total:         total,
+		TargetAC:      ac,
+		Critical:      roll == 20 || (hit && autoCrit != ""),
+		Hit:           hit,
+		NaturalTwenty: roll == 20,
+		NaturalOne:    roll == 1,
+		DamageType:    action.DamageType,
+		RollMode:      mode,
+		Rolls:         rolls,
+		RollReason:    modifiers.describe(),
+		Cover:         cover,
+		CoverBonus:    coverBonus(cover),
+	}
+	result.AdvantageFrom, result.DisadvantageFrom = modifiers.Advantage, modifiers.Disadvantage
+	withMode := ""
+	if mode != "" {
+		withMode = fmt.Sprintf(" with %s %v", mode, rolls)
+	}
+	if result.RollReason != "" {
+		withMode += fmt.Sprintf("; %s", result.RollReason)
+	}
+
+	if !result.Hit {
+		fumble := ""
+		if result.NaturalOne {
+			fumble = "natural 1: "
+		}
+		result.Message = fmt.Sprintf("%s's %s misses %s (%s%d+%d=%d vs AC %d%s%s)", attacker.Name, action.Name, target.Name, fumble, roll, action.AttackBonus, total, ac, result.coverNote(), withMode)
+		return result, nil
+	}
+
+	hitWord := "hits"
+	if result.Critical {
+		hitWord = "CRITS"
+	}
+
+	// An action that only carries a rider, such as a grab, hits without dealing damage
+	if action.DamageDice == "" {
+		result.Message = fmt.Sprintf("%s's %s %s %s", attacker.Name, action.Name, hitWord, target.Name)
+		if withMode != "" {
+			result.Message += fmt.Sprintf(" (rolled %d%s)", roll, withMode)
+		}
+		return result, nil
+	}
+
+	diceMultiplier := 1
+	if result.Critical {
+		diceMultiplier = resources.SRDDamageRules.CriticalMultiplier
+	}
+	damageRoll, err := rollDice(action.DamageDice, diceMultiplier)
+	if err != nil {
+		return AttackResult{}, err
+	}
+	result.DamageRoll = &damageRoll
+
+	finalDamage, modifier, steps := applyDamageSteps(target, damageRoll.Total, action.DamageType)
+	result.Damage = finalDamage
+	result.DamageSteps = steps
+	attacker.DamageDealt += finalDamage
+
+	result.Message = fmt.Sprintf("%s's %s %s %s for %d %s damage%s", attacker.Name, action.Name, hitWord, target.Name, finalDamage, action.DamageType, modifier)
+	if roll != 20 && result.Critical {
+		result.Message += fmt.Sprintf(" (automatic critical: %s within 5 feet)", autoCrit)
+	}
+	if result.CoverBonus != 0 {
+		result.Message += fmt.Sprintf(" (%d vs AC %d%s)", total, ac, result.coverNote())
+	}
+	if withMode != "" {
+		result.Message += fmt.Sprintf(" (rolled %d%s)", roll, withMode)
+	}
+
+	return result, nil
+}
+
+// coverNote explains the cover bonus in the AC, and when the cover turned a hit
+// into a miss, e.g. " with +2 from half cover, which turned a hit into a miss"
+func (r AttackResult) coverNote() string {
+	if r.CoverBonus == 0 {
+		return ""
+	}
+	note := fmt.Sprintf(" with %+d from %s", r.CoverBonus, describeCover(r.Cover))
+	if !r.Hit && !r.NaturalOne && r.Total >= r.TargetAC-r.CoverBonus {
+		note += ", which turned a hit into a miss"
+	}
+	return note
+}
+
+// rollD20 rolls a d20, rolling twice and keeping the higher or lower die when exactly
+// one of advantage and disadvantage applies; it returns the kept roll, both dice when
+// two were rolled, and the roll mode
+func rollD20(advantage, disadvantage bool) (int, []int, string) {
+	roll := rand.Intn(20) + 1
+	if advantage == disadvantage {
+		return roll, nil, ""
+	}
+	second := rand.Intn(20) + 1
+	if advantage {
+		return max(roll, second), []int{roll, second}, "advantage"
+	}
+	return min(roll, second), []int{roll, second}, "disadvantage"
+}
+
+// SwarmAttackInput defines a batch of attacks against one target
+type SwarmAttackInput struct {
+	EncounterScope
+	AttackerIDs  []string `json:"attacker_ids,omitempty" jsonschema:"Entities making the attack, in the order they strike"`
+	TargetID     string   `json:"target_id" jsonschema:"Entity being attacked"`
+	ActionName   string   `json:"action_name,omitempty" jsonschema:"Stat block action to use (defaults to each attacker's first attack)"`
+	StopWhenDown bool     `json:"stop_when_down,omitempty" jsonschema:"Stop attacking once the target reaches 0 HP"`
+	AutoHit      bool     `json:"auto_hit,omitempty" jsonschema:"Skip the attack roll and always hit, e.g. Magic Missile"`
+	// A grouped combatant attacks with every member, after any attacker_ids
+	GroupID           string `json:"group_id,omitempty" jsonschema:"Initiative group whose members all attack, in initiative order"`
+	RollConcentration bool   `json:"roll_concentration,omitempty" jsonschema:"Roll a concentrating target's CON save after each hit instead of reporting the DCs to roll"`
+}
+
+type SwarmAttackOutput struct {
+	Attacks     []AttackResult `json:"attacks"`
+	Hits        int            `json:"hits"`
+	Misses      int            `json:"misses"`
+	Crits       int            `json:"crits"`
+	TotalDamage int            `json:"total_damage"`
+	RemainingHP int            `json:"remaining_hp"`
+	Skipped     []string       `json:"skipped,omitempty" jsonschema:"Attackers that did not attack because the target was already down or they were incapacitated"`
+	Message     string         `json:"message"`
+	// Each damaging hit is its own source of damage for the target's concentration
+	ConcentrationDCs    []int                `json:"concentration_dcs,omitempty" jsonschema:"DC of the CON save the target must make for each hit, in order"`
+	ConcentrationChecks []ConcentrationCheck `json:"concentration_checks,omitempty" jsonschema:"The concentration saves, when they were rolled"`
+	InstantDeath        bool                 `json:"instant_death,omitempty" jsonschema:"A hit's damage past 0 HP equaled the target's max HP, killing it outright"`
+}
+
+func handleSwarmAttack(ctx context.Context, req *mcp.CallToolRequest, input SwarmAttackInput) (*mcp.CallToolResult, SwarmAttackOutput, error) {
+	target := combatState.Entities[input.TargetID]
+	if target == nil {
+		return nil, SwarmAttackOutput{}, fmt.Errorf("target not found: %s", input.TargetID)
+	}
+	attackerIDs := slices.Clone(input.AttackerIDs)
+	if input.GroupID != "" {
+		members := 0
+		for _, id := range combatState.TurnOrder {
+			if combatState.Entities[id].GroupID != input.GroupID {
+				continue
+			}
+			members++
+			if !slices.Contains(attackerIDs, id) {
+				attackerIDs = append(attackerIDs, id)
+			}
+		}
+		if members == 0 {
+			return nil, SwarmAttackOutput{}, fmt.Errorf("group not found: %s", input.GroupID)
+		}
+	}
+	if len(attackerIDs) == 0 {
+		return nil, SwarmAttackOutput{}, fmt.Errorf("no attackers given; list attacker_ids or give a group_id")
+	}
+
+	// Look up every attacker's action before rolling so a bad ID doesn't leave a half-applied swarm
+	attackers := make([]*Entity, 0, len(attackerIDs))
+	actions := make([]resources.MonsterAction, 0, len(attackerIDs))
+	for _, id := range attackerIDs {
+		attacker := combatState.Entities[id]
+		if attacker == nil {
+			return nil, SwarmAttackOutput{}, fmt.Errorf("attacker not found: %s", id)
+		}
+		action, err := attackAction(attacker, input.ActionName)
+		if err != nil {
+			return nil, SwarmAttackOutput{}, err
+		}
+		attackers = append(attackers, attacker)
+		actions = append(actions, action)
+	}
+
+	output := SwarmAttackOutput{Attacks: []AttackResult{}}
+	notes := []string{}
+	incapacitated := 0
+	for i, attacker := range attackers {
+		if input.StopWhenDown && target.CurrentHP == 0 {
+			output.Skipped = append(output.Skipped, attacker.ID)
+			continue
+		}
+		if attacker.IsIncapacitated() {
+			output.Skipped = append(output.Skipped, attacker.ID)
+			incapacitated++
+			continue
+		}
+
+		hpBefore := target.CurrentHP
+		result, err := resolveAttack(attacker, target, actions[i], input.AutoHit)
+		if err != nil {
+			return nil, SwarmAttackOutput{}, err
+		}
+
+		// Each hit settles like apply_damage: bloodied, dropping or dying, and concentration
+		if result.Hit {
+			after := combatState.afterDamage(target, hpBefore, result.Damage, false, input.RollConcentration)
+			if after.ConcentrationCheck != nil {
+				output.ConcentrationChecks = append(output.ConcentrationChecks, *after.ConcentrationCheck)
+			} else if after.ConcentrationDC > 0 {
+				output.ConcentrationDCs = append(output.ConcentrationDCs, after.ConcentrationDC)
+			}
+			output.InstantDeath = output.InstantDeath || after.InstantDeath
+			if after.Note != "" {
+				result.Message += "." + strings.TrimSuffix(after.Note, ".")
+				notes = append(notes, strings.TrimSpace(after.Note))
+			}
+		}
+
+		output.Attacks = append(output.Attacks, result)
+		if result.Hit {
+			output.Hits++
+		} else {
+			output.Misses++
+		}
+		if result.Critical {
+			output.Crits++
+		}
+		output.TotalDamage += result.Damage
+	}
+
+	output.RemainingHP = target.CurrentHP
+	output.Message = fmt.Sprintf("%d attacks on %s: %d hits (%d critical), %d misses, %d total damage. %d HP remaining.",
+		len(output.Attacks), target.Name, output.Hits, output.Crits, output.Misses, output.TotalDamage, target.CurrentHP)
+	if len(notes) > 0 {
+		output.Message += " " + strings.Join(notes, " ")
+	}
+	if input.AutoHit {
+		output.Message += " Attacks hit automatically (auto_hit), so no attack rolls were made."
+	}
+	if incapacitated > 0 {
+		output.Message += fmt.Sprintf(" %d attackers are incapacitated and can't attack.", incapacitated)
+	}
+	if len(output.Skipped) > incapacitated {
+		output.Message += fmt.Sprintf(" %d attackers held back because the target is down.", len(output.Skipped)-incapacitated)
+	}
+
+	return nil, output, nil
+}
@@ -0,0 +1,110 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/kiriyms/dungeon-master-mcp/resources"
+)
+
+func TestIsAttack(t *testing.T) {
+	tests := []struct {
+		name   string
+		action resources.MonsterAction
+		want   bool
+	}{
+		{"weapon attack", resources.MonsterAction{AttackBonus: 4, DamageDice: "1d6+2"}, true},
+		{"+0 weapon attack", resources.MonsterAction{DamageDice: "1d4"}, true},
+		{"attack with a rider save", resources.MonsterAction{AttackBonus: 5, DamageDice: "1d8+3", SaveDC: 11, SaveType: "CON"}, true},
+		{"breath weapon", resources.MonsterAction{DamageDice: "26d6", SaveDC: 24, SaveType: "DEX"}, false},
+		{"multiattack", resources.MonsterAction{Name: "Multiattack"}, false},
+	}
+	for _, tt := range tests {
+		if got := isAttack(tt.action); got != tt.want {
+			t.Errorf("%s: isAttack = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// loadTestMonster adds a stat block to the monster catalog for the length of the test
+func loadTestMonster(t *testing.T, name, statBlock string) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "monster.json"), []byte(statBlock), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if loaded, err := resources.LoadMonsterDir(dir); err != nil || loaded != 1 {
+		t.Fatalf("loading stat block: %d loaded, %v", loaded, err)
+	}
+	t.Cleanup(func() { resources.UnloadMonster(name) })
+}
+
+func TestAttackActionUsesPlusZeroAttack(t *testing.T) {
+	loadTestMonster(t, "Test Commoner", `{"name": "Test Commoner", "size": "Medium", "type": "humanoid", "hp": 4, "ac": 10,
+		"actions": [
+			{"name": "Spit", "damage_dice": "1d4", "damage_type": "acid", "save_dc": 10, "save_type": "DEX"},
+			{"name": "Club", "damage_dice": "1d4", "damage_type": "bludgeoning"}
+		]}`)
+
+	action, err := attackAction(&Entity{ID: "commoner", MonsterName: "Test Commoner"}, "")
+	if err != nil {
+		t.Fatalf("attackAction: %v", err)
+	}
+	if action.Name != "Club" || action.AttackBonus != 0 {
+		t.Errorf("got %s %+d, want the +0 Club", action.Name, action.AttackBonus)
+	}
+}
+
+func TestSwarmAttackByGroup(t *testing.T) {
+	ctx := context.Background()
+	startTestCombat(t,
+		EntityInit{ID: "wizard", Name: "Wizard", Initiative: 15, HP: 40, AC: 12},
+		EntityInit{ID: "g2", Name: "Goblin 2", Initiative: 12, HP: 7, AC: 15, IsMonster: true, MonsterName: "Goblin", GroupID: "goblins"},
+		EntityInit{ID: "g1", Name: "Goblin 1", HP: 7, AC: 15, IsMonster: true, MonsterName: "Goblin", GroupID: "goblins"},
+		EntityInit{ID: "g3", Name: "Goblin 3", HP: 7, AC: 15, IsMonster: true, MonsterName: "Goblin", GroupID: "goblins"},
+	)
+	combatState.Entities["wizard"].Concentrating = "Haste"
+
+	_, output, err := handleSwarmAttack(ctx, nil, SwarmAttackInput{TargetID: "wizard", GroupID: "goblins", AutoHit: true})
+	if err != nil {
+		t.Fatalf("swarm_attack: %v", err)
+	}
+
+	attackers := []string{}
+	for _, attack := range output.Attacks {
+		attackers = append(attackers, attack.AttackerID)
+	}
+	if want := []string{"g1", "g2", "g3"}; !slices.Equal(attackers, want) {
+		t.Errorf("attackers = %v, want the group in initiative order %v", attackers, want)
+	}
+	// Three hits of 1d6+2 leave the wizard up, each calling for its own concentration save
+	if len(output.ConcentrationDCs) != 3 {
+		t.Errorf("concentration DCs = %v, want one per hit", output.ConcentrationDCs)
+	}
+
+	if _, _, err := handleSwarmAttack(ctx, nil, SwarmAttackInput{TargetID: "wizard", GroupID: "orcs"}); err == nil {
+		t.Error("swarm_attack with an unknown group succeeded")
+	}
+}
+
+func TestSwarmAttackMassiveDamage(t *testing.T) {
+	ctx := context.Background()
+	hp := 1
+	startTestCombat(t,
+		EntityInit{ID: "squire", Name: "Squire", Initiative: 15, HP: 2, AC: 10, CurrentHP: &hp},
+		EntityInit{ID: "g1", Name: "Goblin 1", Initiative: 12, HP: 7, AC: 15, IsMonster: true, MonsterName: "Goblin"},
+	)
+
+	// 1d6+2 always deals at least 3: 2 past 0 HP, meeting the squire's max HP of 2
+	_, output, err := handleSwarmAttack(ctx, nil, SwarmAttackInput{TargetID: "squire", AttackerIDs: []string{"g1"}, AutoHit: true})
+	if err != nil {
+		t.Fatalf("swarm_attack: %v", err)
+	}
+	if !output.InstantDeath || !combatState.Entities["squire"].Dead {
+		t.Errorf("instant death = %v, dead = %v, want the squire killed by massive damage (%s)",
+			output.InstantDeath, combatState.Entities["squire"].Dead, output.Message)
+	}
+}
@@ -0,0 +1,312 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kiriyms/dungeon-master-mcp/resources"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// skillAbilities maps each skill to the ability it is based on
+var skillAbilities = map[string]string{
+	"acrobatics":      "DEX",
+	"animal handling": "WIS",
+	"arcana":          "INT",
+	"athletics":       "STR",
+	"deception":       "CHA",
+	"history":         "INT",
+	"insight":         "WIS",
+	"intimidation":    "CHA",
+	"investigation":   "INT",
+	"medicine":        "WIS",
+	"nature":          "INT",
+	"perception":      "WIS",
+	"performance":     "CHA",
+	"persuasion":      "CHA",
+	"religion":        "INT",
+	"sleight of hand": "DEX",
+	"stealth":         "DEX",
+	"survival":        "WIS",
+}
+
+// abilityNames lists the six abilities in their usual order
+var abilityNames = []string{"STR", "DEX", "CON", "INT", "WIS", "CHA"}
+
+// abilityModifier returns the entity's modifier for an ability, treating an
+// unrecorded score as an average 10
+func abilityModifier(e *Entity, ability string) int {
+	score, ok := e.AbilityScores[strings.ToUpper(ability)]
+	if !ok {
+		return 0
+	}
+	return resources.AbilityModifier(score)
+}
+
+// skillBonus returns the entity's bonus for a skill check
+func skillBonus(e *Entity, skill string) int {
+	bonus, _ := skillCheckBonus(e, skill)
+	return bonus
+}
+
+// skillCheckBonus returns the entity's listed skill bonus when proficient, otherwise
+// the modifier of the skill's ability, and whether proficiency applied
+func skillCheckBonus(e *Entity, skill string) (int, bool) {
+	for name, bonus := range e.Skills {
+		if strings.EqualFold(name, skill) {
+			return bonus, true
+		}
+	}
+	return abilityModifier(e, skillAbilities[strings.ToLower(skill)]), false
+}
+
+// setConditionSource records which entity imposed a condition, clearing any
+// previous source when the condition is reapplied without one
+func (e *Entity) setConditionSource(condition, sourceID string) {
+	if sourceID == "" {
+		delete(e.ConditionSources, condition)
+		return
+	}
+	if e.ConditionSources == nil {
+		e.ConditionSources = make(map[string]string)
+	}
+	e.ConditionSources[condition] = sourceID
+}
+
+// passiveScore returns the entity's passive score for a skill: 10 plus its skill
+// bonus, +5 with advantage or -5 with disadvantage
+func passiveScore(e *Entity, skill string, modifiers rollModifiers) int {
+	return 10 + skillBonus(e, skill) + modifiers.passive()
+}
+
+// MakeSkillCheckInput defines a skill check
+type MakeSkillCheckInput struct {
+	EncounterScope
+	EntityID     string `json:"entity_id"`
+	Skill        string `json:"skill" jsonschema:"Skill name, e.g. Perception or Sleight of Hand"`
+	DC           int    `json:"dc,omitempty" jsonschema:"Difficulty to beat; omit to just report the total"`
+	Advantage    bool   `json:"advantage,omitempty" jsonschema:"Other sources of advantage"`
+	Disadvantage bool   `json:"disadvantage,omitempty" jsonschema:"Other sources of disadvantage"`
+}
+
+type MakeSkillCheckOutput struct {
+	Skill      string `json:"skill"`
+	Ability    string `json:"ability" jsonschema:"Ability the skill is based on"`
+	Proficient bool   `json:"proficient" jsonschema:"Whether the bonus is a listed skill bonus rather than the bare ability modifier"`
+	Roll       int    `json:"roll" jsonschema:"natural d20 result"`
+	Rolls      []int  `json:"rolls,omitempty" jsonschema:"both d20s when rolled with advantage or disadvantage"`
+	RollMode   string `json:"roll_mode,omitempty"`
+	RollReason string `json:"roll_reason,omitempty" jsonschema:"What gave the roll advantage or disadvantage"`
+	Bonus      int    `json:"bonus"`
+	Total      int    `json:"total"`
+	DC         int    `json:"dc,omitempty"`
+	Success    *bool  `json:"success,omitempty" jsonschema:"Whether the total met the DC, when one was given"`
+	Message    string `json:"message"`
+}
+
+func handleMakeSkillCheck(ctx context.Context, req *mcp.CallToolRequest, input MakeSkillCheckInput) (*mcp.CallToolResult, MakeSkillCheckOutput, error) {
+	entity := combatState.Entities[input.EntityID]
+	if entity == nil {
+		return nil, MakeSkillCheckOutput{}, fmt.Errorf("entity not found: %s", input.EntityID)
+	}
+	skill := strings.ToLower(strings.TrimSpace(input.Skill))
+	ability, ok := skillAbilities[skill]
+	if !ok {
+		return nil, MakeSkillCheckOutput{}, fmt.Errorf("unknown skill: %s (valid skills: %s)", input.Skill, strings.Join(sortedKeys(skillAbilities), ", "))
+	}
+
+	modifiers := entity.checkModifiers()
+	if input.Advantage {
+		modifiers.add(resources.RollAdvantage, "circumstance")
+	}
+	if input.Disadvantage {
+		modifiers.add(resources.RollDisadvantage, "circumstance")
+	}
+	roll, rolls, mode := modifiers.roll()
+	bonus, proficient := skillCheckBonus(entity, skill)
+
+	output := MakeSkillCheckOutput{
+		Skill:      skill,
+		Ability:    ability,
+		Proficient: proficient,
+		Roll:       roll,
+		Rolls:      rolls,
+		RollMode:   mode,
+		RollReason: modifiers.describe(),
+		Bonus:      bonus,
+		Total:      roll + bonus,
+		DC:         input.DC,
+	}
+	message := fmt.Sprintf("%s rolls %s (%s) %d%+d=%d", entity.Name, skill, ability, roll, bonus, output.Total)
+	if input.DC > 0 {
+		success := output.Total >= input.DC
+		output.Success = &success
+		if success {
+			message += fmt.Sprintf(" vs DC %d: SUCCESS", input.DC)
+		} else {
+			message += fmt.Sprintf(" vs DC %d: FAILURE", input.DC)
+		}
+	}
+	if mode != "" {
+		message += fmt.Sprintf(" (%s, rolled %v)", output.RollReason, rolls)
+	} else if output.RollReason != "" {
+		message += fmt.Sprintf(" (%s)", output.RollReason)
+	}
+	output.Message = message + "."
+
+	return nil, output, nil
+}
+
+// RollAbilityCheckInput defines a raw ability check
+type RollAbilityCheckInput struct {
+	EncounterScope
+	EntityID     string `json:"entity_id"`
+	Ability      string `json:"ability" jsonschema:"STR, DEX, CON, INT, WIS, CHA"`
+	DC           int    `json:"dc,omitempty" jsonschema:"Difficulty to beat; omit to just report the total"`
+	Advantage    bool   `json:"advantage,omitempty" jsonschema:"Other sources of advantage"`
+	Disadvantage bool   `json:"disadvantage,omitempty" jsonschema:"Other sources of disadvantage"`
+}
+
+type RollAbilityCheckOutput struct {
+	Ability    string `json:"ability"`
+	Score      int    `json:"score" jsonschema:"Ability score the modifier comes from (10 when none is recorded)"`
+	Roll       int    `json:"roll" jsonschema:"natural d20 result"`
+	Rolls      []int  `json:"rolls,omitempty" jsonschema:"both d20s when rolled with advantage or disadvantage"`
+	RollMode   string `json:"roll_mode,omitempty"`
+	RollReason string `json:"roll_reason,omitempty" jsonschema:"What gave the roll advantage or disadvantage"`
+	Modifier   int    `json:"modifier"`
+	Total      int    `json:"total"`
+	DC         int    `json:"dc,omitempty"`
+	Success    *bool  `json:"success,omitempty" jsonschema:"Whether the total met the DC, when one was given"`
+	Message    string `json:"message"`
+}
+
+func handleRollAbilityCheck(ctx context.Context, req *mcp.CallToolRequest, input RollAbilityCheckInput) (*mcp.CallToolResult, RollAbilityCheckOutput, error) {
+	entity := combatState.Entities[input.EntityID]
+	if entity == nil {
+		return nil, RollAbilityCheckOutput{}, fmt.Errorf("entity not found: %s", input.EntityID)
+	}
+	ability := strings.ToUpper(strings.TrimSpace(input.Ability))
+	if !slices.Contains(abilityNames, ability) {
+		return nil, RollAbilityCheckOutput{}, fmt.Errorf("unknown ability: %s (valid abilities: %s)", input.Ability, strings.Join(abilityNames, ", "))
+	}
+
+	modifiers := entity.checkModifiers()
+	if input.Advantage {
+		modifiers.add(resources.RollAdvantage, "circumstance")
+	}
+	if input.Disadvantage {
+		modifiers.add(resources.RollDisadvantage, "circumstance")
+	}
+	roll, rolls, mode := modifiers.roll()
+	modifier := abilityModifier(entity, ability)
+
+	score, ok := entity.AbilityScores[ability]
+	if !ok {
+		score = 10
+	}
+	output := RollAbilityCheckOutput{
+		Ability:    ability,
+		Score:      score,
+		Roll:       roll,
+		Rolls:      rolls,
+		RollMode:   mode,
+		RollReason: modifiers.describe(),
+		Modifier:   modifier,
+		Total:      roll + modifier,
+		DC:         input.DC,
+	}
+	message := fmt.Sprintf("%s rolls a %s check (score %d) %d%+d=%d", entity.Name, ability, score, roll, modifier, output.Total)
+	if input.DC > 0 {
+		success := output.Total >= input.DC
+		output.Success = &success
+		if success {
+			message += fmt.Sprintf(" vs DC %d: SUCCESS", input.DC)
+		} else {
+			message += fmt.Sprintf(" vs DC %d: FAILURE", input.DC)
+		}
+	}
+	if mode != "" {
+		message += fmt.Sprintf(" (%s, rolled %v)", output.RollReason, rolls)
+	} else if output.RollReason != "" {
+		message += fmt.Sprintf(" (%s)", output.RollReason)
+	}
+	output.Message = message + "."
+
+	return nil, output, nil
+}
+
+// GetPassiveScoreInput defines a passive skill lookup
+type GetPassiveScoreInput struct {
+	EncounterScope
+	EntityID     string `json:"entity_id"`
+	Skill        string `json:"skill,omitempty" jsonschema:"Skill name (defaults to perception)"`
+	Advantage    bool   `json:"advantage,omitempty" jsonschema:"Other sources of advantage, adding 5"`
+	Disadvantage bool   `json:"disadvantage,omitempty" jsonschema:"Other sources of disadvantage, subtracting 5, e.g. dim light for sight"`
+}
+
+type GetPassiveScoreOutput struct {
+	Skill      string `json:"skill"`
+	Bonus      int    `json:"bonus"`
+	Adjustment int    `json:"adjustment" jsonschema:"+5 for advantage, -5 for disadvantage"`
+	RollReason string `json:"roll_reason,omitempty" jsonschema:"What gave advantage or disadvantage"`
+	Score      int    `json:"score"`
+	StatBlock  int    `json:"stat_block,omitempty" jsonschema:"Passive Perception listed in the creature's senses, for comparison"`
+	Message    string `json:"message"`
+}
+
+func handleGetPassiveScore(ctx context.Context, req *mcp.CallToolRequest, input GetPassiveScoreInput) (*mcp.CallToolResult, GetPassiveScoreOutput, error) {
+	entity := combatState.Entities[input.EntityID]
+	if entity == nil {
+		return nil, GetPassiveScoreOutput{}, fmt.Errorf("entity not found: %s", input.EntityID)
+	}
+	skill := strings.ToLower(strings.TrimSpace(input.Skill))
+	if skill == "" {
+		skill = "perception"
+	}
+	if _, ok := skillAbilities[skill]; !ok {
+		return nil, GetPassiveScoreOutput{}, fmt.Errorf("unknown skill: %s (valid skills: %s)", input.Skill, strings.Join(sortedKeys(skillAbilities), ", "))
+	}
+
+	modifiers := entity.checkModifiers()
+	if input.Advantage {
+		modifiers.add(resources.RollAdvantage, "circumstance")
+	}
+	if input.Disadvantage {
+		modifiers.add(resources.RollDisadvantage, "circumstance")
+	}
+	output := GetPassiveScoreOutput{
+		Skill:      skill,
+		Bonus:      skillBonus(entity, skill),
+		Adjustment: modifiers.passive(),
+		RollReason: modifiers.describe(),
+		Score:      passiveScore(entity, skill, modifiers),
+	}
+	message := fmt.Sprintf("%s's passive %s is %d (10%+d", entity.Name, skill, output.Score, output.Bonus)
+	if output.Adjustment != 0 {
+		message += fmt.Sprintf("%+d", output.Adjustment)
+	}
+	message += ")"
+	if output.RollReason != "" {
+		message += fmt.Sprintf(" with %s", output.RollReason)
+	}
+
+	// Stat blocks list passive Perception under senses
+	if monster, ok := resources.GetMonster(entity.MonsterName); ok && skill == "perception" {
+		if listed, ok := monster.Senses["perception"]; ok {
+			output.StatBlock = listed
+			switch {
+			case output.Adjustment != 0:
+			case listed == output.Score:
+				message += ", matching the stat block"
+			default:
+				message += fmt.Sprintf("; the stat block lists %d", listed)
+			}
+		}
+	}
+	output.Message = message + "."
+
+	return nil, output, nil
+}
@@ -3,18 +3,38 @@ package tools
 import (
 	"context"
 	"fmt"
+	"maps"
 	"math/rand"
+	"slices"
 	"sort"
+	"strconv"
+	"strings"
 
+	"github.com/kiriyms/dungeon-master-mcp/resources"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
 // CombatState tracks the current combat session
 type CombatState struct {
-	Entities    map[string]*Entity // entity_id -> Entity
-	TurnOrder   []string           // ordered list of entity IDs
-	CurrentTurn int                // index in TurnOrder
-	RoundNumber int
+	Entities     map[string]*Entity // entity_id -> Entity
+	TurnOrder    []string           // ordered list of entity IDs
+	CurrentTurn  int                // index in TurnOrder
+	RoundNumber  int
+	EventLog     []string       // notable events that aren't captured by entity state
+	TimedEffects []*TimedEffect // non-concentration durations such as walls and summons
+	DamageOrder  []string       // damage pipeline stage order (nil = default)
+	// Declared save effects awaiting rolls, keyed by effect ID
+	PendingEffects map[string]*PendingEffect
+	PendingSeq     int
+	InitiativeSeed int64 // breaks initiative ties that DEX doesn't, the same way every sort
+	// Lair actions happen once per round on initiative count 20
+	LairActionRound     int // round whose lair actions were last announced
+	LairActionUsedRound int // round in which a lair action was last taken
+}
+
+// logEvent records a notable event, prefixed with the current round
+func (cs *CombatState) logEvent(format string, args ...any) {
+	cs.EventLog = append(cs.EventLog, fmt.Sprintf("Round %d: ", cs.RoundNumber)+fmt.Sprintf(format, args...))
 }
 
 // Entity represents a combatant (PC or monster)
@@ -25,247 +45,1370 @@ type Entity struct {
 	MaxHP                int
 	CurrentHP            int
 	AC                   int
-	Conditions           map[string]int // condition -> turns remaining (-1 = permanent)
-	Resources            map[string]int // resource_name -> current count
+	Conditions           map[string]int    // condition -> turns remaining (-1 = permanent)
+	ConditionSources     map[string]string // condition -> entity ID that imposed it, e.g. the grappler
+	ConditionEnds        map[string]string // condition -> end_of_turn or save_ends; others count down at the start of the turn
+	Resources            map[string]int    // resource_name -> current count
+	ResourceMax          map[string]int    // resource_name -> most it can hold, when capped
 	IsMonster            bool
 	MonsterName          string // for loading stats
 	LegendaryActions     int    // remaining this round
 	MaxLegendaryActions  int
 	LegendaryResistances int
+	LegendaryResetRound  int                 // round in which legendary actions were last refilled
+	Concentrating        string              // spell being concentrated on ("" if none)
+	ConcentrationLinks   []ConcentrationLink // conditions on others that end with this entity's concentration
+	ReadiedAction        *ReadiedAction      // action held until its trigger, if any
+	NextHitBonus         *NextHitBonus       // one-shot damage amplifier consumed by the next damage taken
+	OngoingEffects       []*OngoingEffect
+	Dead                 bool
+	ExhaustionLevel      int // 0-6; each level adds a cumulative penalty
+	ExhaustionMaxHP      int // hit point maximum from before exhaustion halved it (0 if not halved)
+	DeathSaveSuccesses   int // reset when the entity is healed above 0 HP
+	DeathSaveFailures    int
+	Distances            map[string]int // entity_id -> feet, when the DM tracks relative distance
+	Position             *[2]int        // grid coordinates in feet, when the DM uses a grid
+	CreatureType         string         // dragon, humanoid, undead, etc.
+	Size                 string         // Tiny, Small, Medium, Large, Huge, Gargantuan
+	TempImmunities       map[string]int // damage type -> rounds remaining (-1 = until revoked), separate from stat-block immunities
+	// Damage modifiers applied by the damage pipeline
+	Resistances          []string
+	Vulnerabilities      []string
+	Immunities           []string // innate immunities, e.g. from the stat block
+	DamageReduction      int      // flat reduction, e.g. Heavy Armor Master
+	DamageReductionTypes []string // damage types the reduction applies to (empty = all)
+	// Action economy, reset at the start of the entity's turn
+	Speed           int // walking speed in feet
+	Reach           int // melee reach in feet, for opportunity attacks
+	ActionUsed      bool
+	BonusActionUsed bool
+	ReactionUsed    bool
+	MovementUsed    int  // feet moved this turn
+	Disengaged      bool // movement doesn't provoke opportunity attacks this turn
+	// Ability scores and proficiencies used for checks
+	AbilityScores    map[string]int // STR, DEX, CON, INT, WIS, CHA
+	Skills           map[string]int // skill name -> total bonus, for proficient skills
+	ProficiencyBonus int
+	SavingThrows     map[string]int // total bonus for each proficient save; others use the ability modifier
+	DamageDealt      int            // total damage this entity has dealt this encounter
+	Hidden           bool           // unseen by its enemies until it attacks or is found
+	// Pools of expendable dice such as superiority dice; the current count is kept in Resources
+	DicePools map[string]*DicePool
+	// Abilities such as breath weapons that recharge on a d6 at the start of the entity's turn
+	RechargeAbilities map[string]bool // ability -> currently available
+	RechargeOn        map[string]int  // ability -> lowest d6 roll that recharges it (defaults to 5)
+	// Set by a critical make_attack against the entity; the next apply_damage rolls double dice
+	PendingCritical bool
+	// LegendaryResistances refill to this with reset_legendary_resistances
+	MaxLegendaryResistances int
+	// Set by delay_turn; the delayed turn skips the start-of-turn effects it already had
+	DelayedTurn bool
+	// Surprised creatures lose their turn in round 1
+	Surprised bool
+	// Entities sharing a group ID take one initiative slot and act together
+	GroupID string
+	// Free-form DM annotations, oldest first
+	Notes []string
+	// Spell level -> slots remaining, and the most each level holds
+	SpellSlots    map[int]int
+	MaxSpellSlots map[int]int
+	PactMagic     bool // a warlock's slots come back on a short rest
+}
+
+// IsBloodied reports whether the entity is at or below half its max HP but still standing
+func (e *Entity) IsBloodied() bool {
+	return e.CurrentHP > 0 && e.CurrentHP*2 <= e.MaxHP
+}
+
+// incapacitatingConditions all include the incapacitated condition: no actions or reactions
+var incapacitatingConditions = []string{"incapacitated", "paralyzed", "petrified", "stunned", "unconscious"}
+
+// IsIncapacitated reports whether any condition prevents the entity from taking actions or reactions
+func (e *Entity) IsIncapacitated() bool {
+	for _, condition := range incapacitatingConditions {
+		if _, ok := e.Conditions[condition]; ok {
+			return true
+		}
+	}
+	return false
 }
 
-var combatState *CombatState
+var combatState *CombatState
+
+// RegisterCombatTools adds all combat-related tools to the server
+func RegisterCombatTools(server *mcp.Server) {
+	// Initialize combat state with a single default encounter
+	resetEncounters()
+
+	// Tool 1: Start Combat
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "start_combat",
+			Description: "Initialize combat with party members and monsters, assigns initiative",
+		},
+		inEncounter(undoable(handleStartCombat)),
+	)
+
+	// Tool 2: Next Turn
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "next_turn",
+			Description: "Advance to next turn, handle start-of-turn effects",
+		},
+		inEncounter(undoable(requiresCombat(handleNextTurn))),
+	)
+
+	// Tool 3: Apply Damage
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "apply_damage",
+			Description: "Apply damage to target with resistance/vulnerability/immunity calculations",
+		},
+		inEncounter(undoable(requiresCombat(handleApplyDamage))),
+	)
+
+	// Tool 4: Apply Healing
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "apply_healing",
+			Description: "Heal target and update hit points",
+		},
+		inEncounter(undoable(requiresCombat(handleApplyHealing))),
+	)
+
+	// Tool 5: Add Condition
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "add_condition",
+			Description: "Apply a condition to an entity with duration",
+		},
+		inEncounter(undoable(requiresCombat(handleAddCondition))),
+	)
+
+	// Tool 6: Make Saving Throw
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "make_saving_throw",
+			Description: "Roll saving throw with legendary resistance option",
+		},
+		inEncounter(undoable(requiresCombat(handleSavingThrow))),
+	)
+
+	// Tool 7: Use Legendary Action
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "use_legendary_action",
+			Description: "Use a monster's legendary action",
+		},
+		inEncounter(undoable(requiresCombat(handleLegendaryAction))),
+	)
+
+	// Tool 8: Track Resource
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "track_resource",
+			Description: "Track a resource like spell slots or limited abilities: set its value, or spend and restore with a delta, clamped between 0 and an optional max",
+		},
+		inEncounter(undoable(requiresCombat(handleTrackResource))),
+	)
+
+	// Tool 9: Swarm Attack
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "swarm_attack",
+			Description: "Resolve a melee attack from each of several attackers, or every member of an initiative group, against a single target and apply the total damage",
+		},
+		inEncounter(undoable(requiresCombat(handleSwarmAttack))),
+	)
+
+	// Tool 10: Publish Snapshot
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "publish_snapshot",
+			Description: "Publish the current combat state as a shareable read-only snapshot",
+		},
+		inEncounter(requiresCombat(handlePublishSnapshot)),
+	)
+
+	// Tool 11: Load Snapshot
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "load_snapshot",
+			Description: "Replace the current combat state with a published snapshot",
+		},
+		inEncounter(undoable(handleLoadSnapshot)),
+	)
+
+	// Tool 12: Monster DPR
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "monster_dpr",
+			Description: "Estimate a monster's average damage per round from its stat block actions",
+		},
+		handleMonsterDPR,
+	)
+
+	// Tool 13: Ready Action
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "ready_action",
+			Description: "Ready an action or spell to trigger later; readied spells hold concentration until released",
+		},
+		inEncounter(undoable(requiresCombat(handleReadyAction))),
+	)
+
+	// Tool 14: Trigger Readied Action
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "trigger_readied_action",
+			Description: "Release an entity's readied action when its trigger occurs",
+		},
+		inEncounter(undoable(requiresCombat(handleTriggerReadiedAction))),
+	)
+
+	// Tool 15: Mark Next Hit
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "mark_next_hit",
+			Description: "Make a target take doubled or extra damage from the next hit only",
+		},
+		inEncounter(undoable(requiresCombat(handleMarkNextHit))),
+	)
+
+	// Tool 16: Ongoing Save Effect
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "ongoing_save_effect",
+			Description: "Register recurring start-of-turn damage that a saving throw can end (e.g. poison, burning)",
+		},
+		inEncounter(undoable(requiresCombat(handleOngoingSaveEffect))),
+	)
+
+	// Tool 17: Reconcile Entity
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "reconcile_entity",
+			Description: "Correct an entity's max/current HP, clamping HP into range and recomputing bloodied, unconscious, and dead status",
+		},
+		inEncounter(undoable(requiresCombat(handleReconcileEntity))),
+	)
+
+	// Tool 18: Random Monster
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "random_monster",
+			Description: "Pick a random monster from the catalog, optionally filtered by CR and creature type",
+		},
+		handleRandomMonster,
+	)
+
+	// Tool 19: Forced Movement
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "forced_movement",
+			Description: "Push or pull a creature (Thunderwave, Wing Attack, shove), optionally knocking it prone and tracking its distance from the source",
+		},
+		inEncounter(undoable(requiresCombat(handleForcedMovement))),
+	)
+
+	// Tool 20: Resolve Monster Round
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "resolve_monster_round",
+			Description: "Fast-forward consecutive minion turns: resolve each assigned monster attack in initiative order and advance turns",
+		},
+		inEncounter(undoable(requiresCombat(handleResolveMonsterRound))),
+	)
+
+	// Tool 21: Legendary Opportunity
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "legendary_opportunity",
+			Description: "At the end of the current turn, list legendary creatures with actions to spend and their affordable options",
+		},
+		inEncounter(requiresCombat(handleLegendaryOpportunity)),
+	)
+
+	// Tool 22: Roll Table
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "roll_table",
+			Description: "Roll on a custom weighted random table",
+		},
+		handleRollTable,
+	)
+
+	// Tool 23: Critical Hit Effect
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "crit_effect",
+			Description: "Roll on a critical-hit effects table and apply the resulting condition or ongoing damage",
+		},
+		inEncounter(undoable(requiresCombat(handleCritEffect))),
+	)
+
+	// Tool 24: Add Timed Effect
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "add_timed_effect",
+			Description: "Track a spell or effect duration (e.g. a 1-minute wall or 1-hour summon) with optional cleanup when it expires",
+		},
+		inEncounter(undoable(requiresCombat(handleAddTimedEffect))),
+	)
+
+	// Tool 25: Advance Time
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "advance_time",
+			Description: "Advance out-of-combat time in minutes, expiring timed effects",
+		},
+		inEncounter(undoable(handleAdvanceTime)),
+	)
+
+	// Tool 26: Monster Saves
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "monster_saves",
+			Description: "List all six saving throw bonuses for a monster, deriving non-proficient saves from ability modifiers",
+		},
+		handleMonsterSaves,
+	)
+
+	// Tool 27: Resolve Concentration Checks
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "resolve_concentration_checks",
+			Description: "Roll concentration saves for every concentrating creature damaged by one effect, dropping concentration and linked conditions on failures",
+		},
+		inEncounter(undoable(requiresCombat(handleResolveConcentrationChecks))),
+	)
+
+	// Tool 28: Grant Immunity
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "grant_immunity",
+			Description: "Grant or revoke a temporary damage-type immunity (Protection from Energy, boss phases) that expires after a number of rounds",
+		},
+		inEncounter(undoable(requiresCombat(handleGrantImmunity))),
+	)
+
+	// Tool 29: Refresh Actions
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "refresh_actions",
+			Description: "Reset an entity's action, bonus action, reaction, and movement to a fresh-turn state without advancing the turn",
+		},
+		inEncounter(undoable(requiresCombat(handleRefreshActions))),
+	)
+
+	// Tool 30: Register Pending Effect
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "register_pending_effect",
+			Description: "Declare a save-or-suffer effect (damage and/or condition) against targets before their saves are rolled",
+		},
+		inEncounter(undoable(requiresCombat(handleRegisterPendingEffect))),
+	)
+
+	// Tool 31: Resolve Pending Save
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "resolve_pending_save",
+			Description: "Roll one target's save against a pending effect and apply its consequences on a pass or fail",
+		},
+		inEncounter(undoable(requiresCombat(handleResolvePendingSave))),
+	)
+
+	// Tool 32: Escape Grapple
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "escape_grapple",
+			Description: "Roll a grappled creature's Athletics or Acrobatics check against its grappler's escape DC, ending the grapple on success",
+		},
+		inEncounter(undoable(requiresCombat(handleEscapeGrapple))),
+	)
+
+	// Tool 33: Reroll All Initiative
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "reroll_all_initiative",
+			Description: "Re-roll every combatant's initiative, re-sort the order, and restart at the top of the round",
+		},
+		inEncounter(undoable(requiresCombat(handleRerollAllInitiative))),
+	)
+
+	// Tool 34: Combat Forecast
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "combat_forecast",
+			Description: "Estimate how many rounds combat will last from remaining enemy HP and party damage per round (a heuristic, not a guarantee)",
+		},
+		inEncounter(requiresCombat(handleCombatForecast)),
+	)
+
+	// Tool 35: Damage Leaderboard
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "damage_leaderboard",
+			Description: "Rank combatants by total damage dealt this encounter",
+		},
+		inEncounter(requiresCombat(handleDamageLeaderboard)),
+	)
+
+	// Tool 36: Simulate Round
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "simulate_round",
+			Description: "Play out one simulated round with simple targeting heuristics to test encounter balance; runs on a copy unless apply is set",
+		},
+		inEncounter(undoable(requiresCombat(handleSimulateRound))),
+	)
+
+	// Tool 37: Get Effective Speed
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "get_effective_speed",
+			Description: "Report an entity's current speed after conditions (grappled/restrained = 0, prone = half) and the reasons for any reduction",
+		},
+		inEncounter(requiresCombat(handleGetEffectiveSpeed)),
+	)
+
+	// Tool 38: Move
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "move",
+			Description: "Spend an entity's movement for the turn, refusing moves beyond its effective speed",
+		},
+		inEncounter(undoable(requiresCombat(handleMove))),
+	)
+
+	// Tool 39: Ability Attack
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "ability_attack",
+			Description: "Resolve a stat block action by attack roll or saving throw (defaulting from its attack bonus or save DC), applying damage and an optional condition rider",
+		},
+		inEncounter(undoable(requiresCombat(handleAbilityAttack))),
+	)
+
+	// Tool 40: Random Eye Rays
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "random_eye_rays",
+			Description: "Randomly pick distinct save-based effects (e.g. beholder eye rays) and resolve each against a target",
+		},
+		inEncounter(undoable(requiresCombat(handleRandomEyeRays))),
+	)
+
+	// Tool 41: Set Damage Modifiers
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "set_damage_modifiers",
+			Description: "Record an entity's damage resistances, vulnerabilities, immunities, and flat damage reduction",
+		},
+		inEncounter(undoable(requiresCombat(handleSetDamageModifiers))),
+	)
+
+	// Tool 42: Set Damage Order
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "set_damage_order",
+			Description: "Configure the order of the damage pipeline stages (vulnerability, resistance, reduction, immunity)",
+		},
+		inEncounter(undoable(requiresCombat(handleSetDamageOrder))),
+	)
+
+	// Tool 43: Hide
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "hide",
+			Description: "Attempt to hide: roll Stealth against the passive Perception of the creatures that could spot the entity",
+		},
+		inEncounter(undoable(requiresCombat(handleHide))),
+	)
+
+	// Tool 44: Attack Roll
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "attack_roll",
+			Description: "Make a single attack roll, applying advantage from hiding (which reveals the attacker) and disadvantage against hidden targets",
+		},
+		inEncounter(undoable(requiresCombat(handleAttackRoll))),
+	)
+
+	// Tool 45: Set Dice Pool
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "set_dice_pool",
+			Description: "Give an entity a pool of expendable dice, such as a Battle Master's superiority dice, that recharges on a rest",
+		},
+		inEncounter(undoable(requiresCombat(handleSetDicePool))),
+	)
+
+	// Tool 46: Use Maneuver
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "use_maneuver",
+			Description: "Spend a die from a pool on a maneuver: roll it, add it to the attack's damage, and resolve the maneuver's rider",
+		},
+		inEncounter(undoable(requiresCombat(handleUseManeuver))),
+	)
+
+	// Tool 47: Short Rest
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "short_rest",
+			Description: "Take a short rest, restoring dice pools that recharge on a short rest and pact magic spell slots, with a summary per entity",
+		},
+		inEncounter(undoable(requiresCombat(handleShortRest))),
+	)
+
+	// Tool 48: Set Position
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "set_position",
+			Description: "Place an entity at grid coordinates (in feet), or clear its position for grid-less play",
+		},
+		inEncounter(undoable(requiresCombat(handleSetPosition))),
+	)
+
+	// Tool 49: Creatures In Range
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "creatures_in_range",
+			Description: "List the creatures within a radius of a point or entity, with their distances, to use as an area effect's targets",
+		},
+		inEncounter(requiresCombat(handleCreaturesInRange)),
+	)
+
+	// Tool 50: Disengage
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "disengage",
+			Description: "Take the Disengage action so the entity's movement doesn't provoke opportunity attacks for the rest of its turn",
+		},
+		inEncounter(undoable(requiresCombat(handleDisengage))),
+	)
+
+	// Tool 51: Opportunity Attack
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "opportunity_attack",
+			Description: "Spend a creature's reaction on an opportunity attack against a creature leaving its reach",
+		},
+		inEncounter(undoable(requiresCombat(handleOpportunityAttack))),
+	)
+
+	// Tool 52: Import Party
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "import_party",
+			Description: "Import a party of player characters from a JSON array and store it for start_combat to reuse",
+		},
+		handleImportParty,
+	)
+
+	// Tool 53: Get Condition
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "get_condition",
+			Description: "Look up one condition's rules text and mechanical effects by name",
+		},
+		handleGetCondition,
+	)
+
+	// Tool 54: Roll Dice
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "roll_dice",
+			Description: "Roll a dice expression such as 2d8+5, 4d6kh3 (keep highest), or 2d20kl1 (keep lowest), returning each die and the total",
+		},
+		handleRollDice,
+	)
+
+	// Tool 55: Death Save
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "death_save",
+			Description: "Roll a death saving throw for a creature at 0 HP, tracking successes and failures until it stabilizes, dies, or rallies on a natural 20",
+		},
+		inEncounter(undoable(requiresCombat(handleDeathSave))),
+	)
+
+	// Tool 56: Recharge Ability
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "recharge_ability",
+			Description: "Track a recharge ability such as a breath weapon, set its recharge range, or mark it spent; spent abilities are rolled for at the start of the creature's turn",
+		},
+		inEncounter(undoable(requiresCombat(handleRechargeAbility))),
+	)
+
+	// Tool 57: Lair Action
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "lair_action",
+			Description: "Take a monster's lair action on initiative count 20, at most once per round",
+		},
+		inEncounter(undoable(requiresCombat(handleLairAction))),
+	)
+
+	// Tool 58: Undo Last Action
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "undo_last_action",
+			Description: "Revert the most recent action that changed the combat state, such as damage, healing, a condition, or a turn advance",
+		},
+		inEncounter(handleUndoLastAction),
+	)
+
+	// Tool 59: Save Combat
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "save_combat",
+			Description: "Save the whole combat state to a named file on disk so it survives a server restart",
+		},
+		inEncounter(requiresCombat(handleSaveCombat)),
+	)
+
+	// Tool 60: Load Combat
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "load_combat",
+			Description: "Resume a combat saved with save_combat, replacing the current combat state",
+		},
+		inEncounter(undoable(handleLoadCombat)),
+	)
+
+	// Tool 61: Remove Entity
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "remove_entity",
+			Description: "Take a creature that died or fled out of combat, keeping the turn order on track",
+		},
+		inEncounter(undoable(requiresCombat(handleRemoveEntity))),
+	)
+
+	// Tool 62: Add Entity
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "add_entity",
+			Description: "Bring a new combatant, such as a reinforcement, into the ongoing combat at its place in the initiative order",
+		},
+		inEncounter(undoable(requiresCombat(handleAddEntity))),
+	)
+
+	// Tool 63: Start Concentration
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "start_concentration",
+			Description: "Start concentrating on a spell, ending any spell the caster was already concentrating on",
+		},
+		inEncounter(undoable(requiresCombat(handleStartConcentration))),
+	)
+
+	// Tool 64: Set Exhaustion
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "set_exhaustion",
+			Description: "Set or change a creature's exhaustion level (0-6) and report the cumulative penalties it suffers",
+		},
+		inEncounter(undoable(requiresCombat(handleSetExhaustion))),
+	)
+
+	// Tool 65: Make Attack
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "make_attack",
+			Description: "Roll an attack to hit against the target's AC without rolling damage; a critical hit makes the next apply_damage on the target double its dice",
+		},
+		inEncounter(undoable(requiresCombat(handleMakeAttack))),
+	)
+
+	// Tool 66: Resolve Attack
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "resolve_attack",
+			Description: "Resolve a whole attack in one call: roll to hit, roll damage (doubled on a critical), apply resistances, and update the target's HP, with a breakdown of each step",
+		},
+		inEncounter(undoable(requiresCombat(handleResolveAttack))),
+	)
+
+	// Tool 67: Get Combat State
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "get_combat_state",
+			Description: "Get the whole encounter at once: round, initiative order, and every combatant's HP, conditions, and legendary actions and resistances",
+		},
+		inEncounter(requiresCombat(handleGetCombatState)),
+	)
+
+	// Tool 68: List Encounters
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "list_encounters",
+			Description: "List the named encounters being run, which one is active, and how far each has got",
+		},
+		handleListEncounters,
+	)
+
+	// Tool 69: Switch Encounter
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "switch_encounter",
+			Description: "Make a named encounter the one combat tools act on when no encounter_id is given, creating it if it's new",
+		},
+		handleSwitchEncounter,
+	)
+
+	// Tool 70: Use Reaction
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "use_reaction",
+			Description: "Spend a creature's reaction, e.g. on Shield or Hellish Rebuke; fails if it already reacted since its last turn",
+		},
+		inEncounter(undoable(requiresCombat(handleUseReaction))),
+	)
+
+	// Tool 71: Reset Legendary Resistances
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "reset_legendary_resistances",
+			Description: "Restore spent legendary resistances to their maximum, after a long rest or between encounters at the DM's discretion",
+		},
+		inEncounter(undoable(requiresCombat(handleResetLegendaryResistances))),
+	)
+
+	// Tool 72: Make Skill Check
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "make_skill_check",
+			Description: "Roll a skill check such as Perception or Stealth using the creature's stat block skill bonus or ability modifier, optionally against a DC",
+		},
+		inEncounter(requiresCombat(handleMakeSkillCheck)),
+	)
+
+	// Tool 73: Contested Roll
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "contested_roll",
+			Description: "Resolve an opposed check between two creatures, such as Athletics vs Acrobatics for a grapple or shove, or Stealth vs passive Perception; ties go to the defender",
+		},
+		inEncounter(requiresCombat(handleContestedRoll)),
+	)
+
+	// Tool 74: Save For Damage
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "save_for_damage",
+			Description: "Roll a saving throw against damage and apply full damage on a failure and half (or none) on a success, honoring legendary resistance and damage modifiers",
+		},
+		inEncounter(undoable(requiresCombat(handleSaveForDamage))),
+	)
+
+	// Tool 75: Resolve Area of Effect
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "resolve_aoe",
+			Description: "Resolve an area-of-effect ability such as a fireball against many targets: roll the damage once, then each target's save for full or half damage",
+		},
+		inEncounter(undoable(requiresCombat(handleResolveAOE))),
+	)
+
+	// Tool 76: Roll To End Condition
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "roll_to_end_condition",
+			Description: "Roll the save a creature makes at the end of its turn against a save-ends condition, removing the condition on a success",
+		},
+		inEncounter(undoable(requiresCombat(handleRollToEndCondition))),
+	)
+
+	// Tool 77: Roll Ability Check
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "roll_ability_check",
+			Description: "Roll a raw ability check such as a STR check to break down a door, using the modifier from the creature's ability score, optionally against a DC",
+		},
+		inEncounter(requiresCombat(handleRollAbilityCheck)),
+	)
+
+	// Tool 78: Get Passive Score
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "get_passive_score",
+			Description: "Get a creature's passive score for a skill, such as passive Perception against hidden threats: 10 + skill bonus, +5 with advantage or -5 with disadvantage",
+		},
+		inEncounter(requiresCombat(handleGetPassiveScore)),
+	)
 
-// RegisterCombatTools adds all combat-related tools to the server
-func RegisterCombatTools(server *mcp.Server) {
-	// Initialize combat state
-	combatState = &CombatState{
-		Entities:  make(map[string]*Entity),
-		TurnOrder: []string{},
-	}
+	// Tool 79: Delay Turn
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "delay_turn",
+			Description: "Delay the acting creature's turn to a lower initiative count this round and hand the turn to the next creature; use ready_action instead to act on a trigger",
+		},
+		inEncounter(undoable(requiresCombat(handleDelayTurn))),
+	)
 
-	// Tool 1: Start Combat
+	// Tool 80: Previous Turn
 	mcp.AddTool(server,
 		&mcp.Tool{
-			Name:        "start_combat",
-			Description: "Initialize combat with party members and monsters, assigns initiative",
+			Name:        "previous_turn",
+			Description: "Move the turn pointer back one place, to the previous round when at the top of the order, without re-running or reversing any turn effects; use undo_last_action to reverse a next_turn fully",
 		},
-		handleStartCombat,
+		inEncounter(undoable(requiresCombat(handlePreviousTurn))),
 	)
 
-	// Tool 2: Next Turn
+	// Tool 81: List Group
 	mcp.AddTool(server,
 		&mcp.Tool{
-			Name:        "next_turn",
-			Description: "Advance to next turn, handle start-of-turn effects",
+			Name:        "list_group",
+			Description: "Show the status of every member of an initiative group, defaulting to the group whose turn it is",
 		},
-		handleNextTurn,
+		inEncounter(requiresCombat(handleListGroup)),
 	)
 
-	// Tool 3: Apply Damage
+	// Tool 82: Add Note
 	mcp.AddTool(server,
 		&mcp.Tool{
-			Name:        "apply_damage",
-			Description: "Apply damage to target with resistance/vulnerability/immunity calculations",
+			Name:        "add_note",
+			Description: "Attach a free-form note to a combatant, such as guarding the door or illusory duplicate; notes show in get_combat_state and the turn summary",
 		},
-		handleApplyDamage,
+		inEncounter(undoable(requiresCombat(handleAddNote))),
 	)
 
-	// Tool 4: Apply Healing
+	// Tool 83: Remove Note
 	mcp.AddTool(server,
 		&mcp.Tool{
-			Name:        "apply_healing",
-			Description: "Heal target and update hit points",
+			Name:        "remove_note",
+			Description: "Remove one note, or every note, from a combatant",
 		},
-		handleApplyHealing,
+		inEncounter(undoable(requiresCombat(handleRemoveNote))),
 	)
 
-	// Tool 5: Add Condition
+	// Tool 84: Get Resources
 	mcp.AddTool(server,
 		&mcp.Tool{
-			Name:        "add_condition",
-			Description: "Apply a condition to an entity with duration",
+			Name:        "get_resources",
+			Description: "List every resource an entity tracks, with its max and any dice pool it belongs to",
 		},
-		handleAddCondition,
+		inEncounter(requiresCombat(handleGetResources)),
 	)
 
-	// Tool 6: Make Saving Throw
+	// Tool 85: Set Spell Slots
 	mcp.AddTool(server,
 		&mcp.Tool{
-			Name:        "make_saving_throw",
-			Description: "Roll saving throw with legendary resistance option",
+			Name:        "set_spell_slots",
+			Description: "Give an entity its spell slots, from the SRD table for a class and level or as a custom count per spell level",
 		},
-		handleSavingThrow,
+		inEncounter(undoable(requiresCombat(handleSetSpellSlots))),
 	)
 
-	// Tool 7: Use Legendary Action
+	// Tool 86: Expend Spell Slot
 	mcp.AddTool(server,
 		&mcp.Tool{
-			Name:        "use_legendary_action",
-			Description: "Use a monster's legendary action",
+			Name:        "expend_spell_slot",
+			Description: "Spend one spell slot of a given level and show the slots left at each level",
 		},
-		handleLegendaryAction,
+		inEncounter(undoable(requiresCombat(handleExpendSpellSlot))),
 	)
 
-	// Tool 8: Track Resource
+	// Tool 87: Restore Spell Slots
 	mcp.AddTool(server,
 		&mcp.Tool{
-			Name:        "track_resource",
-			Description: "Track a resource like spell slots or limited abilities",
+			Name:        "restore_spell_slots",
+			Description: "Regain spent spell slots at one level or all of them, e.g. after a rest",
+		},
+		inEncounter(undoable(requiresCombat(handleRestoreSpellSlots))),
+	)
+
+	// Tool 88: Long Rest
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "long_rest",
+			Description: "Take a long rest: restore HP, spell slots, dice pools, capped resources, recharge abilities, and legendary resistances, and remove one level of exhaustion, with a summary per entity",
+		},
+		inEncounter(undoable(requiresCombat(handleLongRest))),
+	)
+
+	// Tool 89: Calculate Encounter Difficulty
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "calculate_encounter_difficulty",
+			Description: "Rate a planned encounter: total the monsters' XP by CR, apply the multiplier for their number, and compare against the party's easy/medium/hard/deadly thresholds",
 		},
-		handleTrackResource,
+		handleCalculateEncounterDifficulty,
 	)
 }
 
 // StartCombatInput defines the structure for starting combat
 type StartCombatInput struct {
+	EncounterScope
 	Entities []EntityInit `json:"entities" jsonschema:"List of combatants with initiative"`
+	// Characters stored with import_party join the combat alongside the listed entities
+	Party           string         `json:"party,omitempty" jsonschema:"Name of an imported party to add to the combat"`
+	PartyInitiative map[string]int `json:"party_initiative,omitempty" jsonschema:"Initiative for each party member ID (unlisted members roll d20 + DEX)"`
+	// Entities listed without an initiative roll d20 + DEX, using the stat block for monsters
+	AutoRollInitiative bool `json:"auto_roll_initiative,omitempty" jsonschema:"Roll d20 + DEX modifier for entities that have no initiative"`
+	// Surprised combatants skip their turn in round 1
+	Surprised []string `json:"surprised,omitempty" jsonschema:"IDs of combatants caught by surprise, who skip their first-round turn"`
 }
 
 type EntityInit struct {
 	ID          string `json:"id" jsonschema:"Unique identifier"`
 	Name        string `json:"name" jsonschema:"Display name"`
-	Initiative  int    `json:"initiative" jsonschema:"Initiative roll"`
+	Initiative  int    `json:"initiative,omitempty" jsonschema:"Initiative roll (omit with auto_roll_initiative to have it rolled)"`
 	HP          int    `json:"hp" jsonschema:"Max hit points"`
 	AC          int    `json:"ac" jsonschema:"Armor class"`
 	IsMonster   bool   `json:"is_monster" jsonschema:"Whether this is a monster"`
 	MonsterName string `json:"monster_name,omitempty" jsonschema:"Monster type name for loading stats"`
+	CurrentHP   *int   `json:"current_hp,omitempty" jsonschema:"Current hit points if already damaged (defaults to max)"`
+	// Monsters take these from their stat block; characters default to a Medium humanoid
+	CreatureType string `json:"creature_type,omitempty" jsonschema:"Creature type (humanoid, dragon, undead, etc)"`
+	Size         string `json:"size,omitempty" jsonschema:"Size category (Tiny, Small, Medium, Large, Huge, Gargantuan)"`
+	Speed        int    `json:"speed,omitempty" jsonschema:"Walking speed in feet (defaults to 30, or the stat block speed for monsters)"`
+	Reach        int    `json:"reach,omitempty" jsonschema:"Melee reach in feet (defaults to 5, or the longest stat block reach for monsters)"`
+	// Monsters take these from their stat block
+	AbilityScores    map[string]int `json:"ability_scores,omitempty" jsonschema:"Ability scores keyed by STR, DEX, CON, INT, WIS, CHA"`
+	Skills           map[string]int `json:"skills,omitempty" jsonschema:"Total bonus for each proficient skill, e.g. {Athletics: 5}"`
+	ProficiencyBonus int            `json:"proficiency_bonus,omitempty" jsonschema:"Proficiency bonus (defaults to 2)"`
+	SavingThrows     map[string]int `json:"saving_throws,omitempty" jsonschema:"Total bonus for each proficient save keyed by STR, DEX, CON, INT, WIS, CHA"`
+	// Overrides the stat block, for legendary creatures the server has no count for
+	LegendaryResistances int `json:"legendary_resistances,omitempty" jsonschema:"Legendary resistances per day"`
+	// Monsters can take their HP from the stat block's hit dice instead
+	HPMode string `json:"hp_mode,omitempty" jsonschema:"For a monster with a stat block: fixed (the hp given, the default), average, or roll from its hit dice"`
+	// Grouped minions share the first member's initiative
+	GroupID string `json:"group_id,omitempty" jsonschema:"Group to act with on one shared initiative count, e.g. goblins"`
 }
 
 type StartCombatOutput struct {
-	TurnOrder []string `json:"turn_order" jsonschema:"Initiative order by entity ID"`
-	Message   string   `json:"message" jsonschema:"Status message"`
+	TurnOrder        []string       `json:"turn_order" jsonschema:"Initiative order by entity ID"`
+	RolledInitiative map[string]int `json:"rolled_initiative,omitempty" jsonschema:"Initiative the server rolled, by entity ID"`
+	RolledHP         map[string]int `json:"rolled_hp,omitempty" jsonschema:"Max HP rolled from hit dice, by entity ID"`
+	Corrections      []string       `json:"corrections,omitempty" jsonschema:"HP values that were clamped on import"`
+	Message          string         `json:"message" jsonschema:"Status message"`
 }
 
 func handleStartCombat(ctx context.Context, req *mcp.CallToolRequest, input StartCombatInput) (*mcp.CallToolResult, StartCombatOutput, error) {
+	entities := input.Entities
+	partyNote := ""
+	if input.Party != "" {
+		members, rolled, err := partyCombatants(input.Party, input.PartyInitiative)
+		if err != nil {
+			return nil, StartCombatOutput{}, err
+		}
+		entities = append(slices.Clone(entities), members...)
+		partyNote = fmt.Sprintf(" The %s party joined with %d characters.", input.Party, len(members))
+		if len(rolled) > 0 {
+			partyNote += fmt.Sprintf(" Rolled initiative: %s.", strings.Join(rolled, ", "))
+		}
+	}
+
+	for _, e := range entities {
+		if err := e.checkHPMode(); err != nil {
+			return nil, StartCombatOutput{}, err
+		}
+	}
+	for _, id := range input.Surprised {
+		if !slices.ContainsFunc(entities, func(e EntityInit) bool { return e.ID == id }) {
+			return nil, StartCombatOutput{}, fmt.Errorf("surprised entity not found: %s", id)
+		}
+	}
+
 	// Reset combat state
 	combatState.Entities = make(map[string]*Entity)
 	combatState.TurnOrder = []string{}
 	combatState.CurrentTurn = 0
 	combatState.RoundNumber = 1
+	combatState.EventLog = []string{}
+	combatState.TimedEffects = []*TimedEffect{}
+	combatState.PendingEffects = make(map[string]*PendingEffect)
+	combatState.LairActionRound = 0
+	combatState.LairActionUsedRound = 0
+	combatState.InitiativeSeed = rand.Int63()
+
+	corrections := []string{}
 
 	// Create entities
-	for _, e := range input.Entities {
-		entity := &Entity{
-			ID:             e.ID,
-			Name:           e.Name,
-			InitiativeRoll: e.Initiative,
-			MaxHP:          e.HP,
-			CurrentHP:      e.HP,
-			AC:             e.AC,
-			Conditions:     make(map[string]int),
-			Resources:      make(map[string]int),
-			IsMonster:      e.IsMonster,
-			MonsterName:    e.MonsterName,
+	rolledHP := make(map[string]int)
+	for _, e := range entities {
+		entity, fixes := newEntity(e, 1)
+		corrections = append(corrections, fixes...)
+		combatState.Entities[e.ID] = entity
+		if e.HPMode == hpModeRoll {
+			rolledHP[e.ID] = entity.MaxHP
 		}
+	}
 
-		// Load monster stats if applicable
-		if e.IsMonster && e.MonsterName != "" {
-			loadMonsterStats(entity)
+	// Roll for listed entities that came without initiative, once their stats are loaded
+	rolled := make(map[string]int)
+	if input.AutoRollInitiative {
+		for _, e := range input.Entities {
+			if e.Initiative != 0 {
+				continue
+			}
+			entity := combatState.Entities[e.ID]
+			entity.InitiativeRoll = entity.rollInitiative()
+			rolled[e.ID] = entity.InitiativeRoll
 		}
+	}
 
-		combatState.Entities[e.ID] = entity
+	combatState.shareGroupInitiative(entities, rolled)
+	combatState.sortTurnOrder()
+	for _, id := range input.Surprised {
+		combatState.Entities[id].Surprised = true
 	}
 
-	// Sort by initiative (descending)
-	type initPair struct {
-		id   string
-		init int
+	rolledNote := ""
+	if len(rolled) > 0 {
+		rolls := []string{}
+		for _, id := range combatState.TurnOrder {
+			if roll, ok := rolled[id]; ok {
+				rolls = append(rolls, fmt.Sprintf("%s %d", combatState.Entities[id].Name, roll))
+			}
+		}
+		rolledNote = fmt.Sprintf(" Rolled initiative: %s.", strings.Join(rolls, ", "))
 	}
-	pairs := []initPair{}
-	for id, e := range combatState.Entities {
-		pairs = append(pairs, initPair{id, e.InitiativeRoll})
+
+	// With nobody above initiative 20, round 1 opens on the lair's count
+	lairNote := ""
+	if len(combatState.TurnOrder) > 0 && combatState.Entities[combatState.TurnOrder[0]].InitiativeRoll < lairInitiative {
+		if lair := combatState.lairActionEffects(); len(lair) > 0 {
+			lairNote = " " + strings.Join(lair, " ")
+		}
 	}
-	sort.Slice(pairs, func(i, j int) bool {
-		return pairs[i].init > pairs[j].init
-	})
 
-	for _, p := range pairs {
-		combatState.TurnOrder = append(combatState.TurnOrder, p.id)
+	// A surprised creature at the top of the order loses the opening turn
+	surpriseNote := ""
+	if len(input.Surprised) > 0 {
+		names := []string{}
+		for _, id := range combatState.TurnOrder {
+			if combatState.Entities[id].Surprised {
+				names = append(names, combatState.Entities[id].Name)
+			}
+		}
+		surpriseNote = fmt.Sprintf(" Surprised: %s.", strings.Join(names, ", "))
+		if turn := combatState.skipSurprisedTurns(NextTurnOutput{}); len(turn.Effects) > 0 {
+			surpriseNote += fmt.Sprintf(" %s. %s acts first.", strings.Join(turn.Effects, ". "), turn.CurrentEntityName)
+		}
 	}
 
 	return nil, StartCombatOutput{
-		TurnOrder: combatState.TurnOrder,
-		Message:   fmt.Sprintf("Combat started with %d combatants. Round 1, turn 1.", len(combatState.Entities)),
+		TurnOrder:        combatState.TurnOrder,
+		RolledInitiative: rolled,
+		RolledHP:         rolledHP,
+		Corrections:      corrections,
+		Message:          fmt.Sprintf("Combat started with %d combatants. Round 1, turn 1.%s%s%s%s", len(combatState.Entities), rolledNote, partyNote, lairNote, surpriseNote),
 	}, nil
 }
 
+// newEntity builds a combatant from its initial values, loading monster stats and
+// filling in defaults. It returns the entity and any HP corrections made on import.
+func newEntity(e EntityInit, round int) (*Entity, []string) {
+	entity := &Entity{
+		ID:               e.ID,
+		Name:             e.Name,
+		InitiativeRoll:   e.Initiative,
+		MaxHP:            e.HP,
+		CurrentHP:        e.HP,
+		AC:               e.AC,
+		Conditions:       make(map[string]int),
+		Resources:        make(map[string]int),
+		IsMonster:        e.IsMonster,
+		AbilityScores:    e.AbilityScores,
+		Skills:           e.Skills,
+		ProficiencyBonus: e.ProficiencyBonus,
+		SavingThrows:     e.SavingThrows,
+		MonsterName:      e.MonsterName,
+		CreatureType:     strings.ToLower(e.CreatureType),
+		Size:             e.Size,
+		Speed:            e.Speed,
+		Reach:            e.Reach,
+		GroupID:          e.GroupID,
+	}
+	if !e.IsMonster {
+		if entity.CreatureType == "" {
+			entity.CreatureType = "humanoid"
+		}
+		if entity.Size == "" {
+			entity.Size = "Medium"
+		}
+	}
+
+	// Load monster stats if applicable
+	if e.IsMonster && e.MonsterName != "" {
+		loadMonsterStats(entity, e.HPMode)
+	}
+	if entity.Speed == 0 {
+		entity.Speed = 30
+	}
+	if entity.ProficiencyBonus == 0 {
+		entity.ProficiencyBonus = 2
+	}
+	if entity.Reach == 0 {
+		entity.Reach = defaultReach
+	}
+	if e.LegendaryResistances > 0 {
+		entity.MaxLegendaryResistances = e.LegendaryResistances
+	}
+	entity.LegendaryResistances = entity.MaxLegendaryResistances
+	// Monsters enter combat with a full legendary budget for the round they join
+	entity.LegendaryResetRound = round
+
+	// Imported HP may be out of range for the entity's max
+	if e.CurrentHP != nil {
+		entity.CurrentHP = *e.CurrentHP
+	}
+	return entity, reconcileHP(entity)
+}
+
+// sortTurnOrder rebuilds the turn order from each entity's initiative roll
+func (cs *CombatState) sortTurnOrder() {
+	cs.TurnOrder = []string{}
+	for id := range cs.Entities {
+		cs.TurnOrder = append(cs.TurnOrder, id)
+	}
+	sort.Slice(cs.TurnOrder, func(i, j int) bool {
+		return cs.actsBefore(cs.Entities[cs.TurnOrder[i]], cs.Entities[cs.TurnOrder[j]])
+	})
+}
+
 // NextTurnInput defines advancing the turn
-type NextTurnInput struct{}
+type NextTurnInput struct {
+	EncounterScope
+}
+
+// CombatantSummary is one combatant's line in the turn status summary
+type CombatantSummary struct {
+	EntityID string `json:"entity_id"`
+	Summary  string `json:"summary"`
+}
 
 type NextTurnOutput struct {
-	CurrentEntityID   string            `json:"current_entity_id"`
-	CurrentEntityName string            `json:"current_entity_name"`
-	RoundNumber       int               `json:"round_number"`
-	Effects           []string          `json:"effects" jsonschema:"Start of turn effects applied"`
-	CombatStatus      map[string]string `json:"combat_status" jsonschema:"HP and conditions summary"`
+	CurrentEntityID   string             `json:"current_entity_id"`
+	CurrentEntityName string             `json:"current_entity_name"`
+	RoundNumber       int                `json:"round_number"`
+	Effects           []string           `json:"effects" jsonschema:"End of turn effects for the previous entity, then start of turn effects applied"`
+	CombatStatus      []CombatantSummary `json:"combat_status" jsonschema:"HP and conditions summary of every combatant, in initiative order"`
+	Group             []EntityStatus     `json:"group,omitempty" jsonschema:"Every member of the group acting together on this turn"`
 }
 
 func handleNextTurn(ctx context.Context, req *mcp.CallToolRequest, input NextTurnInput) (*mcp.CallToolResult, NextTurnOutput, error) {
-	// Advance turn
-	combatState.CurrentTurn++
-	if combatState.CurrentTurn >= len(combatState.TurnOrder) {
-		combatState.CurrentTurn = 0
-		combatState.RoundNumber++
+	return nil, combatState.advanceTurn(), nil
+}
+
+// advanceTurn ends the current entity's turn, then moves to the next entity in
+// initiative order and applies its start-of-turn effects
+func (cs *CombatState) advanceTurn() NextTurnOutput {
+	previousInit := 0
+	ended := []string{}
+	if cs.CurrentTurn < len(cs.TurnOrder) {
+		group := cs.actingGroup()
+		previousInit = group[0].InitiativeRoll
+		for _, previous := range group {
+			ended = append(ended, previous.endTurn()...)
+		}
+		cs.CurrentTurn += len(group) - 1
 	}
 
-	currentID := combatState.TurnOrder[combatState.CurrentTurn]
-	current := combatState.Entities[currentID]
+	// Advance turn
+	cs.CurrentTurn++
+	output := cs.beginTurn(previousInit)
+	output.Effects = append(ended, output.Effects...)
+	return cs.skipSurprisedTurns(output)
+}
+
+// skipSurprisedTurns passes over the turns surprised creatures lose in round 1,
+// starting from the turn output already begun, and returns the turn that follows
+func (cs *CombatState) skipSurprisedTurns(output NextTurnOutput) NextTurnOutput {
+	for cs.RoundNumber == 1 && cs.CurrentTurn < len(cs.TurnOrder) {
+		group := cs.actingGroup()
+		surprised := 0
+		effects := output.Effects
+		for _, member := range group {
+			if member.Surprised {
+				surprised++
+				effects = append(effects, fmt.Sprintf("%s is surprised and skips their turn", member.Name))
+			}
+		}
+		// Members of a group who aren't surprised still take the group's turn
+		if surprised < len(group) {
+			output.Effects = effects
+			break
+		}
+		for _, member := range group {
+			effects = append(effects, member.endTurn()...)
+		}
+		cs.CurrentTurn += len(group)
+		output = cs.beginTurn(group[0].InitiativeRoll)
+		output.Effects = append(effects, output.Effects...)
+	}
+	return output
+}
 
+// beginTurn applies the start-of-turn effects for the entity at CurrentTurn,
+// rolling over to a new round first if CurrentTurn is past the end of the order.
+// previousInit is the initiative of the turn that just ended.
+func (cs *CombatState) beginTurn(previousInit int) NextTurnOutput {
 	effects := []string{}
 
-	// Reset legendary actions at start of monster turn
-	if current.IsMonster && current.MaxLegendaryActions > 0 {
-		current.LegendaryActions = current.MaxLegendaryActions
-		effects = append(effects, fmt.Sprintf("Legendary actions reset to %d", current.MaxLegendaryActions))
-	}
+	wrapped := cs.CurrentTurn >= len(cs.TurnOrder)
+	if wrapped {
+		cs.CurrentTurn = 0
+		cs.RoundNumber++
 
-	// Process conditions (decrement duration)
-	for condition, duration := range current.Conditions {
-		if duration > 0 {
-			current.Conditions[condition]--
-			if current.Conditions[condition] == 0 {
-				delete(current.Conditions, condition)
-				effects = append(effects, fmt.Sprintf("Condition '%s' ended", condition))
-			}
+		// Surprise only lasts through the first round
+		for _, e := range cs.Entities {
+			e.Surprised = false
+		}
+
+		// Timed spell effects tick down once per round
+		effects = append(effects, cs.tickTimedEffects(1)...)
+		if len(cs.TurnOrder) == 0 {
+			return NextTurnOutput{RoundNumber: cs.RoundNumber, Effects: effects}
 		}
 	}
 
-	// Build status summary
-	status := make(map[string]string)
-	for id, e := range combatState.Entities {
-		condList := []string{}
-		for c := range e.Conditions {
-			condList = append(condList, c)
+	currentID := cs.TurnOrder[cs.CurrentTurn]
+	current := cs.Entities[currentID]
+
+	// Lair actions happen on initiative count 20, losing initiative ties
+	if lairActionDue(previousInit, current.InitiativeRoll, wrapped) {
+		effects = append(effects, cs.lairActionEffects()...)
+	}
+
+	// A delayed turn already had its start-of-turn effects when it first came up
+	group := cs.actingGroup()
+	for _, member := range group {
+		if member.DelayedTurn {
+			member.DelayedTurn = false
+			effects = append(effects, fmt.Sprintf("%s takes the turn it delayed", member.Name))
+		} else {
+			effects = append(effects, cs.startTurn(member)...)
+		}
+	}
+	var groupStatus []EntityStatus
+	if len(group) > 1 {
+		names := []string{}
+		for _, member := range group {
+			names = append(names, member.Name)
+			groupStatus = append(groupStatus, member.status())
 		}
+		effects = append(effects, fmt.Sprintf("Group %s acts together: %s", current.GroupID, strings.Join(names, ", ")))
+	}
+
+	// Build status summary, top to bottom of the initiative order
+	status := []CombatantSummary{}
+	for _, id := range cs.TurnOrder {
+		e := cs.Entities[id]
+		condList := sortedKeys(e.Conditions)
 		condStr := ""
 		if len(condList) > 0 {
-			condStr = fmt.Sprintf(" [%v]", condList)
+			condStr = fmt.Sprintf(" [%s]", strings.Join(condList, ", "))
+		}
+		name := e.Name
+		if desc := describeCreature(e); desc != "" {
+			name = fmt.Sprintf("%s (%s)", e.Name, desc)
+		}
+		if len(e.TempImmunities) > 0 {
+			condStr += fmt.Sprintf(" (temp immune: %s)", strings.Join(sortedKeys(e.TempImmunities), ", "))
 		}
-		status[id] = fmt.Sprintf("%s: %d/%d HP%s", e.Name, e.CurrentHP, e.MaxHP, condStr)
+		if e.Hidden {
+			condStr += " (hidden)"
+		}
+		if e.ExhaustionLevel > 0 {
+			condStr += fmt.Sprintf(" (exhaustion %d)", e.ExhaustionLevel)
+		}
+		if len(e.Notes) > 0 {
+			condStr += fmt.Sprintf(" (notes: %s)", strings.Join(e.Notes, "; "))
+		}
+		status = append(status, CombatantSummary{
+			EntityID: id,
+			Summary:  fmt.Sprintf("%s: %d/%d HP%s", name, e.CurrentHP, e.MaxHP, condStr),
+		})
 	}
 
-	return nil, NextTurnOutput{
+	return NextTurnOutput{
 		CurrentEntityID:   currentID,
 		CurrentEntityName: current.Name,
-		RoundNumber:       combatState.RoundNumber,
+		RoundNumber:       cs.RoundNumber,
 		Effects:           effects,
 		CombatStatus:      status,
-	}, nil
+		Group:             groupStatus,
+	}
+}
+
+// startTurn applies the start-of-turn effects of the entity whose turn is beginning
+func (cs *CombatState) startTurn(current *Entity) []string {
+	effects := []string{}
+
+	// A fresh turn restores the action economy
+	if current.ReactionUsed {
+		effects = append(effects, "Reaction restored")
+	}
+	current.refreshActions()
+
+	// Reset legendary actions at start of monster turn (at most once per round)
+	if current.IsMonster && cs.refreshLegendaryActions(current) {
+		effects = append(effects, fmt.Sprintf("Legendary actions reset to %d", current.MaxLegendaryActions))
+	}
+
+	// Spent recharge abilities roll to come back at the start of the turn
+	if len(current.RechargeAbilities) > 0 {
+		effects = append(effects, current.rollRecharges()...)
+	}
+
+	// A readied action is lost if its trigger hasn't fired by the start of the holder's turn
+	if current.ReadiedAction != nil {
+		effects = append(effects, cs.expireReadiedAction(current))
+	}
+
+	// Ongoing damage ticks at the start of the turn, followed by the save to end it
+	if len(current.OngoingEffects) > 0 {
+		effects = append(effects, cs.processOngoingEffects(current)...)
+	}
+
+	// Temporary immunities count down alongside conditions
+	effects = append(effects, tickTempImmunities(current)...)
+
+	// Process conditions (decrement duration) that end at the start of the turn
+	effects = append(effects, current.tickConditions(endsStartOfTurn)...)
+
+	return effects
 }
 
 // ApplyDamageInput defines damage application
 type ApplyDamageInput struct {
+	EncounterScope
 	TargetID   string `json:"target_id" jsonschema:"Entity receiving damage"`
 	Damage     int    `json:"damage" jsonschema:"Damage amount"`
-	DamageType string `json:"damage_type" jsonschema:"Type of damage (fire, slashing, etc)"`
+	DamageDice string `json:"damage_dice,omitempty" jsonschema:"Dice to roll for the damage, e.g. 8d6; used instead of damage when both are given"`
+	IsCritical bool   `json:"is_critical,omitempty" jsonschema:"Critical hit: the damage dice (not the modifier) are rolled twice; implied after a critical make_attack against the target"`
+	DamageType string `json:"damage_type,omitempty" jsonschema:"Type of damage (fire, slashing, etc)"`
+	SourceID   string `json:"source_id,omitempty" jsonschema:"Entity that dealt the damage, for the damage leaderboard"`
+	// A concentrating target must save to keep its spell
+	RollConcentration bool `json:"roll_concentration,omitempty" jsonschema:"Roll a concentrating target's CON save automatically instead of reporting the DC to roll"`
+	// Attacks that deal more than one damage type, e.g. 2d6 slashing plus 1d8 fire
+	Components []DamageComponent `json:"components,omitempty" jsonschema:"Damage of several types, each resisted separately; used instead of damage, damage_dice and damage_type"`
+	Nonlethal  bool              `json:"nonlethal,omitempty" jsonschema:"The attacker pulls its blow: a target dropped to 0 HP is knocked out, unconscious and stable, instead of dying"`
 }
 
 type ApplyDamageOutput struct {
-	FinalDamage   int    `json:"final_damage"`
-	RemainingHP   int    `json:"remaining_hp"`
-	Message       string `json:"message"`
-	IsUnconscious bool   `json:"is_unconscious"`
+	FinalDamage        int                 `json:"final_damage"`
+	RemainingHP        int                 `json:"remaining_hp"`
+	DamageRoll         *DiceRoll           `json:"damage_roll,omitempty" jsonschema:"The rolled dice, when damage_dice was given"`
+	Steps              []DamageStep        `json:"steps,omitempty" jsonschema:"Damage after each stage of the pipeline, for single-type damage"`
+	Components         []ComponentResult   `json:"components,omitempty" jsonschema:"Each damage type before and after the target's modifiers, when components were given"`
+	ConcentrationDC    int                 `json:"concentration_dc,omitempty" jsonschema:"DC of the CON save the target must make to keep concentrating"`
+	ConcentrationCheck *ConcentrationCheck `json:"concentration_check,omitempty" jsonschema:"The concentration save, when it was rolled"`
+	Message            string              `json:"message"`
+	IsUnconscious      bool                `json:"is_unconscious"`
+	Bloodied           bool                `json:"bloodied" jsonschema:"At or below half max HP but still standing"`
+	JustBecameBloodied bool                `json:"just_became_bloodied" jsonschema:"This damage took the target to half HP or below, which triggers some monsters' bloodied abilities"`
+	KnockedOut         bool                `json:"knocked_out,omitempty" jsonschema:"Nonlethal damage dropped the target to 0 HP: it is unconscious and stable rather than dying"`
+	Overkill           int                 `json:"overkill,omitempty" jsonschema:"Damage left over after the target reached 0 HP"`
+	InstantDeath       bool                `json:"instant_death,omitempty" jsonschema:"The overkill equaled or exceeded the target's max HP, killing it outright"`
 }
 
 func handleApplyDamage(ctx context.Context, req *mcp.CallToolRequest, input ApplyDamageInput) (*mcp.CallToolResult, ApplyDamageOutput, error) {
@@ -273,35 +1416,133 @@ func handleApplyDamage(ctx context.Context, req *mcp.CallToolRequest, input Appl
 	if target == nil {
 		return nil, ApplyDamageOutput{}, fmt.Errorf("target not found: %s", input.TargetID)
 	}
+	if input.Damage < 0 {
+		return nil, ApplyDamageOutput{}, fmt.Errorf("damage can't be negative; use apply_healing to restore HP")
+	}
+
+	source := combatState.Entities[input.SourceID]
+	if input.SourceID != "" && source == nil {
+		return nil, ApplyDamageOutput{}, fmt.Errorf("source not found: %s", input.SourceID)
+	}
+
+	// A critical hit from make_attack carries over to the damage that follows it
+	critical := input.IsCritical || target.PendingCritical
+	target.PendingCritical = false
+
+	hpBefore := target.CurrentHP
+	var output ApplyDamageOutput
+	if len(input.Components) > 0 {
+		var err error
+		if output, err = applyDamageComponents(target, input.Components, critical); err != nil {
+			return nil, ApplyDamageOutput{}, err
+		}
+	} else {
+		// Dice take precedence over a pre-summed amount
+		damage := input.Damage
+		rolled := ""
+		if input.DamageDice != "" {
+			diceMultiplier := 1
+			if critical {
+				diceMultiplier = resources.SRDDamageRules.CriticalMultiplier
+			}
+			roll, err := rollDice(input.DamageDice, diceMultiplier)
+			if err != nil {
+				return nil, ApplyDamageOutput{}, err
+			}
+			damage = roll.Total
+			output.DamageRoll = &roll
+			rolled = fmt.Sprintf(" (rolled %s: %v = %d)", input.DamageDice, roll.Rolls, roll.Total)
+			if critical {
+				rolled = fmt.Sprintf(" (critical, %dx dice: %s rolled as %v%+d = %d)", diceMultiplier, input.DamageDice, roll.Rolls, roll.Modifier, roll.Total)
+			}
+		} else if critical {
+			rolled = " (critical; a pre-summed amount is applied as given)"
+		}
+
+		var modifier string
+		output.FinalDamage, modifier, output.Steps = applyDamageSteps(target, damage, input.DamageType)
+		output.Message = fmt.Sprintf("%s takes %d %s damage%s%s. %d HP remaining.", target.Name, output.FinalDamage, input.DamageType, rolled, modifier, target.CurrentHP)
+	}
+	if source != nil {
+		source.DamageDealt += output.FinalDamage
+	}
+
+	output.RemainingHP = target.CurrentHP
+	after := combatState.afterDamage(target, hpBefore, output.FinalDamage, input.Nonlethal, input.RollConcentration)
+	output.IsUnconscious, output.Bloodied, output.JustBecameBloodied = after.IsUnconscious, after.Bloodied, after.JustBecameBloodied
+	output.KnockedOut, output.Overkill, output.InstantDeath = after.KnockedOut, after.Overkill, after.InstantDeath
+	output.ConcentrationDC, output.ConcentrationCheck = after.ConcentrationDC, after.ConcentrationCheck
+	output.Message += after.Note
 
-	// Apply resistance/vulnerability/immunity (simplified - would normally check monster stats)
-	finalDamage := input.Damage
-	modifier := ""
+	return nil, output, nil
+}
+
+// damageAftermath is what happened to a creature because of damage it just took
+type damageAftermath struct {
+	IsUnconscious      bool
+	Bloodied           bool
+	JustBecameBloodied bool
+	KnockedOut         bool
+	Overkill           int
+	InstantDeath       bool
+	ConcentrationDC    int
+	ConcentrationCheck *ConcentrationCheck
+	Note               string // sentences describing the above, each starting with a space
+}
 
-	// Check resistances from Resources
-	if _, ok := target.Resources["resistances"]; ok {
-		// In real implementation, parse resistance types
-		finalDamage = input.Damage / 2
-		modifier = " (resisted)"
+// afterDamage settles the consequences of damage already taken off a target that had
+// hpBefore HP: becoming bloodied, a knockout or a death from massive damage when it
+// drops to 0 HP, and the concentration save it must make
+func (cs *CombatState) afterDamage(target *Entity, hpBefore, damage int, nonlethal, rollConcentration bool) damageAftermath {
+	after := damageAftermath{
+		IsUnconscious: target.CurrentHP == 0,
+		Bloodied:      target.IsBloodied(),
+	}
+	if after.Bloodied && !(hpBefore > 0 && hpBefore*2 <= target.MaxHP) {
+		after.JustBecameBloodied = true
+		after.Note += fmt.Sprintf(" %s is now bloodied.", target.Name)
 	}
 
-	target.CurrentHP -= finalDamage
-	if target.CurrentHP < 0 {
-		target.CurrentHP = 0
+	// Only the hit that drops the target decides between a knockout and a lethal fall;
+	// damage left over that matches the max HP kills outright unless the blow was pulled
+	if after.IsUnconscious && !target.Dead {
+		after.Overkill = damage - hpBefore
+	}
+	if hpBefore > 0 && after.IsUnconscious {
+		if nonlethal {
+			after.KnockedOut = true
+			target.knockOut()
+			after.Note += fmt.Sprintf(" %s is knocked out: unconscious and stable.", target.Name)
+			cs.logEvent("%s is knocked out", target.Name)
+		} else if after.Overkill >= target.MaxHP {
+			after.InstantDeath = true
+			after.IsUnconscious = false
+			target.Dead = true
+			after.Note += fmt.Sprintf(" Massive damage: %d damage past 0 HP meets the max HP of %d, so %s dies instantly.", after.Overkill, target.MaxHP, target.Name)
+			cs.logEvent("%s dies instantly from massive damage", target.Name)
+		} else if target.IsMonster {
+			after.Note += fmt.Sprintf(" %s drops to 0 HP from lethal damage.", target.Name)
+		} else {
+			after.Note += fmt.Sprintf(" %s drops to 0 HP from lethal damage and is dying; roll death saves with death_save.", target.Name)
+		}
 	}
 
-	isUnconscious := target.CurrentHP == 0
+	var note string
+	after.ConcentrationDC, after.ConcentrationCheck, note = cs.concentrationAfterDamage(target, damage, rollConcentration)
+	after.Note += note
+	return after
+}
 
-	return nil, ApplyDamageOutput{
-		FinalDamage:   finalDamage,
-		RemainingHP:   target.CurrentHP,
-		Message:       fmt.Sprintf("%s takes %d %s damage%s. %d HP remaining.", target.Name, finalDamage, input.DamageType, modifier, target.CurrentHP),
-		IsUnconscious: isUnconscious,
-	}, nil
+// applyDamage subtracts damage from the target's HP after resistances and returns
+// the final amount dealt along with a note describing any modifier that applied
+func applyDamage(target *Entity, damage int, damageType string) (int, string) {
+	finalDamage, modifier, _ := applyDamageSteps(target, damage, damageType)
+	return finalDamage, modifier
 }
 
 // ApplyHealingInput defines healing
 type ApplyHealingInput struct {
+	EncounterScope
 	TargetID string `json:"target_id"`
 	Amount   int    `json:"amount"`
 }
@@ -309,6 +1550,7 @@ type ApplyHealingInput struct {
 type ApplyHealingOutput struct {
 	AmountHealed int    `json:"amount_healed"`
 	CurrentHP    int    `json:"current_hp"`
+	Revived      bool   `json:"revived,omitempty" jsonschema:"The healing brought the creature up from 0 HP, clearing unconscious and its death saves"`
 	Message      string `json:"message"`
 }
 
@@ -317,6 +1559,9 @@ func handleApplyHealing(ctx context.Context, req *mcp.CallToolRequest, input App
 	if target == nil {
 		return nil, ApplyHealingOutput{}, fmt.Errorf("target not found: %s", input.TargetID)
 	}
+	if input.Amount < 0 {
+		return nil, ApplyHealingOutput{}, fmt.Errorf("healing can't be negative; use apply_damage to take HP away")
+	}
 
 	before := target.CurrentHP
 	target.CurrentHP += input.Amount
@@ -325,22 +1570,41 @@ func handleApplyHealing(ctx context.Context, req *mcp.CallToolRequest, input App
 	}
 	healed := target.CurrentHP - before
 
-	return nil, ApplyHealingOutput{
+	output := ApplyHealingOutput{
 		AmountHealed: healed,
 		CurrentHP:    target.CurrentHP,
 		Message:      fmt.Sprintf("%s healed for %d HP. Now at %d/%d.", target.Name, healed, target.CurrentHP, target.MaxHP),
-	}, nil
+	}
+	if before == 0 && target.CurrentHP > 0 && !target.Dead {
+		output.Revived = true
+		target.revive()
+		output.Message += fmt.Sprintf(" %s regains consciousness; death saves reset.", target.Name)
+		combatState.logEvent("%s is healed back to consciousness", target.Name)
+	}
+
+	return nil, output, nil
 }
 
 // AddConditionInput defines adding conditions
 type AddConditionInput struct {
+	EncounterScope
 	TargetID  string `json:"target_id"`
-	Condition string `json:"condition" jsonschema:"Condition name (stunned, prone, etc)"`
+	Condition string `json:"condition" jsonschema:"SRD condition name, case-insensitive (stunned, prone, etc)"`
 	Duration  int    `json:"duration" jsonschema:"Turns remaining, -1 for permanent"`
+	EndsAt    string `json:"ends_at,omitempty" jsonschema:"When the duration counts down: start_of_turn (default), end_of_turn, or save_ends for a condition the creature can save against at the end of each of its turns"`
+	// Optional restrictions for effects like Charm Person or "Large or smaller" riders
+	AllowedTypes []string `json:"allowed_types,omitempty" jsonschema:"Creature types the effect can apply to, e.g. [humanoid]"`
+	MaxSize      string   `json:"max_size,omitempty" jsonschema:"Largest size the effect can apply to, e.g. Large"`
+	SourceID     string   `json:"source_id,omitempty" jsonschema:"Entity imposing the condition, e.g. the grappler"`
+	SourceSpell  string   `json:"source_spell,omitempty" jsonschema:"Concentration spell maintaining the condition; it ends when the source's concentration drops"`
 }
 
 type AddConditionOutput struct {
-	Message string `json:"message"`
+	Applied              bool   `json:"applied"`
+	LinkedTo             string `json:"linked_to,omitempty" jsonschema:"Concentration the condition is tied to"`
+	DroppedConcentration string `json:"dropped_concentration,omitempty" jsonschema:"Spell the source stopped concentrating on to maintain this one"`
+	RemovedBy            string `json:"removed_by,omitempty" jsonschema:"What ends a condition that never wears off, e.g. petrified"`
+	Message              string `json:"message"`
 }
 
 func handleAddCondition(ctx context.Context, req *mcp.CallToolRequest, input AddConditionInput) (*mcp.CallToolResult, AddConditionOutput, error) {
@@ -349,31 +1613,105 @@ func handleAddCondition(ctx context.Context, req *mcp.CallToolRequest, input Add
 		return nil, AddConditionOutput{}, fmt.Errorf("target not found: %s", input.TargetID)
 	}
 
-	target.Conditions[input.Condition] = input.Duration
-	durationMsg := fmt.Sprintf("%d turns", input.Duration)
-	if input.Duration == -1 {
+	condition, err := canonicalCondition(input.Condition)
+	if err != nil {
+		return nil, AddConditionOutput{}, err
+	}
+	trigger, err := conditionEndTrigger(input.EndsAt)
+	if err != nil {
+		return nil, AddConditionOutput{}, err
+	}
+
+	source := combatState.Entities[input.SourceID]
+	if input.SourceID != "" && source == nil {
+		return nil, AddConditionOutput{}, fmt.Errorf("source not found: %s", input.SourceID)
+	}
+	if input.SourceSpell != "" && source == nil {
+		return nil, AddConditionOutput{}, fmt.Errorf("a source spell needs a source_id")
+	}
+
+	if reason := restrictionReason(target, input.AllowedTypes, input.MaxSize); reason != "" {
+		return nil, AddConditionOutput{
+			Applied: false,
+			Message: fmt.Sprintf("%s is unaffected: %s.", target.Name, reason),
+		}, nil
+	}
+
+	output := AddConditionOutput{Applied: true}
+
+	// Casting a different concentration spell ends the one the source was maintaining
+	if input.SourceSpell != "" && !strings.EqualFold(source.Concentrating, input.SourceSpell) {
+		output.DroppedConcentration = combatState.endConcentration(source)
+		source.Concentrating = input.SourceSpell
+	}
+
+	duration := input.Duration
+	requirement := removalRequirement(condition)
+	if requirement != "" {
+		duration = -1
+		trigger = endsStartOfTurn
+	}
+	target.Conditions[condition] = duration
+	target.setConditionSource(condition, input.SourceID)
+	target.setConditionEnd(condition, trigger)
+	durationMsg := fmt.Sprintf("%d turns", duration)
+	if duration == -1 {
 		durationMsg = "permanent"
 	}
+	switch {
+	case trigger == endsOnSave && duration == -1:
+		durationMsg = "save ends"
+	case trigger == endsOnSave:
+		durationMsg += ", save ends"
+	case trigger == endsEndOfTurn && duration != -1:
+		durationMsg += ", counted at the end of its turns"
+	}
+	output.Message = fmt.Sprintf("%s is now %s (%s).", target.Name, condition, durationMsg)
+	if requirement != "" {
+		output.RemovedBy = requirement
+		output.Message += fmt.Sprintf(" It doesn't wear off; only %s ends it.", requirement)
+	}
 
-	return nil, AddConditionOutput{
-		Message: fmt.Sprintf("%s is now %s (%s).", target.Name, input.Condition, durationMsg),
-	}, nil
+	if input.SourceSpell != "" {
+		source.ConcentrationLinks = append(source.ConcentrationLinks, ConcentrationLink{TargetID: target.ID, Condition: condition})
+		output.LinkedTo = fmt.Sprintf("%s's concentration on %s", source.Name, source.Concentrating)
+		output.Message += fmt.Sprintf(" It ends when %s stops concentrating on %s.", source.Name, source.Concentrating)
+		if output.DroppedConcentration != "" {
+			output.Message += fmt.Sprintf(" %s's concentration on %s ends.", source.Name, output.DroppedConcentration)
+		}
+	}
+
+	return nil, output, nil
 }
 
 // SavingThrowInput defines saving throws
 type SavingThrowInput struct {
+	EncounterScope
 	EntityID string `json:"entity_id"`
 	SaveType string `json:"save_type" jsonschema:"STR, DEX, CON, INT, WIS, CHA"`
 	DC       int    `json:"dc" jsonschema:"Difficulty class"`
+	Cover    string `json:"cover,omitempty" jsonschema:"Cover from the effect: half (+2 to DEX saves), three_quarters (+5 to DEX saves), or total (unaffected)"`
+	// nil spends a legendary resistance on a failed save, as the other saves do
+	UseLegendaryResistance *bool `json:"use_legendary_resistance,omitempty" jsonschema:"Set false to let a failed save stand and keep the creature's legendary resistances; spent automatically by default"`
 }
 
 type SavingThrowOutput struct {
 	Roll                      int    `json:"roll"`
 	Bonus                     int    `json:"bonus"`
+	Ability                   string `json:"ability" jsonschema:"Ability the save is based on"`
+	AbilityModifier           int    `json:"ability_modifier" jsonschema:"Modifier from the ability score"`
+	Proficient                bool   `json:"proficient" jsonschema:"Whether the bonus is a proficient save rather than the bare modifier"`
 	Total                     int    `json:"total"`
 	Success                   bool   `json:"success"`
+	NaturalTwenty             bool   `json:"natural_twenty" jsonschema:"A natural 20 succeeds whatever the DC"`
+	NaturalOne                bool   `json:"natural_one" jsonschema:"A natural 1 fails whatever the DC"`
+	Rolls                     []int  `json:"rolls,omitempty" jsonschema:"Both d20s, when the save was rolled with advantage or disadvantage"`
+	RollMode                  string `json:"roll_mode,omitempty" jsonschema:"advantage or disadvantage, e.g. from a condition or exhaustion"`
+	RollReason                string `json:"roll_reason,omitempty" jsonschema:"What gave the save advantage or disadvantage, e.g. disadvantage from restrained"`
 	UsedLegendaryResistance   bool   `json:"used_legendary_resistance"`
 	RemainingLegendaryResists int    `json:"remaining_legendary_resists"`
+	Cover                     string `json:"cover,omitempty"`
+	CoverBonus                int    `json:"cover_bonus,omitempty" jsonschema:"Bonus the cover added to a DEX save, included in the total"`
 	Message                   string `json:"message"`
 }
 
@@ -383,47 +1721,182 @@ func handleSavingThrow(ctx context.Context, req *mcp.CallToolRequest, input Savi
 		return nil, SavingThrowOutput{}, fmt.Errorf("entity not found: %s", input.EntityID)
 	}
 
-	// Roll d20
-	roll := rand.Intn(20) + 1
+	cover, err := parseCover(input.Cover)
+	if err != nil {
+		return nil, SavingThrowOutput{}, err
+	}
 
-	// Get save bonus (simplified - would normally check monster stats)
-	bonus := 0
-	if entity.IsMonster {
-		bonus = 3 // placeholder
+	useLegendary := input.UseLegendaryResistance == nil || *input.UseLegendaryResistance
+	save := rollCoveredSave(entity, input.SaveType, input.DC, cover, useLegendary)
+	output := save.output(entity, input.DC)
+	if cover != coverTotal {
+		output.Message += save.breakdown()
 	}
 
-	total := roll + bonus
-	success := total >= input.DC
+	return nil, output, nil
+}
+
+// saveResult is the outcome of a single saving throw
+type saveResult struct {
+	Roll                    int
+	Bonus                   int
+	Ability                 string
+	AbilityModifier         int
+	Proficient              bool // the bonus is a listed proficient save rather than the bare modifier
+	Total                   int
+	Success                 bool
+	Rolls                   []int  // both d20s when rolled with advantage or disadvantage
+	RollMode                string // "advantage", "disadvantage" or ""
+	RollReason              string // conditions or exhaustion behind the roll mode
+	UsedLegendaryResistance bool
+	AutoFailedBy            string // condition that made the save fail automatically
+	NaturalTwenty           bool   // succeeds whatever the DC
+	NaturalOne              bool   // fails whatever the DC
+	Cover                   string // the saver's cover from the effect
+	CoverBonus              int    // added to a DEX save's total by cover
+}
+
+// savePasses applies the saving throw rule: a natural 20 always succeeds, a natural 1
+// always fails, and any other roll succeeds when the total meets the DC
+func savePasses(roll, total, dc int) bool {
+	return roll == 20 || (roll != 1 && total >= dc)
+}
+
+// rollSavingThrow rolls the entity's save against a DC, applying its conditions and
+// exhaustion, and spends a legendary resistance to turn a failure into a success
+// when it has one
+func rollSavingThrow(entity *Entity, saveType string, dc int) saveResult {
+	return rollCoveredSave(entity, saveType, dc, "", true)
+}
+
+// rollCoveredSave rolls a save like rollSavingThrow for a creature behind cover: half
+// and three-quarters cover add to DEX saves, and total cover keeps the effect from
+// reaching the creature at all, so it succeeds without rolling. A failure only spends
+// a legendary resistance when useLegendary is set.
+func rollCoveredSave(entity *Entity, saveType string, dc int, cover string, useLegendary bool) saveResult {
+	ability := strings.ToUpper(saveType)
+	if cover == coverTotal {
+		return saveResult{Ability: ability, Success: true, Cover: cover}
+	}
+	bonus, proficient := saveBonus(entity, ability)
+	modifiers := entity.saveModifiers(ability)
+	roll, rolls, mode := modifiers.roll()
+	result := saveResult{
+		Roll:            roll,
+		Rolls:           rolls,
+		RollMode:        mode,
+		RollReason:      modifiers.describe(),
+		Bonus:           bonus,
+		Ability:         ability,
+		AbilityModifier: abilityModifier(entity, ability),
+		Proficient:      proficient,
+	}
+	if ability == "DEX" {
+		result.Cover, result.CoverBonus = cover, coverBonus(cover)
+	}
+	result.Total = result.Roll + result.Bonus + result.CoverBonus
+	result.NaturalTwenty = result.Roll == 20
+	result.NaturalOne = result.Roll == 1
+	result.Success = savePasses(result.Roll, result.Total, dc)
+	if condition := entity.autoFailCondition(saveType); condition != "" {
+		result.Success = false
+		result.AutoFailedBy = condition
+	}
 
-	usedLegendary := false
-	if !success && entity.LegendaryResistances > 0 {
+	if !result.Success && useLegendary && entity.LegendaryResistances > 0 {
 		// Auto-succeed using legendary resistance
-		success = true
-		usedLegendary = true
+		result.Success = true
+		result.UsedLegendaryResistance = true
 		entity.LegendaryResistances--
 	}
+	return result
+}
 
-	message := fmt.Sprintf("%s rolled %d+%d=%d vs DC %d: %s",
-		entity.Name, roll, bonus, total, input.DC,
-		map[bool]string{true: "SUCCESS", false: "FAILURE"}[success])
+// describe summarizes the save, e.g. "Red rolled 6+3=9 vs DC 15: SUCCESS (used legendary resistance, 2 remaining)"
+func (r saveResult) describe(entity *Entity, dc int) string {
+	outcome := map[bool]string{true: "SUCCESS", false: "FAILURE"}[r.Success]
+	if r.Cover == coverTotal {
+		return fmt.Sprintf("%s has total cover vs DC %d and is unaffected: %s", entity.Name, dc, outcome)
+	}
+	sum := fmt.Sprintf("%d+%d", r.Roll, r.Bonus)
+	if r.CoverBonus != 0 {
+		sum += fmt.Sprintf("+%d", r.CoverBonus)
+	}
+	message := fmt.Sprintf("%s rolled %s=%d vs DC %d: %s", entity.Name, sum, r.Total, dc, outcome)
+	switch {
+	case r.AutoFailedBy != "":
+	case r.NaturalTwenty:
+		message = fmt.Sprintf("%s rolled a natural 20 (%s=%d) vs DC %d: %s", entity.Name, sum, r.Total, dc, outcome)
+	case r.NaturalOne:
+		message = fmt.Sprintf("%s rolled a natural 1 (%s=%d) vs DC %d: %s", entity.Name, sum, r.Total, dc, outcome)
+	}
+	if r.AutoFailedBy != "" {
+		message = fmt.Sprintf("%s automatically fails vs DC %d (%s): %s", entity.Name, dc, r.AutoFailedBy, outcome)
+	} else if r.RollMode != "" {
+		message += fmt.Sprintf(" (%s, rolled %v)", r.RollReason, r.Rolls)
+	} else if r.RollReason != "" {
+		message += fmt.Sprintf(" (%s)", r.RollReason)
+	}
 
-	if usedLegendary {
+	if r.CoverBonus != 0 {
+		message += fmt.Sprintf(" (%+d from %s)", r.CoverBonus, describeCover(r.Cover))
+	}
+	if r.UsedLegendaryResistance {
 		message += fmt.Sprintf(" (used legendary resistance, %d remaining)", entity.LegendaryResistances)
 	}
+	return message
+}
 
-	return nil, SavingThrowOutput{
-		Roll:                      roll,
-		Bonus:                     bonus,
-		Total:                     total,
-		Success:                   success,
-		UsedLegendaryResistance:   usedLegendary,
+// output reports the save in the form make_saving_throw returns it
+func (r saveResult) output(entity *Entity, dc int) SavingThrowOutput {
+	return SavingThrowOutput{
+		Roll:                      r.Roll,
+		Bonus:                     r.Bonus,
+		Ability:                   r.Ability,
+		AbilityModifier:           r.AbilityModifier,
+		Proficient:                r.Proficient,
+		Total:                     r.Total,
+		Success:                   r.Success,
+		NaturalTwenty:             r.NaturalTwenty,
+		NaturalOne:                r.NaturalOne,
+		Rolls:                     r.Rolls,
+		RollMode:                  r.RollMode,
+		RollReason:                r.RollReason,
+		UsedLegendaryResistance:   r.UsedLegendaryResistance,
 		RemainingLegendaryResists: entity.LegendaryResistances,
-		Message:                   message,
-	}, nil
+		Cover:                     r.Cover,
+		CoverBonus:                r.CoverBonus,
+		Message:                   r.describe(entity, dc),
+	}
+}
+
+// breakdown explains where the save bonus came from, e.g. " (DEX proficient save +7)"
+func (r saveResult) breakdown() string {
+	if r.Proficient {
+		return fmt.Sprintf(" (%s proficient save %+d)", r.Ability, r.Bonus)
+	}
+	return fmt.Sprintf(" (%s modifier %+d)", r.Ability, r.AbilityModifier)
+}
+
+// savingThrowBonus returns the bonus an entity adds to a saving throw of the given type
+func savingThrowBonus(entity *Entity, saveType string) int {
+	bonus, _ := saveBonus(entity, saveType)
+	return bonus
+}
+
+// saveBonus returns the entity's listed bonus for a proficient save, or otherwise the
+// modifier of the save's ability score, and whether proficiency applied
+func saveBonus(entity *Entity, saveType string) (int, bool) {
+	ability := strings.ToUpper(saveType)
+	if bonus, ok := entity.SavingThrows[ability]; ok {
+		return bonus, true
+	}
+	return abilityModifier(entity, ability), false
 }
 
 // LegendaryActionInput defines using legendary actions
 type LegendaryActionInput struct {
+	EncounterScope
 	MonsterID  string `json:"monster_id"`
 	ActionName string `json:"action_name"`
 	Cost       int    `json:"cost" jsonschema:"Number of legendary actions to spend"`
@@ -441,6 +1914,32 @@ func handleLegendaryAction(ctx context.Context, req *mcp.CallToolRequest, input
 		return nil, LegendaryActionOutput{}, fmt.Errorf("monster not found: %s", input.MonsterID)
 	}
 
+	if monster.MaxLegendaryActions == 0 {
+		return nil, LegendaryActionOutput{}, fmt.Errorf("%s has no legendary actions", monster.Name)
+	}
+	if input.Cost < 1 {
+		return nil, LegendaryActionOutput{}, fmt.Errorf("cost must be at least 1")
+	}
+	if monster.IsIncapacitated() {
+		return nil, LegendaryActionOutput{
+			Success:          false,
+			RemainingActions: monster.LegendaryActions,
+			Message:          fmt.Sprintf("%s is incapacitated and can't use legendary actions.", monster.Name),
+		}, nil
+	}
+	if len(combatState.TurnOrder) > 0 && combatState.TurnOrder[combatState.CurrentTurn] == monster.ID {
+		return nil, LegendaryActionOutput{
+			Success:          false,
+			RemainingActions: monster.LegendaryActions,
+			Message:          fmt.Sprintf("%s can't use legendary actions on its own turn; they're taken at the end of another creature's turn.", monster.Name),
+		}, nil
+	}
+
+	// If the monster's turn this round was skipped over, its budget is owed a refill
+	if combatState.turnPassedThisRound(monster.ID) {
+		combatState.refreshLegendaryActions(monster)
+	}
+
 	if monster.LegendaryActions < input.Cost {
 		return nil, LegendaryActionOutput{
 			Success:          false,
@@ -458,42 +1957,90 @@ func handleLegendaryAction(ctx context.Context, req *mcp.CallToolRequest, input
 	}, nil
 }
 
-// TrackResourceInput defines resource tracking
-type TrackResourceInput struct {
-	EntityID     string `json:"entity_id"`
-	ResourceName string `json:"resource_name"`
-	CurrentValue int    `json:"current_value"`
-}
-
-type TrackResourceOutput struct {
-	Message string `json:"message"`
+// refreshLegendaryActions refills an entity's legendary actions unless that already
+// happened this round, reporting whether a refill occurred
+func (cs *CombatState) refreshLegendaryActions(e *Entity) bool {
+	if e.MaxLegendaryActions == 0 || e.LegendaryResetRound >= cs.RoundNumber {
+		return false
+	}
+	e.LegendaryActions = e.MaxLegendaryActions
+	e.LegendaryResetRound = cs.RoundNumber
+	return true
 }
 
-func handleTrackResource(ctx context.Context, req *mcp.CallToolRequest, input TrackResourceInput) (*mcp.CallToolResult, TrackResourceOutput, error) {
-	entity := combatState.Entities[input.EntityID]
-	if entity == nil {
-		return nil, TrackResourceOutput{}, fmt.Errorf("entity not found: %s", input.EntityID)
+// turnPassedThisRound reports whether the entity's turn has already started this round
+func (cs *CombatState) turnPassedThisRound(id string) bool {
+	for i, turnID := range cs.TurnOrder {
+		if turnID == id {
+			return i <= cs.CurrentTurn
+		}
 	}
-
-	entity.Resources[input.ResourceName] = input.CurrentValue
-
-	return nil, TrackResourceOutput{
-		Message: fmt.Sprintf("%s now has %d %s.", entity.Name, input.CurrentValue, input.ResourceName),
-	}, nil
+	return false
 }
 
-// loadMonsterStats populates monster-specific stats from Resources
-func loadMonsterStats(entity *Entity) {
-	// This would normally query the Resources for monster stat blocks
-	// For now, set some defaults
-	if entity.MonsterName == "Ancient Red Dragon" {
-		entity.MaxLegendaryActions = 3
-		entity.LegendaryActions = 3
-		entity.LegendaryResistances = 3
+// loadMonsterStats populates monster-specific stats from Resources, replacing the
+// given HP with the stat block's hit dice average or a roll when hpMode asks for it
+// (checked beforehand with checkHPMode)
+func loadMonsterStats(entity *Entity, hpMode string) {
+	if monster, ok := resources.GetMonster(entity.MonsterName); ok {
+		if hpMode == hpModeAverage || hpMode == hpModeRoll {
+			if hp, err := hitDiceHP(monster.HitDice, hpMode); err == nil {
+				entity.MaxHP = hp
+				entity.CurrentHP = hp
+			}
+		}
+		entity.CreatureType = monster.Type
+		entity.Size = monster.Size
+		if entity.Speed == 0 {
+			entity.Speed = monster.Speed["walk"]
+		}
+		if entity.Reach == 0 {
+			entity.Reach = statBlockReach(monster)
+		}
+		if entity.AbilityScores == nil {
+			entity.AbilityScores = maps.Clone(monster.AbilityScores)
+		}
+		if entity.SavingThrows == nil {
+			entity.SavingThrows = monster.ProficientSaves()
+		}
+		entity.Resistances = slices.Clone(monster.DamageResistances)
+		entity.Vulnerabilities = slices.Clone(monster.DamageVulnerabilities)
+		entity.Immunities = slices.Clone(monster.DamageImmunities)
+		if entity.Skills == nil {
+			entity.Skills = maps.Clone(monster.Skills)
+		}
+		if entity.ProficiencyBonus == 0 {
+			entity.ProficiencyBonus = monster.ProficiencyBonus()
+		}
+		if monster.LegendaryActions != nil {
+			entity.MaxLegendaryActions = monster.LegendaryActions.ActionsPerRound
+			entity.LegendaryActions = entity.MaxLegendaryActions
+		}
+		entity.MaxLegendaryResistances = monster.LegendaryResistances()
+		for _, action := range monster.Actions {
+			if m := rechargePattern.FindStringSubmatch(action.Name); m != nil {
+				if entity.RechargeAbilities == nil {
+					entity.RechargeAbilities = make(map[string]bool)
+					entity.RechargeOn = make(map[string]int)
+				}
+				entity.RechargeAbilities[action.Name] = true
+				entity.RechargeOn[action.Name], _ = strconv.Atoi(m[1])
+			}
+		}
 	}
 }
 
-// GetCombatState returns the current combat state pointer.
+// GetCombatState returns the active encounter's combat state pointer.
 func GetCombatState() *CombatState {
-	return combatState
+	encounterMu.Lock()
+	defer encounterMu.Unlock()
+	return activeCombatState()
+}
+
+// activeCombatState returns the active encounter's combat state; the caller holds encounterMu
+func activeCombatState() *CombatState {
+	if encounters == nil {
+		return combatState
+	}
+	return encounters[activeEncounterID].State
 }
@@ -0,0 +1,129 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// startTestCombat starts a fresh encounter with the given combatants and restores an
+// empty one when the test ends
+func startTestCombat(t *testing.T, entities ...EntityInit) StartCombatOutput {
+	t.Helper()
+	resetEncounters()
+	t.Cleanup(resetEncounters)
+	_, output, err := handleStartCombat(context.Background(), nil, StartCombatInput{Entities: entities})
+	if err != nil {
+		t.Fatalf("start_combat: %v", err)
+	}
+	return output
+}
+
+func TestIncapacitatingConditionsBlockActions(t *testing.T) {
+	ctx := context.Background()
+	bonus := 5
+
+	for _, condition := range []string{"incapacitated", "stunned", "paralyzed", "petrified", "unconscious"} {
+		t.Run(condition, func(t *testing.T) {
+			startTestCombat(t,
+				EntityInit{ID: "fighter", Name: "Fighter", Initiative: 15, HP: 30, AC: 16},
+				EntityInit{ID: "orc", Name: "Orc", Initiative: 10, HP: 15, AC: 13, IsMonster: true},
+			)
+			fighter := combatState.Entities["fighter"]
+			fighter.Conditions[condition] = -1
+
+			if !fighter.IsIncapacitated() {
+				t.Fatalf("IsIncapacitated() = false with %s", condition)
+			}
+
+			_, _, err := handleMakeAttack(ctx, nil, MakeAttackInput{AttackerID: "fighter", TargetID: "orc", AttackBonus: &bonus})
+			if err == nil || !strings.Contains(err.Error(), "incapacitated") {
+				t.Errorf("make_attack error = %v, want the attacker refused as incapacitated", err)
+			}
+			_, _, err = handleReadyAction(ctx, nil, ReadyActionInput{EntityID: "fighter", Trigger: "the orc moves", Action: "attack"})
+			if err == nil || !strings.Contains(err.Error(), "incapacitated") {
+				t.Errorf("ready_action error = %v, want the entity refused as incapacitated", err)
+			}
+			_, _, err = handleUseReaction(ctx, nil, UseReactionInput{EntityID: "fighter", Reaction: "opportunity attack"})
+			if err == nil || !strings.Contains(err.Error(), "incapacitated") {
+				t.Errorf("use_reaction error = %v, want the entity refused as incapacitated", err)
+			}
+			if fighter.ReactionUsed {
+				t.Error("the refused reaction was still spent")
+			}
+
+			// Once the condition ends the same attack goes ahead
+			delete(fighter.Conditions, condition)
+			if fighter.IsIncapacitated() {
+				t.Fatalf("IsIncapacitated() = true after %s ended", condition)
+			}
+			if _, _, err := handleMakeAttack(ctx, nil, MakeAttackInput{AttackerID: "fighter", TargetID: "orc", AttackBonus: &bonus}); err != nil {
+				t.Errorf("make_attack after %s ended: %v", condition, err)
+			}
+		})
+	}
+}
+
+func TestNegativeDamageAndHealingAreRejected(t *testing.T) {
+	ctx := context.Background()
+	startTestCombat(t,
+		EntityInit{ID: "fighter", Name: "Fighter", Initiative: 15, HP: 30, AC: 16},
+		EntityInit{ID: "orc", Name: "Orc", Initiative: 10, HP: 15, AC: 13, IsMonster: true},
+	)
+	fighter := combatState.Entities["fighter"]
+	fighter.CurrentHP = 20
+
+	if _, _, err := handleApplyDamage(ctx, nil, ApplyDamageInput{TargetID: "fighter", Damage: -5}); err == nil {
+		t.Error("apply_damage accepted negative damage")
+	}
+	if _, _, err := handleApplyDamage(ctx, nil, ApplyDamageInput{TargetID: "fighter", Components: []DamageComponent{{Damage: -5, DamageType: "fire"}}}); err == nil {
+		t.Error("apply_damage accepted a negative damage component")
+	}
+	if _, _, err := handleApplyHealing(ctx, nil, ApplyHealingInput{TargetID: "fighter", Amount: -5}); err == nil {
+		t.Error("apply_healing accepted negative healing")
+	}
+
+	// A roll whose modifier outweighs the dice deals no damage rather than healing
+	_, output, err := handleApplyDamage(ctx, nil, ApplyDamageInput{TargetID: "fighter", DamageDice: "1d4-10", DamageType: "slashing"})
+	if err != nil {
+		t.Fatalf("apply_damage: %v", err)
+	}
+	if output.FinalDamage != 0 {
+		t.Errorf("1d4-10 dealt %d damage, want 0", output.FinalDamage)
+	}
+	if dealt, _, _ := applyDamageSteps(fighter, -5, "fire"); dealt != 0 {
+		t.Errorf("the damage pipeline turned -5 into %d damage, want 0", dealt)
+	}
+	if fighter.CurrentHP != 20 {
+		t.Errorf("fighter has %d HP, want the rejected and zero damage to leave 20", fighter.CurrentHP)
+	}
+}
+
+func TestSavingThrowCanHoldLegendaryResistance(t *testing.T) {
+	ctx := context.Background()
+	startTestCombat(t,
+		EntityInit{ID: "fighter", Name: "Fighter", Initiative: 15, HP: 30, AC: 16},
+		EntityInit{ID: "dragon", Name: "Dragon", Initiative: 10, HP: 200, AC: 19, IsMonster: true, LegendaryResistances: 3},
+	)
+	// Paralyzed creatures fail DEX saves automatically
+	combatState.Entities["dragon"].Conditions["paralyzed"] = -1
+
+	hold := false
+	_, output, err := handleSavingThrow(ctx, nil, SavingThrowInput{EntityID: "dragon", SaveType: "DEX", DC: 15, UseLegendaryResistance: &hold})
+	if err != nil {
+		t.Fatalf("make_saving_throw: %v", err)
+	}
+	if output.Success || output.UsedLegendaryResistance || output.RemainingLegendaryResists != 3 {
+		t.Errorf("success = %v, used = %v, remaining = %d, want the failure to stand with 3 resistances kept",
+			output.Success, output.UsedLegendaryResistance, output.RemainingLegendaryResists)
+	}
+
+	_, output, err = handleSavingThrow(ctx, nil, SavingThrowInput{EntityID: "dragon", SaveType: "DEX", DC: 15})
+	if err != nil {
+		t.Fatalf("make_saving_throw: %v", err)
+	}
+	if !output.Success || !output.UsedLegendaryResistance || output.RemainingLegendaryResists != 2 {
+		t.Errorf("success = %v, used = %v, remaining = %d, want a resistance spent by default",
+			output.Success, output.UsedLegendaryResistance, output.RemainingLegendaryResists)
+	}
+}
@@ -0,0 +1,214 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ConcentrationLink is a condition on another creature that lasts only as long as
+// the caster's concentration, e.g. paralyzed from Hold Person
+type ConcentrationLink struct {
+	TargetID  string
+	Condition string
+}
+
+// endConcentration drops whatever the entity is concentrating on, including a readied
+// spell being held, removes the conditions linked to it, and returns the name of the
+// spell that ended ("" if none)
+func (cs *CombatState) endConcentration(e *Entity) string {
+	dropped := e.Concentrating
+	e.Concentrating = ""
+
+	if e.ReadiedAction != nil && e.ReadiedAction.Spell != "" {
+		if dropped == "" {
+			dropped = e.ReadiedAction.Spell
+		}
+		e.ReadiedAction = nil
+	}
+
+	for _, link := range e.ConcentrationLinks {
+		// Skip conditions that have since been reapplied by someone else
+		if target := cs.Entities[link.TargetID]; target != nil && target.ConditionSources[link.Condition] == e.ID {
+			delete(target.Conditions, link.Condition)
+			delete(target.ConditionSources, link.Condition)
+		}
+	}
+	e.ConcentrationLinks = nil
+
+	return dropped
+}
+
+// concentrationDC is the DC of the CON save to keep concentrating after taking damage
+func concentrationDC(damage int) int {
+	return max(10, damage/2)
+}
+
+// linkedConditionNames describes the conditions that end along with the entity's concentration
+func (cs *CombatState) linkedConditionNames(e *Entity) []string {
+	names := []string{}
+	for _, link := range e.ConcentrationLinks {
+		if target := cs.Entities[link.TargetID]; target != nil && target.ConditionSources[link.Condition] == e.ID {
+			names = append(names, fmt.Sprintf("%s on %s", link.Condition, target.Name))
+		}
+	}
+	return names
+}
+
+// concentrationCheck rolls the CON save a concentrating entity makes after taking
+// damage, ending its concentration on a failure
+func (cs *CombatState) concentrationCheck(e *Entity, damage int) ConcentrationCheck {
+	check := ConcentrationCheck{
+		EntityID: e.ID,
+		Spell:    e.Concentrating,
+		Damage:   damage,
+		DC:       concentrationDC(damage),
+	}
+	check.Roll, _, _ = e.saveModifiers("CON").roll()
+	check.Total = check.Roll + savingThrowBonus(e, "CON")
+	check.Maintained = savePasses(check.Roll, check.Total, check.DC)
+
+	if !check.Maintained {
+		check.Removed = cs.linkedConditionNames(e)
+		cs.endConcentration(e)
+		cs.logEvent("%s loses concentration on %s", e.Name, check.Spell)
+	}
+	return check
+}
+
+// concentrationAfterDamage handles the concentration save damage forces on a
+// concentrating target: dropping to 0 HP ends concentration outright, otherwise the
+// save is rolled when roll is set, or its DC is returned for the player to roll. The
+// note is appended to the damage message.
+func (cs *CombatState) concentrationAfterDamage(target *Entity, damage int, roll bool) (int, *ConcentrationCheck, string) {
+	if target.Concentrating == "" || damage <= 0 {
+		return 0, nil, ""
+	}
+	switch {
+	case target.CurrentHP == 0:
+		spell := cs.endConcentration(target)
+		cs.logEvent("%s loses concentration on %s", target.Name, spell)
+		return 0, nil, fmt.Sprintf(" %s drops to 0 HP and loses concentration on %s.", target.Name, spell)
+	case roll:
+		check := cs.concentrationCheck(target, damage)
+		return check.DC, &check, " " + check.describe(target) + "."
+	default:
+		dc := concentrationDC(damage)
+		return dc, nil, fmt.Sprintf(" %s must make a DC %d CON save to keep concentrating on %s.", target.Name, dc, target.Concentrating)
+	}
+}
+
+// describe summarizes the check for a tool message
+func (c ConcentrationCheck) describe(e *Entity) string {
+	outcome := "keeps concentrating on " + c.Spell
+	if !c.Maintained {
+		outcome = "loses concentration on " + c.Spell
+		if len(c.Removed) > 0 {
+			outcome += fmt.Sprintf(", ending %s", strings.Join(c.Removed, ", "))
+		}
+	}
+	return fmt.Sprintf("DC %d CON save: %d (rolled %d), %s %s", c.DC, c.Total, c.Roll, e.Name, outcome)
+}
+
+// StartConcentrationInput defines a caster beginning to concentrate on a spell
+type StartConcentrationInput struct {
+	EncounterScope
+	EntityID string `json:"entity_id"`
+	Spell    string `json:"spell" jsonschema:"Concentration spell being cast, e.g. Bless"`
+}
+
+type StartConcentrationOutput struct {
+	Concentrating        string   `json:"concentrating"`
+	DroppedConcentration string   `json:"dropped_concentration,omitempty" jsonschema:"Spell whose concentration ended to start this one"`
+	EndedEffects         []string `json:"ended_effects,omitempty" jsonschema:"Conditions the dropped spell was maintaining, removed with it"`
+	Message              string   `json:"message"`
+}
+
+func handleStartConcentration(ctx context.Context, req *mcp.CallToolRequest, input StartConcentrationInput) (*mcp.CallToolResult, StartConcentrationOutput, error) {
+	entity := combatState.Entities[input.EntityID]
+	if entity == nil {
+		return nil, StartConcentrationOutput{}, fmt.Errorf("entity not found: %s", input.EntityID)
+	}
+	if input.Spell == "" {
+		return nil, StartConcentrationOutput{}, fmt.Errorf("spell name is required")
+	}
+	if entity.IsIncapacitated() {
+		return nil, StartConcentrationOutput{}, fmt.Errorf("%s is incapacitated and can't concentrate", entity.Name)
+	}
+
+	// A creature can concentrate on only one spell at a time
+	output := StartConcentrationOutput{Concentrating: input.Spell}
+	message := fmt.Sprintf("%s is concentrating on %s.", entity.Name, input.Spell)
+	if entity.Concentrating != "" || entity.ReadiedAction != nil && entity.ReadiedAction.Spell != "" {
+		output.EndedEffects = combatState.linkedConditionNames(entity)
+		output.DroppedConcentration = combatState.endConcentration(entity)
+		message += fmt.Sprintf(" Concentration on %s ends", output.DroppedConcentration)
+		if len(output.EndedEffects) > 0 {
+			message += fmt.Sprintf(", ending %s", strings.Join(output.EndedEffects, ", "))
+		}
+		message += "."
+		combatState.logEvent("%s drops concentration on %s to cast %s", entity.Name, output.DroppedConcentration, input.Spell)
+	}
+	entity.Concentrating = input.Spell
+	output.Message = message
+
+	return nil, output, nil
+}
+
+// ConcentrationCheck is the outcome of one caster's concentration save
+type ConcentrationCheck struct {
+	EntityID   string   `json:"entity_id"`
+	Spell      string   `json:"spell"`
+	Damage     int      `json:"damage"`
+	DC         int      `json:"dc"`
+	Roll       int      `json:"roll"`
+	Total      int      `json:"total"`
+	Maintained bool     `json:"maintained"`
+	Removed    []string `json:"removed,omitempty" jsonschema:"Linked conditions removed because concentration ended"`
+}
+
+// ResolveConcentrationChecksInput defines concentration saves after an area effect
+type ResolveConcentrationChecksInput struct {
+	EncounterScope
+	Damage map[string]int `json:"damage" jsonschema:"Damage taken by each entity, keyed by entity ID"`
+}
+
+type ResolveConcentrationChecksOutput struct {
+	Checks  []ConcentrationCheck `json:"checks"`
+	Skipped []string             `json:"skipped" jsonschema:"Entities that took damage but weren't concentrating"`
+	Message string               `json:"message"`
+}
+
+func handleResolveConcentrationChecks(ctx context.Context, req *mcp.CallToolRequest, input ResolveConcentrationChecksInput) (*mcp.CallToolResult, ResolveConcentrationChecksOutput, error) {
+	ids := make([]string, 0, len(input.Damage))
+	for id := range input.Damage {
+		if combatState.Entities[id] == nil {
+			return nil, ResolveConcentrationChecksOutput{}, fmt.Errorf("entity not found: %s", id)
+		}
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	output := ResolveConcentrationChecksOutput{Checks: []ConcentrationCheck{}, Skipped: []string{}}
+	dropped := 0
+	for _, id := range ids {
+		entity := combatState.Entities[id]
+		damage := input.Damage[id]
+		if entity.Concentrating == "" || damage <= 0 {
+			output.Skipped = append(output.Skipped, entity.Name)
+			continue
+		}
+
+		check := combatState.concentrationCheck(entity, damage)
+		if !check.Maintained {
+			dropped++
+		}
+		output.Checks = append(output.Checks, check)
+	}
+
+	output.Message = fmt.Sprintf("%d concentration checks rolled, %d lost concentration.", len(output.Checks), dropped)
+	return nil, output, nil
+}
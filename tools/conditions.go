@@ -0,0 +1,358 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kiriyms/dungeon-master-mcp/resources"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// magicRemovalConditions never wear off on their own; each maps to what ends it
+var magicRemovalConditions = map[string]string{
+	"petrified": "greater restoration, stone to flesh, or similar magic",
+}
+
+// canonicalCondition validates a condition name against the SRD conditions and
+// returns it in the lowercase form conditions are stored under
+func canonicalCondition(name string) (string, error) {
+	definition, ok := resources.GetCondition(name)
+	if !ok {
+		valid := []string{}
+		for _, n := range resources.ConditionNames() {
+			if !strings.EqualFold(n, "exhaustion") {
+				valid = append(valid, strings.ToLower(n))
+			}
+		}
+		return "", fmt.Errorf("unknown condition %q; valid conditions are %s", name, strings.Join(valid, ", "))
+	}
+	if strings.EqualFold(definition.Name, "exhaustion") {
+		return "", fmt.Errorf("exhaustion is tracked in levels; use set_exhaustion")
+	}
+	return strings.ToLower(definition.Name), nil
+}
+
+// removalRequirement returns what ends a condition that doesn't expire, or ""
+func removalRequirement(condition string) string {
+	return magicRemovalConditions[strings.ToLower(condition)]
+}
+
+// When a condition's duration counts down; most SRD effects end at the start or
+// end of the affected creature's turn, or when it succeeds on a save
+const (
+	endsStartOfTurn = "start_of_turn"
+	endsEndOfTurn   = "end_of_turn"
+	endsOnSave      = "save_ends"
+)
+
+// conditionEndTrigger validates an ends_at value, defaulting to the start of the turn
+func conditionEndTrigger(endsAt string) (string, error) {
+	switch trigger := strings.ToLower(strings.TrimSpace(endsAt)); trigger {
+	case "":
+		return endsStartOfTurn, nil
+	case endsStartOfTurn, endsEndOfTurn, endsOnSave:
+		return trigger, nil
+	default:
+		return "", fmt.Errorf("unknown ends_at %q; use %s, %s or %s", endsAt, endsStartOfTurn, endsEndOfTurn, endsOnSave)
+	}
+}
+
+// setConditionEnd records when a condition counts down, leaving the start of the
+// turn implicit
+func (e *Entity) setConditionEnd(condition, trigger string) {
+	if trigger == endsStartOfTurn {
+		delete(e.ConditionEnds, condition)
+		return
+	}
+	if e.ConditionEnds == nil {
+		e.ConditionEnds = make(map[string]string)
+	}
+	e.ConditionEnds[condition] = trigger
+}
+
+// conditionEnd returns when the condition counts down
+func (e *Entity) conditionEnd(condition string) string {
+	if trigger, ok := e.ConditionEnds[condition]; ok {
+		return trigger
+	}
+	return endsStartOfTurn
+}
+
+// tickConditions counts down the timed conditions that end at one of the triggers,
+// removing those that run out; conditions that only magic removes never tick down
+func (e *Entity) tickConditions(triggers ...string) []string {
+	effects := []string{}
+	for _, condition := range sortedKeys(e.Conditions) {
+		duration := e.Conditions[condition]
+		if duration <= 0 || removalRequirement(condition) != "" || !slices.Contains(triggers, e.conditionEnd(condition)) {
+			continue
+		}
+		e.Conditions[condition]--
+		if e.Conditions[condition] == 0 {
+			delete(e.Conditions, condition)
+			delete(e.ConditionSources, condition)
+			delete(e.ConditionEnds, condition)
+			effects = append(effects, fmt.Sprintf("Condition '%s' ended", condition))
+		}
+	}
+	return effects
+}
+
+// endTurn processes the conditions that end with the entity's turn and reminds the
+// DM of the saves its save-ends conditions allow
+func (e *Entity) endTurn() []string {
+	effects := []string{}
+	for _, effect := range e.tickConditions(endsEndOfTurn, endsOnSave) {
+		effects = append(effects, fmt.Sprintf("End of %s's turn: %s", e.Name, effect))
+	}
+	for _, condition := range e.saveEndsConditions() {
+		effects = append(effects, fmt.Sprintf("End of %s's turn: roll a save to end '%s' (roll_to_end_condition)", e.Name, condition))
+	}
+	return effects
+}
+
+// activeConditions returns the SRD definitions of the entity's conditions, in name order
+func (e *Entity) activeConditions() []resources.ConditionDefinition {
+	definitions := []resources.ConditionDefinition{}
+	for _, condition := range sortedKeys(e.Conditions) {
+		if definition, ok := resources.GetCondition(condition); ok {
+			definitions = append(definitions, definition)
+		}
+	}
+	return definitions
+}
+
+// autoFailCondition returns the condition that makes the entity automatically fail
+// a save of the given type, or "" if the save is rolled normally
+func (e *Entity) autoFailCondition(saveType string) string {
+	for _, definition := range e.activeConditions() {
+		if definition.Saves[strings.ToUpper(saveType)] == resources.SaveAutoFail {
+			return strings.ToLower(definition.Name)
+		}
+	}
+	return ""
+}
+
+// rollModifiers collects what gives a d20 roll advantage or disadvantage
+type rollModifiers struct {
+	Advantage    []string
+	Disadvantage []string
+}
+
+// add records a reason for advantage or disadvantage; other modes, and reasons
+// already counted, are ignored
+func (m *rollModifiers) add(mode, reason string) {
+	switch mode {
+	case resources.RollAdvantage:
+		if !slices.Contains(m.Advantage, reason) {
+			m.Advantage = append(m.Advantage, reason)
+		}
+	case resources.RollDisadvantage:
+		if !slices.Contains(m.Disadvantage, reason) {
+			m.Disadvantage = append(m.Disadvantage, reason)
+		}
+	}
+}
+
+// roll rolls the d20 with whatever advantage or disadvantage applies
+func (m rollModifiers) roll() (int, []int, string) {
+	return rollD20(len(m.Advantage) > 0, len(m.Disadvantage) > 0)
+}
+
+// passive returns the adjustment to a passive score: +5 with advantage, -5 with
+// disadvantage, and nothing when neither or both apply
+func (m rollModifiers) passive() int {
+	switch {
+	case len(m.Advantage) > 0 && len(m.Disadvantage) == 0:
+		return 5
+	case len(m.Disadvantage) > 0 && len(m.Advantage) == 0:
+		return -5
+	default:
+		return 0
+	}
+}
+
+// describe explains the modifiers, e.g. "disadvantage from poisoned", or "" if none apply
+func (m rollModifiers) describe() string {
+	parts := []string{}
+	if len(m.Advantage) > 0 {
+		parts = append(parts, "advantage from "+strings.Join(m.Advantage, ", "))
+	}
+	if len(m.Disadvantage) > 0 {
+		parts = append(parts, "disadvantage from "+strings.Join(m.Disadvantage, ", "))
+	}
+	if len(parts) == 2 {
+		return strings.Join(parts, " and ") + " cancel out"
+	}
+	return strings.Join(parts, "")
+}
+
+// saveModifiers collects the conditions and exhaustion affecting the entity's save
+func (e *Entity) saveModifiers(saveType string) rollModifiers {
+	var m rollModifiers
+	for _, definition := range e.activeConditions() {
+		m.add(definition.Saves[strings.ToUpper(saveType)], strings.ToLower(definition.Name))
+	}
+	if e.ExhaustionLevel >= exhaustionRollDisadvantage {
+		m.add(resources.RollDisadvantage, fmt.Sprintf("exhaustion %d", e.ExhaustionLevel))
+	}
+	return m
+}
+
+// checkModifiers collects the conditions and exhaustion affecting the entity's ability checks
+func (e *Entity) checkModifiers() rollModifiers {
+	var m rollModifiers
+	for _, definition := range e.activeConditions() {
+		m.add(definition.AbilityChecks, strings.ToLower(definition.Name))
+	}
+	if e.ExhaustionLevel >= exhaustionCheckDisadvantage {
+		m.add(resources.RollDisadvantage, fmt.Sprintf("exhaustion %d", e.ExhaustionLevel))
+	}
+	return m
+}
+
+// attackModifiers collects the conditions on both sides of an attack that affect the
+// roll, and returns the target condition that makes a hit critical ("" if none).
+// Without a known distance, only attacks described as ranged count as beyond 5 feet.
+func attackModifiers(attacker, target *Entity, action resources.MonsterAction) (rollModifiers, string) {
+	var m rollModifiers
+	for _, definition := range attacker.activeConditions() {
+		m.add(definition.OwnAttacks, strings.ToLower(definition.Name))
+	}
+	if attacker.ExhaustionLevel >= exhaustionRollDisadvantage {
+		m.add(resources.RollDisadvantage, fmt.Sprintf("exhaustion %d", attacker.ExhaustionLevel))
+	}
+
+	within5 := !strings.Contains(strings.ToLower(action.Description), "ranged")
+	if feet, ok := distanceBetween(attacker, target); ok {
+		within5 = feet <= 5
+	}
+	autoCrit := ""
+	for _, definition := range target.activeConditions() {
+		mode := definition.AttacksAgainst
+		if !within5 && definition.RangedAttacksAgainst != "" {
+			mode = definition.RangedAttacksAgainst
+		}
+		m.add(mode, "target "+strings.ToLower(definition.Name))
+		if within5 && definition.CritsWithin5Feet && autoCrit == "" {
+			autoCrit = strings.ToLower(definition.Name)
+		}
+	}
+	return m, autoCrit
+}
+
+// resistsAllDamage reports whether the entity halves every damage type, as a
+// petrified creature does
+func (e *Entity) resistsAllDamage() bool {
+	// A tracked "resistances" resource predates typed resistances and covers every type
+	if _, ok := e.Resources["resistances"]; ok {
+		return true
+	}
+	_, petrified := e.Conditions["petrified"]
+	return petrified
+}
+
+// conditionImmuneToDamage reports whether a condition makes the entity immune to the
+// damage type, as petrification does to poison
+func (e *Entity) conditionImmuneToDamage(damageType string) bool {
+	_, petrified := e.Conditions["petrified"]
+	return petrified && slices.Contains([]string{"poison"}, strings.ToLower(damageType))
+}
+
+// GetConditionInput defines a condition lookup
+type GetConditionInput struct {
+	Name string `json:"name" jsonschema:"Condition name, e.g. restrained"`
+}
+
+type GetConditionOutput struct {
+	Condition resources.ConditionDefinition `json:"condition"`
+	URI       string                        `json:"uri" jsonschema:"Resource URI serving the same definition"`
+	Message   string                        `json:"message"`
+}
+
+func handleGetCondition(ctx context.Context, req *mcp.CallToolRequest, input GetConditionInput) (*mcp.CallToolResult, GetConditionOutput, error) {
+	condition, ok := resources.GetCondition(input.Name)
+	if !ok {
+		return nil, GetConditionOutput{}, fmt.Errorf("unknown condition %q; valid conditions are %s", input.Name, strings.Join(resources.ConditionNames(), ", "))
+	}
+
+	message := fmt.Sprintf("%s: %s Effects: %s. Ends: %s.", condition.Name, condition.Description, strings.Join(condition.Effects, "; "), condition.EndCondition)
+	return nil, GetConditionOutput{
+		Condition: condition,
+		URI:       "srd://rules/conditions/" + strings.ToLower(condition.Name),
+		Message:   message,
+	}, nil
+}
+
+// saveEndsConditions lists the entity's conditions that a save can end, in name order
+func (e *Entity) saveEndsConditions() []string {
+	conditions := []string{}
+	for _, condition := range sortedKeys(e.Conditions) {
+		if e.conditionEnd(condition) == endsOnSave {
+			conditions = append(conditions, condition)
+		}
+	}
+	return conditions
+}
+
+// RollToEndConditionInput defines a save against a save-ends condition
+type RollToEndConditionInput struct {
+	EncounterScope
+	EntityID  string `json:"entity_id"`
+	Condition string `json:"condition" jsonschema:"Save-ends condition to shake off, e.g. stunned"`
+	SaveType  string `json:"save_type" jsonschema:"STR, DEX, CON, INT, WIS, CHA"`
+	DC        int    `json:"dc" jsonschema:"Difficulty class"`
+}
+
+type RollToEndConditionOutput struct {
+	Save              SavingThrowOutput `json:"save"`
+	Ended             bool              `json:"ended"`
+	RemainingSaveEnds []string          `json:"remaining_save_ends_conditions" jsonschema:"Save-ends conditions still affecting the entity"`
+	Message           string            `json:"message"`
+}
+
+func handleRollToEndCondition(ctx context.Context, req *mcp.CallToolRequest, input RollToEndConditionInput) (*mcp.CallToolResult, RollToEndConditionOutput, error) {
+	entity := combatState.Entities[input.EntityID]
+	if entity == nil {
+		return nil, RollToEndConditionOutput{}, fmt.Errorf("entity not found: %s", input.EntityID)
+	}
+	condition, err := canonicalCondition(input.Condition)
+	if err != nil {
+		return nil, RollToEndConditionOutput{}, err
+	}
+	if _, ok := entity.Conditions[condition]; !ok {
+		return nil, RollToEndConditionOutput{}, fmt.Errorf("%s is not %s", entity.Name, condition)
+	}
+	if entity.conditionEnd(condition) != endsOnSave {
+		return nil, RollToEndConditionOutput{}, fmt.Errorf("%s's %s condition isn't save-ends; add it with ends_at save_ends to allow saves", entity.Name, condition)
+	}
+	if input.DC <= 0 {
+		return nil, RollToEndConditionOutput{}, fmt.Errorf("dc must be positive")
+	}
+
+	save := rollSavingThrow(entity, input.SaveType, input.DC)
+	output := RollToEndConditionOutput{
+		Save:  save.output(entity, input.DC),
+		Ended: save.Success,
+	}
+	message := fmt.Sprintf("%s save to end %s: %s", save.Ability, condition, output.Save.Message)
+	if output.Ended {
+		delete(entity.Conditions, condition)
+		delete(entity.ConditionSources, condition)
+		delete(entity.ConditionEnds, condition)
+		message += fmt.Sprintf(". %s is no longer %s", entity.Name, condition)
+		combatState.logEvent("%s shakes off %s", entity.Name, condition)
+	} else {
+		message += fmt.Sprintf(". %s remains %s", entity.Name, condition)
+	}
+
+	output.RemainingSaveEnds = entity.saveEndsConditions()
+	if len(output.RemainingSaveEnds) > 0 {
+		message += fmt.Sprintf(". Save-ends conditions left: %s", strings.Join(output.RemainingSaveEnds, ", "))
+	}
+	output.Message = message + "."
+
+	return nil, output, nil
+}
@@ -0,0 +1,144 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kiriyms/dungeon-master-mcp/resources"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// contestBonus returns the entity's bonus for a skill or bare ability check, along
+// with the normalized check name
+func contestBonus(e *Entity, check string) (string, int, error) {
+	name := strings.ToLower(strings.TrimSpace(check))
+	if _, ok := skillAbilities[name]; ok {
+		bonus, _ := skillCheckBonus(e, name)
+		return name, bonus, nil
+	}
+	if ability := strings.ToUpper(name); slices.Contains(abilityNames, ability) {
+		return ability, abilityModifier(e, ability), nil
+	}
+	return "", 0, fmt.Errorf("unknown skill or ability: %s", check)
+}
+
+// ContestSide is one creature's half of a contest
+type ContestSide struct {
+	EntityID   string `json:"entity_id"`
+	Check      string `json:"check" jsonschema:"Skill or ability rolled"`
+	Passive    bool   `json:"passive,omitempty" jsonschema:"The total is a passive score (10 + bonus, +5 with advantage or -5 with disadvantage) rather than a roll"`
+	Roll       int    `json:"roll,omitempty" jsonschema:"natural d20 result"`
+	Rolls      []int  `json:"rolls,omitempty" jsonschema:"both d20s when rolled with advantage or disadvantage"`
+	RollMode   string `json:"roll_mode,omitempty"`
+	RollReason string `json:"roll_reason,omitempty" jsonschema:"What gave the roll advantage or disadvantage"`
+	Bonus      int    `json:"bonus"`
+	Total      int    `json:"total"`
+}
+
+// rollContestSide rolls one side of a contest, or takes its passive score
+func rollContestSide(e *Entity, check string, passive, advantage, disadvantage bool) (ContestSide, error) {
+	name, bonus, err := contestBonus(e, check)
+	if err != nil {
+		return ContestSide{}, err
+	}
+	side := ContestSide{EntityID: e.ID, Check: name, Passive: passive, Bonus: bonus}
+
+	modifiers := e.checkModifiers()
+	if advantage {
+		modifiers.add(resources.RollAdvantage, "circumstance")
+	}
+	if disadvantage {
+		modifiers.add(resources.RollDisadvantage, "circumstance")
+	}
+	side.RollReason = modifiers.describe()
+	if passive {
+		side.Total = 10 + bonus + modifiers.passive()
+		return side, nil
+	}
+	side.Roll, side.Rolls, side.RollMode = modifiers.roll()
+	side.Total = side.Roll + bonus
+	return side, nil
+}
+
+// describe formats the side's check for a contest message
+func (s ContestSide) describe(e *Entity) string {
+	if s.Passive && s.RollReason != "" {
+		return fmt.Sprintf("%s's passive %s %d (%s)", e.Name, s.Check, s.Total, s.RollReason)
+	}
+	if s.Passive {
+		return fmt.Sprintf("%s's passive %s %d", e.Name, s.Check, s.Total)
+	}
+	text := fmt.Sprintf("%s's %s %d%+d=%d", e.Name, s.Check, s.Roll, s.Bonus, s.Total)
+	if s.RollMode != "" {
+		text += fmt.Sprintf(" (%s, rolled %v)", s.RollReason, s.Rolls)
+	} else if s.RollReason != "" {
+		text += fmt.Sprintf(" (%s)", s.RollReason)
+	}
+	return text
+}
+
+// ContestedRollInput defines an opposed check between two creatures
+type ContestedRollInput struct {
+	EncounterScope
+	InitiatorID           string `json:"initiator_id" jsonschema:"Creature attempting the action, e.g. the grappler or the hider"`
+	InitiatorCheck        string `json:"initiator_check" jsonschema:"Skill or ability the initiator uses, e.g. athletics or STR"`
+	DefenderID            string `json:"defender_id" jsonschema:"Creature resisting the action"`
+	DefenderCheck         string `json:"defender_check" jsonschema:"Skill or ability the defender uses, e.g. acrobatics or perception"`
+	DefenderPassive       bool   `json:"defender_passive,omitempty" jsonschema:"Use the defender's passive score (10 + bonus) instead of rolling, e.g. passive Perception against Stealth"`
+	InitiatorAdvantage    bool   `json:"initiator_advantage,omitempty"`
+	InitiatorDisadvantage bool   `json:"initiator_disadvantage,omitempty"`
+	DefenderAdvantage     bool   `json:"defender_advantage,omitempty"`
+	DefenderDisadvantage  bool   `json:"defender_disadvantage,omitempty"`
+}
+
+type ContestedRollOutput struct {
+	Initiator ContestSide `json:"initiator"`
+	Defender  ContestSide `json:"defender"`
+	WinnerID  string      `json:"winner_id" jsonschema:"Ties go to the defender"`
+	Margin    int         `json:"margin" jsonschema:"Initiator total minus defender total"`
+	Message   string      `json:"message"`
+}
+
+func handleContestedRoll(ctx context.Context, req *mcp.CallToolRequest, input ContestedRollInput) (*mcp.CallToolResult, ContestedRollOutput, error) {
+	initiator := combatState.Entities[input.InitiatorID]
+	if initiator == nil {
+		return nil, ContestedRollOutput{}, fmt.Errorf("initiator not found: %s", input.InitiatorID)
+	}
+	defender := combatState.Entities[input.DefenderID]
+	if defender == nil {
+		return nil, ContestedRollOutput{}, fmt.Errorf("defender not found: %s", input.DefenderID)
+	}
+	if initiator.ID == defender.ID {
+		return nil, ContestedRollOutput{}, fmt.Errorf("%s can't contest itself", initiator.Name)
+	}
+
+	attempt, err := rollContestSide(initiator, input.InitiatorCheck, false, input.InitiatorAdvantage, input.InitiatorDisadvantage)
+	if err != nil {
+		return nil, ContestedRollOutput{}, err
+	}
+	resist, err := rollContestSide(defender, input.DefenderCheck, input.DefenderPassive, input.DefenderAdvantage, input.DefenderDisadvantage)
+	if err != nil {
+		return nil, ContestedRollOutput{}, err
+	}
+
+	output := ContestedRollOutput{
+		Initiator: attempt,
+		Defender:  resist,
+		Margin:    attempt.Total - resist.Total,
+	}
+	// A tie leaves the situation as it was, so the defender wins
+	winner, result := defender, "wins"
+	switch {
+	case output.Margin > 0:
+		winner = initiator
+	case output.Margin == 0:
+		result = "wins the tie"
+	}
+	output.WinnerID = winner.ID
+	output.Message = fmt.Sprintf("%s vs %s: %s %s.", attempt.describe(initiator), resist.describe(defender), winner.Name, result)
+	combatState.logEvent("%s contests %s (%s vs %s): %s wins", initiator.Name, defender.Name, attempt.Check, resist.Check, winner.Name)
+
+	return nil, output, nil
+}
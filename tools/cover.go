@@ -0,0 +1,46 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Degrees of cover a target can have against an attack or effect
+const (
+	coverHalf          = "half"
+	coverThreeQuarters = "three_quarters"
+	coverTotal         = "total"
+)
+
+// parseCover normalizes a cover argument, accepting spellings such as
+// three-quarters and 3/4; an empty string or none means no cover
+func parseCover(cover string) (string, error) {
+	switch strings.NewReplacer("-", "_", " ", "_").Replace(strings.ToLower(strings.TrimSpace(cover))) {
+	case "", "none":
+		return "", nil
+	case "half", "1/2":
+		return coverHalf, nil
+	case "three_quarters", "three_quarter", "3/4":
+		return coverThreeQuarters, nil
+	case "total", "full":
+		return coverTotal, nil
+	}
+	return "", fmt.Errorf("unknown cover: %s (use half, three_quarters, or total)", cover)
+}
+
+// coverBonus is what cover adds to the target's AC and DEX saves; total cover
+// instead keeps the target from being targeted at all
+func coverBonus(cover string) int {
+	switch cover {
+	case coverHalf:
+		return 2
+	case coverThreeQuarters:
+		return 5
+	}
+	return 0
+}
+
+// describeCover names the cover for messages, e.g. three-quarters cover
+func describeCover(cover string) string {
+	return strings.ReplaceAll(cover, "_", "-") + " cover"
+}
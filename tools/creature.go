@@ -0,0 +1,50 @@
+package tools
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// sizeOrder ranks size categories from smallest to largest
+var sizeOrder = []string{"tiny", "small", "medium", "large", "huge", "gargantuan"}
+
+// sizeRank returns the position of a size category, or -1 if it's unknown
+func sizeRank(size string) int {
+	return slices.Index(sizeOrder, strings.ToLower(size))
+}
+
+// describeCreature returns a short "Size type" description such as "Medium humanoid"
+func describeCreature(e *Entity) string {
+	return strings.TrimSpace(e.Size + " " + e.CreatureType)
+}
+
+// restrictionReason explains why an effect limited to certain creature types or sizes
+// can't apply to the entity, or returns "" if it can
+func restrictionReason(e *Entity, allowedTypes []string, maxSize string) string {
+	if len(allowedTypes) > 0 {
+		allowed := false
+		for _, t := range allowedTypes {
+			if strings.EqualFold(t, e.CreatureType) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			creatureType := e.CreatureType
+			if creatureType == "" {
+				creatureType = "of unknown type"
+			}
+			return fmt.Sprintf("it is %s but the effect only works on %s", creatureType, strings.Join(allowedTypes, ", "))
+		}
+	}
+
+	if maxSize != "" {
+		limit := sizeRank(maxSize)
+		if limit >= 0 && sizeRank(e.Size) > limit {
+			return fmt.Sprintf("it is %s but the effect only works on %s or smaller creatures", e.Size, maxSize)
+		}
+	}
+
+	return ""
+}
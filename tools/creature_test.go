@@ -0,0 +1,47 @@
+package tools
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCreatureRestrictedEffects(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name         string
+		targetID     string
+		allowedTypes []string
+		maxSize      string
+		applied      bool
+	}{
+		{"humanoid-only effect refused by a dragon", "dragon", []string{"humanoid"}, "", false},
+		{"humanoid-only effect lands on a humanoid", "fighter", []string{"humanoid"}, "", true},
+		{"Large or smaller rider refused by a Gargantuan dragon", "dragon", nil, "Large", false},
+		{"Large or smaller rider lands on a Medium humanoid", "fighter", nil, "Large", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			startTestCombat(t,
+				EntityInit{ID: "fighter", Name: "Fighter", Initiative: 15, HP: 30, AC: 16},
+				EntityInit{ID: "dragon", Name: "Red", Initiative: 20, HP: 546, AC: 22, IsMonster: true, MonsterName: "Ancient Red Dragon"},
+			)
+
+			_, output, err := handleAddCondition(ctx, nil, AddConditionInput{
+				TargetID:     tt.targetID,
+				Condition:    "charmed",
+				Duration:     10,
+				AllowedTypes: tt.allowedTypes,
+				MaxSize:      tt.maxSize,
+			})
+			if err != nil {
+				t.Fatalf("add_condition: %v", err)
+			}
+
+			_, charmed := combatState.Entities[tt.targetID].Conditions["charmed"]
+			if output.Applied != tt.applied || charmed != tt.applied {
+				t.Errorf("applied = %v, charmed = %v, want %v (%s)", output.Applied, charmed, tt.applied, output.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// CritEffect is one entry on a critical-hit effects table
+type CritEffect struct {
+	Description string `json:"description"`
+	Weight      int    `json:"weight,omitempty" jsonschema:"How many numbers on the die this entry covers (defaults to 1)"`
+	Condition   string `json:"condition,omitempty" jsonschema:"Condition applied to the target"`
+	Duration    int    `json:"duration,omitempty" jsonschema:"Condition duration in turns, -1 for permanent"`
+	EndsAt      string `json:"ends_at,omitempty" jsonschema:"When the condition's duration counts down: start_of_turn (default), end_of_turn, or save_ends"`
+	// Ongoing damage applied to the target, e.g. bleeding
+	OngoingDice   string `json:"ongoing_dice,omitempty" jsonschema:"Damage dice dealt at the start of each of the target's turns"`
+	OngoingType   string `json:"ongoing_type,omitempty"`
+	OngoingRounds int    `json:"ongoing_rounds,omitempty" jsonschema:"Rounds the ongoing damage lasts (0 = until saved)"`
+	SaveType      string `json:"save_type,omitempty" jsonschema:"Save that ends the ongoing damage"`
+	DC            int    `json:"dc,omitempty"`
+}
+
+// defaultCritTable is a d100 table of optional grisly critical-hit effects
+var defaultCritTable = []CritEffect{
+	{Description: "Solid hit - no additional effect", Weight: 40},
+	{Description: "Knocked off balance - the target is knocked prone", Weight: 15, Condition: "prone", Duration: -1},
+	{Description: "Deep cut - the target bleeds for 1d4 slashing damage each turn for 3 rounds", Weight: 15, OngoingDice: "1d4", OngoingType: "slashing", OngoingRounds: 3},
+	{Description: "Rattled - the target is frightened until the end of its next turn", Weight: 10, Condition: "frightened", Duration: 1, EndsAt: endsEndOfTurn},
+	{Description: "Ringing blow - the target is deafened for 3 rounds", Weight: 8, Condition: "deafened", Duration: 3},
+	{Description: "Eye wound - the target is blinded for 1 round", Weight: 6, Condition: "blinded", Duration: 1},
+	{Description: "Staggering strike - the target is stunned for 1 round", Weight: 5, Condition: "stunned", Duration: 1},
+	{Description: "Grievous wound - the target bleeds for 2d6 slashing damage each turn until it succeeds on a DC 12 CON save", Weight: 1, OngoingDice: "2d6", OngoingType: "slashing", SaveType: "CON", DC: 12},
+}
+
+// CritEffectInput defines a roll on the critical-hit effects table
+type CritEffectInput struct {
+	EncounterScope
+	TargetID   string       `json:"target_id" jsonschema:"Creature that was critically hit"`
+	AttackerID string       `json:"attacker_id,omitempty" jsonschema:"Creature that landed the hit, recorded as the source of any condition"`
+	Table      []CritEffect `json:"table,omitempty" jsonschema:"Custom effects table (defaults to the built-in d100 table)"`
+}
+
+type CritEffectOutput struct {
+	Roll    int        `json:"roll"`
+	Die     int        `json:"die"`
+	Effect  CritEffect `json:"effect"`
+	Applied []string   `json:"applied" jsonschema:"Conditions and ongoing damage applied to the target"`
+	Message string     `json:"message"`
+}
+
+func handleCritEffect(ctx context.Context, req *mcp.CallToolRequest, input CritEffectInput) (*mcp.CallToolResult, CritEffectOutput, error) {
+	target := combatState.Entities[input.TargetID]
+	if target == nil {
+		return nil, CritEffectOutput{}, fmt.Errorf("target not found: %s", input.TargetID)
+	}
+	if input.AttackerID != "" && combatState.Entities[input.AttackerID] == nil {
+		return nil, CritEffectOutput{}, fmt.Errorf("attacker not found: %s", input.AttackerID)
+	}
+
+	table := slices.Clone(input.Table)
+	if len(table) == 0 {
+		table = slices.Clone(defaultCritTable)
+	}
+	entries := make([]TableEntry, len(table))
+	die := 0
+	for i, effect := range table {
+		if effect.Condition != "" {
+			condition, err := canonicalCondition(effect.Condition)
+			if err != nil {
+				return nil, CritEffectOutput{}, fmt.Errorf("table entry %d: %w", i+1, err)
+			}
+			trigger, err := conditionEndTrigger(effect.EndsAt)
+			if err != nil {
+				return nil, CritEffectOutput{}, fmt.Errorf("table entry %d: %w", i+1, err)
+			}
+			table[i].Condition, table[i].EndsAt = condition, trigger
+		}
+		if effect.OngoingDice != "" {
+			if _, _, _, err := parseDice(effect.OngoingDice); err != nil {
+				return nil, CritEffectOutput{}, fmt.Errorf("table entry %d: %w", i+1, err)
+			}
+		}
+		entries[i] = TableEntry{Result: effect.Description, Weight: effect.Weight}
+		die += max(effect.Weight, 1)
+	}
+
+	index, roll, err := rollOnTable(entries)
+	if err != nil {
+		return nil, CritEffectOutput{}, err
+	}
+	effect := table[index]
+
+	applied := []string{}
+	if effect.Condition != "" {
+		duration := effect.Duration
+		if duration == 0 {
+			duration = 1
+		}
+		target.Conditions[effect.Condition] = duration
+		target.setConditionSource(effect.Condition, input.AttackerID)
+		target.setConditionEnd(effect.Condition, effect.EndsAt)
+		applied = append(applied, fmt.Sprintf("condition %s", effect.Condition))
+	}
+	if effect.OngoingDice != "" {
+		rounds := effect.OngoingRounds
+		if rounds <= 0 {
+			rounds = -1
+		}
+		addOngoingEffect(target, &OngoingEffect{
+			Name:            "Critical wound",
+			DamageDice:      effect.OngoingDice,
+			DamageType:      effect.OngoingType,
+			SaveType:        effect.SaveType,
+			DC:              effect.DC,
+			RoundsRemaining: rounds,
+		})
+		applied = append(applied, fmt.Sprintf("ongoing %s %s damage", effect.OngoingDice, effect.OngoingType))
+	}
+
+	return nil, CritEffectOutput{
+		Roll:    roll,
+		Die:     die,
+		Effect:  effect,
+		Applied: applied,
+		Message: fmt.Sprintf("Critical effect on %s (rolled %d on d%d): %s", target.Name, roll, die, effect.Description),
+	}, nil
+}
@@ -0,0 +1,43 @@
+package tools
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCritEffectConditions(t *testing.T) {
+	ctx := context.Background()
+	startTestCombat(t,
+		EntityInit{ID: "fighter", Name: "Fighter", Initiative: 15, HP: 30, AC: 16},
+		EntityInit{ID: "orc", Name: "Orc", Initiative: 10, HP: 15, AC: 13, IsMonster: true},
+	)
+
+	if _, _, err := handleCritEffect(ctx, nil, CritEffectInput{TargetID: "orc", Table: []CritEffect{{Description: "Dazed", Condition: "dazd"}}}); err == nil {
+		t.Error("a table entry with an unknown condition was accepted")
+	}
+
+	var rattled CritEffect
+	for _, effect := range defaultCritTable {
+		if effect.Condition == "frightened" {
+			rattled = effect
+		}
+	}
+	_, output, err := handleCritEffect(ctx, nil, CritEffectInput{TargetID: "orc", AttackerID: "fighter", Table: []CritEffect{rattled}})
+	if err != nil {
+		t.Fatalf("crit_effect: %v", err)
+	}
+	orc := combatState.Entities["orc"]
+	if orc.ConditionSources["frightened"] != "fighter" {
+		t.Errorf("frightened source = %q, want fighter (%s)", orc.ConditionSources["frightened"], output.Message)
+	}
+
+	// Frightened until the end of its next turn lasts through that turn
+	combatState.advanceTurn()
+	if _, ok := orc.Conditions["frightened"]; !ok {
+		t.Fatal("frightened ended at the start of the orc's turn")
+	}
+	combatState.advanceTurn()
+	if _, ok := orc.Conditions["frightened"]; ok {
+		t.Error("frightened outlasted the end of the orc's turn")
+	}
+}
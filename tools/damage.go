@@ -0,0 +1,310 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/kiriyms/dungeon-master-mcp/resources"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Damage pipeline stages
+const (
+	stageVulnerability = "vulnerability"
+	stageResistance    = "resistance"
+	stageReduction     = "reduction"
+	stageImmunity      = "immunity"
+)
+
+// defaultDamageOrder applies resistance before flat reduction, per the common ruling
+var defaultDamageOrder = []string{stageVulnerability, stageResistance, stageReduction, stageImmunity}
+
+// DamageStep is the damage after one stage of the pipeline
+type DamageStep struct {
+	Stage string `json:"stage"`
+	Value int    `json:"value" jsonschema:"Damage after this stage"`
+	Note  string `json:"note,omitempty" jsonschema:"What the stage did, if anything"`
+}
+
+// damageOrder returns the configured pipeline order
+func (cs *CombatState) damageOrder() []string {
+	if len(cs.DamageOrder) == 0 {
+		return defaultDamageOrder
+	}
+	return cs.DamageOrder
+}
+
+// hasDamageType reports whether the list covers the damage type, ignoring case. Stat
+// block entries such as "bludgeoning, piercing, and slashing from nonmagical attacks"
+// cover each type they name; the nonmagical qualifier is left to the DM.
+func hasDamageType(types []string, damageType string) bool {
+	if damageType == "" {
+		return false
+	}
+	for _, t := range types {
+		words := strings.FieldsFunc(strings.ToLower(t), func(r rune) bool { return !unicode.IsLetter(r) })
+		if slices.Contains(words, strings.ToLower(damageType)) {
+			return true
+		}
+	}
+	return false
+}
+
+// scaleDamage multiplies damage by a DamageRules multiplier, rounding down
+func scaleDamage(damage int, multiplier float64) int {
+	return int(math.Floor(float64(damage) * multiplier))
+}
+
+// isImmune reports whether the entity takes no damage of the type, innately or temporarily
+func isImmune(e *Entity, damageType string) bool {
+	if _, ok := e.TempImmunities[strings.ToLower(damageType)]; ok {
+		return true
+	}
+	return hasDamageType(e.Immunities, damageType) || e.conditionImmuneToDamage(damageType)
+}
+
+// calculateDamage runs raw damage through each pipeline stage in order, recording the
+// value after every stage
+func calculateDamage(target *Entity, damage int, damageType string, order []string) (int, []DamageStep) {
+	steps := []DamageStep{{Stage: "raw", Value: damage}}
+	value := damage
+
+	for _, stage := range order {
+		step := DamageStep{Stage: stage}
+		switch stage {
+		case stageVulnerability:
+			if hasDamageType(target.Vulnerabilities, damageType) {
+				value = scaleDamage(value, resources.SRDDamageRules.VulnerabilityMultiplier)
+				step.Note = "vulnerable: doubled"
+			}
+		case stageResistance:
+			if target.resistsAllDamage() || hasDamageType(target.Resistances, damageType) {
+				value = scaleDamage(value, resources.SRDDamageRules.ResistanceMultiplier)
+				step.Note = "resisted: halved"
+			}
+		case stageReduction:
+			if target.DamageReduction > 0 && (len(target.DamageReductionTypes) == 0 || hasDamageType(target.DamageReductionTypes, damageType)) {
+				value = max(value-target.DamageReduction, 0)
+				step.Note = fmt.Sprintf("reduced by %d", target.DamageReduction)
+			}
+		case stageImmunity:
+			if isImmune(target, damageType) {
+				value = 0
+				step.Note = "immune"
+			}
+		}
+		step.Value = value
+		steps = append(steps, step)
+	}
+
+	return value, steps
+}
+
+// applyDamageSteps amplifies damage with any next-hit marker, runs it through the damage
+// pipeline, and subtracts it from the target's HP, returning the final damage, a short
+// note on the modifiers that applied, and the pipeline steps
+func applyDamageSteps(target *Entity, damage int, damageType string) (int, string, []DamageStep) {
+	notes := []string{}
+	// Damage never heals, whatever a caller passes in
+	damage = max(damage, 0)
+
+	// A one-shot marker amplifies the first hit that actually deals damage, so an
+	// immune target doesn't waste it
+	if target.NextHitBonus != nil && damage > 0 && !isImmune(target, damageType) {
+		var note string
+		damage, note = consumeNextHitBonus(target, damage)
+		notes = append(notes, note)
+	}
+
+	finalDamage, steps := calculateDamage(target, damage, damageType, combatState.damageOrder())
+	for _, step := range steps {
+		if step.Note != "" {
+			notes = append(notes, step.Note)
+		}
+	}
+
+	target.CurrentHP -= finalDamage
+	if target.CurrentHP < 0 {
+		target.CurrentHP = 0
+	}
+
+	modifier := ""
+	if len(notes) > 0 {
+		modifier = fmt.Sprintf(" (%s)", strings.Join(notes, "; "))
+	}
+	return finalDamage, modifier, steps
+}
+
+// DamageComponent is one damage type of a multi-type hit
+type DamageComponent struct {
+	Dice       string `json:"dice,omitempty" jsonschema:"Dice to roll, e.g. 1d8"`
+	Damage     int    `json:"damage,omitempty" jsonschema:"Pre-rolled amount, used when dice is omitted"`
+	DamageType string `json:"damage_type"`
+}
+
+// ComponentResult is one damage type of a multi-type hit after the damage pipeline
+type ComponentResult struct {
+	DamageType  string       `json:"damage_type"`
+	Roll        *DiceRoll    `json:"roll,omitempty"`
+	Damage      int          `json:"damage" jsonschema:"Damage before the target's modifiers"`
+	FinalDamage int          `json:"final_damage" jsonschema:"Damage after resistances, immunities and reductions"`
+	Steps       []DamageStep `json:"steps"`
+}
+
+// applyDamageComponents rolls every component of a multi-type hit, doubling the dice
+// on a critical, then runs each through the damage pipeline on its own so a
+// resistance or immunity only affects its own type
+func applyDamageComponents(target *Entity, components []DamageComponent, critical bool) (ApplyDamageOutput, error) {
+	diceMultiplier := 1
+	if critical {
+		diceMultiplier = resources.SRDDamageRules.CriticalMultiplier
+	}
+
+	// Roll everything before touching HP so a bad expression changes nothing
+	results := make([]ComponentResult, 0, len(components))
+	for _, component := range components {
+		if component.DamageType == "" {
+			return ApplyDamageOutput{}, fmt.Errorf("every damage component needs a damage_type")
+		}
+		if component.Damage < 0 {
+			return ApplyDamageOutput{}, fmt.Errorf("%s damage can't be negative", component.DamageType)
+		}
+		result := ComponentResult{DamageType: component.DamageType, Damage: component.Damage}
+		if component.Dice != "" {
+			roll, err := rollDice(component.Dice, diceMultiplier)
+			if err != nil {
+				return ApplyDamageOutput{}, err
+			}
+			result.Roll = &roll
+			result.Damage = roll.Total
+		}
+		results = append(results, result)
+	}
+
+	output := ApplyDamageOutput{}
+	parts := []string{}
+	for i := range results {
+		result := &results[i]
+		var modifier string
+		result.FinalDamage, modifier, result.Steps = applyDamageSteps(target, result.Damage, result.DamageType)
+		output.FinalDamage += result.FinalDamage
+
+		part := fmt.Sprintf("%d %s", result.FinalDamage, result.DamageType)
+		if result.Roll != nil {
+			part += fmt.Sprintf(" (rolled %s: %v = %d)", components[i].Dice, result.Roll.Rolls, result.Roll.Total)
+		} else if result.FinalDamage != result.Damage {
+			part += fmt.Sprintf(" (from %d)", result.Damage)
+		}
+		parts = append(parts, part+modifier)
+	}
+	output.Components = results
+
+	critNote := ""
+	if critical {
+		critNote = fmt.Sprintf(" (critical, %dx dice)", diceMultiplier)
+	}
+	output.Message = fmt.Sprintf("%s takes %d damage%s: %s. %d HP remaining.", target.Name, output.FinalDamage, critNote, strings.Join(parts, ", "), target.CurrentHP)
+	return output, nil
+}
+
+// SetDamageModifiersInput defines an entity's damage modifiers; omitted lists are left unchanged
+type SetDamageModifiersInput struct {
+	EncounterScope
+	EntityID             string   `json:"entity_id"`
+	Resistances          []string `json:"resistances,omitempty" jsonschema:"Damage types the entity resists"`
+	Vulnerabilities      []string `json:"vulnerabilities,omitempty" jsonschema:"Damage types the entity is vulnerable to"`
+	Immunities           []string `json:"immunities,omitempty" jsonschema:"Damage types the entity is innately immune to"`
+	DamageReduction      *int     `json:"damage_reduction,omitempty" jsonschema:"Flat amount subtracted from each instance of damage"`
+	DamageReductionTypes []string `json:"damage_reduction_types,omitempty" jsonschema:"Damage types the flat reduction applies to (empty = all)"`
+}
+
+type SetDamageModifiersOutput struct {
+	Message string `json:"message"`
+}
+
+func handleSetDamageModifiers(ctx context.Context, req *mcp.CallToolRequest, input SetDamageModifiersInput) (*mcp.CallToolResult, SetDamageModifiersOutput, error) {
+	entity := combatState.Entities[input.EntityID]
+	if entity == nil {
+		return nil, SetDamageModifiersOutput{}, fmt.Errorf("entity not found: %s", input.EntityID)
+	}
+
+	if input.Resistances != nil {
+		entity.Resistances = input.Resistances
+	}
+	if input.Vulnerabilities != nil {
+		entity.Vulnerabilities = input.Vulnerabilities
+	}
+	if input.Immunities != nil {
+		entity.Immunities = input.Immunities
+	}
+	if input.DamageReduction != nil {
+		if *input.DamageReduction < 0 {
+			return nil, SetDamageModifiersOutput{}, fmt.Errorf("damage reduction can't be negative")
+		}
+		entity.DamageReduction = *input.DamageReduction
+	}
+	if input.DamageReductionTypes != nil {
+		entity.DamageReductionTypes = input.DamageReductionTypes
+	}
+
+	parts := []string{}
+	for _, list := range []struct {
+		label string
+		types []string
+	}{
+		{"resists", entity.Resistances},
+		{"vulnerable to", entity.Vulnerabilities},
+		{"immune to", entity.Immunities},
+	} {
+		if len(list.types) > 0 {
+			parts = append(parts, fmt.Sprintf("%s %s", list.label, strings.Join(list.types, ", ")))
+		}
+	}
+	if entity.DamageReduction > 0 {
+		parts = append(parts, fmt.Sprintf("reduces damage by %d", entity.DamageReduction))
+	}
+
+	message := fmt.Sprintf("%s has no damage modifiers.", entity.Name)
+	if len(parts) > 0 {
+		message = fmt.Sprintf("%s %s.", entity.Name, strings.Join(parts, "; "))
+	}
+	return nil, SetDamageModifiersOutput{Message: message}, nil
+}
+
+// SetDamageOrderInput defines the damage pipeline order
+type SetDamageOrderInput struct {
+	EncounterScope
+	Order []string `json:"order,omitempty" jsonschema:"All four stages in order: vulnerability, resistance, reduction, immunity (empty restores the default)"`
+}
+
+type SetDamageOrderOutput struct {
+	Order   []string `json:"order"`
+	Message string   `json:"message"`
+}
+
+func handleSetDamageOrder(ctx context.Context, req *mcp.CallToolRequest, input SetDamageOrderInput) (*mcp.CallToolResult, SetDamageOrderOutput, error) {
+	if len(input.Order) == 0 {
+		combatState.DamageOrder = nil
+	} else {
+		order := make([]string, len(input.Order))
+		for i, stage := range input.Order {
+			order[i] = strings.ToLower(stage)
+		}
+		sorted := slices.Sorted(slices.Values(order))
+		expected := slices.Sorted(slices.Values(defaultDamageOrder))
+		if !slices.Equal(sorted, expected) {
+			return nil, SetDamageOrderOutput{}, fmt.Errorf("order must list each of %s exactly once", strings.Join(defaultDamageOrder, ", "))
+		}
+		combatState.DamageOrder = order
+	}
+
+	order := combatState.damageOrder()
+	return nil, SetDamageOrderOutput{
+		Order:   order,
+		Message: fmt.Sprintf("Damage pipeline: %s.", strings.Join(order, " -> ")),
+	}, nil
+}
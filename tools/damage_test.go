@@ -0,0 +1,105 @@
+package tools
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestDamagePipelineOrders(t *testing.T) {
+	// Each order is set with set_damage_order; nil keeps the default
+	orders := []struct {
+		name  string
+		order []string
+	}{
+		{"default", nil},
+		{"reduction first", []string{stageReduction, stageResistance, stageVulnerability, stageImmunity}},
+		{"immunity first", []string{stageImmunity, stageVulnerability, stageReduction, stageResistance}},
+	}
+
+	// 25 fire damage against every combination of modifiers; steps holds the value
+	// after the raw stage and after each stage, for each order above
+	tests := []struct {
+		name                                     string
+		vulnerable, resistant, reduction, immune bool
+		steps                                    [3][]int
+	}{
+		{"none", false, false, false, false, [3][]int{{25, 25, 25, 25, 25}, {25, 25, 25, 25, 25}, {25, 25, 25, 25, 25}}},
+		{"immune", false, false, false, true, [3][]int{{25, 25, 25, 25, 0}, {25, 25, 25, 25, 0}, {25, 0, 0, 0, 0}}},
+		{"reduction", false, false, true, false, [3][]int{{25, 25, 25, 20, 20}, {25, 20, 20, 20, 20}, {25, 25, 25, 20, 20}}},
+		{"reduction+immune", false, false, true, true, [3][]int{{25, 25, 25, 20, 0}, {25, 20, 20, 20, 0}, {25, 0, 0, 0, 0}}},
+		{"resistant", false, true, false, false, [3][]int{{25, 25, 12, 12, 12}, {25, 25, 12, 12, 12}, {25, 25, 25, 25, 12}}},
+		{"resistant+immune", false, true, false, true, [3][]int{{25, 25, 12, 12, 0}, {25, 25, 12, 12, 0}, {25, 0, 0, 0, 0}}},
+		{"resistant+reduction", false, true, true, false, [3][]int{{25, 25, 12, 7, 7}, {25, 20, 10, 10, 10}, {25, 25, 25, 20, 10}}},
+		{"resistant+reduction+immune", false, true, true, true, [3][]int{{25, 25, 12, 7, 0}, {25, 20, 10, 10, 0}, {25, 0, 0, 0, 0}}},
+		{"vulnerable", true, false, false, false, [3][]int{{25, 50, 50, 50, 50}, {25, 25, 25, 50, 50}, {25, 25, 50, 50, 50}}},
+		{"vulnerable+immune", true, false, false, true, [3][]int{{25, 50, 50, 50, 0}, {25, 25, 25, 50, 0}, {25, 0, 0, 0, 0}}},
+		{"vulnerable+reduction", true, false, true, false, [3][]int{{25, 50, 50, 45, 45}, {25, 20, 20, 40, 40}, {25, 25, 50, 45, 45}}},
+		{"vulnerable+reduction+immune", true, false, true, true, [3][]int{{25, 50, 50, 45, 0}, {25, 20, 20, 40, 0}, {25, 0, 0, 0, 0}}},
+		{"vulnerable+resistant", true, true, false, false, [3][]int{{25, 50, 25, 25, 25}, {25, 25, 12, 24, 24}, {25, 25, 50, 50, 25}}},
+		{"vulnerable+resistant+immune", true, true, false, true, [3][]int{{25, 50, 25, 25, 0}, {25, 25, 12, 24, 0}, {25, 0, 0, 0, 0}}},
+		{"vulnerable+resistant+reduction", true, true, true, false, [3][]int{{25, 50, 25, 20, 20}, {25, 20, 10, 20, 20}, {25, 25, 50, 45, 22}}},
+		{"vulnerable+resistant+reduction+immune", true, true, true, true, [3][]int{{25, 50, 25, 20, 0}, {25, 20, 10, 20, 0}, {25, 0, 0, 0, 0}}},
+	}
+
+	t.Cleanup(resetEncounters)
+	for i, order := range orders {
+		combatState = newCombatState()
+		_, set, err := handleSetDamageOrder(context.Background(), nil, SetDamageOrderInput{Order: order.order})
+		if err != nil {
+			t.Fatalf("%s: set_damage_order: %v", order.name, err)
+		}
+
+		for _, tt := range tests {
+			t.Run(order.name+"/"+tt.name, func(t *testing.T) {
+				target := &Entity{ID: "target", Name: "Target", CurrentHP: 100, MaxHP: 100}
+				applied := map[string]bool{
+					stageVulnerability: tt.vulnerable,
+					stageResistance:    tt.resistant,
+					stageReduction:     tt.reduction,
+					stageImmunity:      tt.immune,
+				}
+				if tt.vulnerable {
+					target.Vulnerabilities = []string{"fire"}
+				}
+				if tt.resistant {
+					target.Resistances = []string{"fire"}
+				}
+				if tt.reduction {
+					target.DamageReduction = 5
+				}
+				if tt.immune {
+					target.Immunities = []string{"fire"}
+				}
+
+				final, _, steps := applyDamageSteps(target, 25, "fire")
+
+				want := tt.steps[i]
+				if final != want[len(want)-1] {
+					t.Errorf("final damage = %d, want %d", final, want[len(want)-1])
+				}
+				if target.CurrentHP != 100-final {
+					t.Errorf("HP = %d, want %d", target.CurrentHP, 100-final)
+				}
+
+				stages := []string{}
+				values := []int{}
+				for _, step := range steps {
+					stages = append(stages, step.Stage)
+					values = append(values, step.Value)
+				}
+				if wantStages := append([]string{"raw"}, set.Order...); !slices.Equal(stages, wantStages) {
+					t.Errorf("stages = %v, want %v", stages, wantStages)
+				}
+				if !slices.Equal(values, want) {
+					t.Errorf("step values = %v, want %v", values, want)
+				}
+				for _, step := range steps[1:] {
+					if (step.Note != "") != applied[step.Stage] {
+						t.Errorf("%s step note = %q, want a note only when the modifier applies", step.Stage, step.Note)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// deathSaveLimit is the number of successes to stabilize or failures to die
+const deathSaveLimit = 3
+
+// resetDeathSaves clears the entity's death saving throw tally
+func (e *Entity) resetDeathSaves() {
+	e.DeathSaveSuccesses = 0
+	e.DeathSaveFailures = 0
+}
+
+// knockOut leaves an entity at 0 HP unconscious and stable, as after nonlethal damage
+func (e *Entity) knockOut() {
+	e.Conditions["unconscious"] = -1
+	e.DeathSaveSuccesses = deathSaveLimit
+	e.DeathSaveFailures = 0
+}
+
+// revive clears the unconscious condition and death save tally of an entity healed
+// up from 0 HP
+func (e *Entity) revive() {
+	e.resetDeathSaves()
+	delete(e.Conditions, "unconscious")
+	delete(e.ConditionSources, "unconscious")
+	delete(e.ConditionEnds, "unconscious")
+}
+
+// deathStatus describes where an entity stands with death saves
+func (e *Entity) deathStatus() string {
+	switch {
+	case e.Dead:
+		return "dead"
+	case e.CurrentHP > 0:
+		return "conscious"
+	case e.DeathSaveSuccesses >= deathSaveLimit:
+		return "stable"
+	default:
+		return "dying"
+	}
+}
+
+// DeathSaveInput defines a death saving throw
+type DeathSaveInput struct {
+	EncounterScope
+	EntityID string `json:"entity_id"`
+}
+
+type DeathSaveOutput struct {
+	Roll      int    `json:"roll"`
+	Successes int    `json:"successes"`
+	Failures  int    `json:"failures"`
+	Status    string `json:"status" jsonschema:"dying, stable, dead, or conscious (after a natural 20)"`
+	Message   string `json:"message"`
+}
+
+func handleDeathSave(ctx context.Context, req *mcp.CallToolRequest, input DeathSaveInput) (*mcp.CallToolResult, DeathSaveOutput, error) {
+	entity := combatState.Entities[input.EntityID]
+	if entity == nil {
+		return nil, DeathSaveOutput{}, fmt.Errorf("entity not found: %s", input.EntityID)
+	}
+	switch entity.deathStatus() {
+	case "dead":
+		return nil, DeathSaveOutput{}, fmt.Errorf("%s is dead", entity.Name)
+	case "conscious":
+		return nil, DeathSaveOutput{}, fmt.Errorf("%s has %d HP and doesn't make death saves", entity.Name, entity.CurrentHP)
+	case "stable":
+		return nil, DeathSaveOutput{}, fmt.Errorf("%s is stable and doesn't make death saves", entity.Name)
+	}
+
+	roll := rand.Intn(20) + 1
+	var result string
+	switch {
+	case roll == 20:
+		// A natural 20 brings the creature back with 1 HP
+		entity.CurrentHP = 1
+		entity.revive()
+		result = fmt.Sprintf("natural 20! %s regains 1 HP and is conscious", entity.Name)
+	case roll == 1:
+		entity.DeathSaveFailures += 2
+		result = "natural 1, two failures"
+	case roll >= 10:
+		entity.DeathSaveSuccesses++
+		result = "success"
+	default:
+		entity.DeathSaveFailures++
+		result = "failure"
+	}
+
+	if entity.DeathSaveFailures >= deathSaveLimit {
+		entity.Dead = true
+		result += fmt.Sprintf(". %s dies", entity.Name)
+		combatState.logEvent("%s dies after failing death saves", entity.Name)
+	} else if entity.DeathSaveSuccesses >= deathSaveLimit {
+		result += fmt.Sprintf(". %s is stable", entity.Name)
+		combatState.logEvent("%s stabilizes", entity.Name)
+	}
+
+	output := DeathSaveOutput{
+		Roll:      roll,
+		Successes: entity.DeathSaveSuccesses,
+		Failures:  min(entity.DeathSaveFailures, deathSaveLimit),
+		Status:    entity.deathStatus(),
+	}
+	output.Message = fmt.Sprintf("%s rolls a death save: %d, %s. Successes %d/%d, failures %d/%d.",
+		entity.Name, roll, result, output.Successes, deathSaveLimit, output.Failures, deathSaveLimit)
+
+	return nil, output, nil
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -69,3 +72,180 @@ func RollD20Disadvantage(ctx context.Context, req *mcp.CallToolRequest, _ struct
 		Note:  "Rolled with disadvantage (kept lowest)",
 	}, nil
 }
+
+// DiceRoll is the result of rolling a dice expression such as "2d6+3"
+type DiceRoll struct {
+	Expression string `json:"expression" jsonschema:"the dice expression that was rolled"`
+	Rolls      []int  `json:"rolls" jsonschema:"individual die results"`
+	Dropped    []int  `json:"dropped,omitempty" jsonschema:"dice discarded by a keep-highest or keep-lowest suffix"`
+	Modifier   int    `json:"modifier" jsonschema:"flat modifier added to the dice"`
+	Total      int    `json:"total" jsonschema:"sum of the kept dice plus the modifier"`
+}
+
+// Limits that keep a typo like 1000d1000 from stalling the server
+const (
+	maxDiceCount = 100
+	maxDieSize   = 1000
+)
+
+// diceExpr is a parsed dice expression: NdM, an optional khK/klK suffix, and a flat modifier
+type diceExpr struct {
+	Count      int
+	Sides      int
+	Modifier   int
+	Keep       int // dice kept after rolling; 0 keeps them all
+	KeepLowest bool
+}
+
+// parseDiceExpr parses an expression like "2d6+3", "4d6kh3", or "2d20kl1-1"
+func parseDiceExpr(expr string) (diceExpr, error) {
+	s := strings.ReplaceAll(strings.ToLower(expr), " ", "")
+	if s == "" {
+		return diceExpr{}, fmt.Errorf("empty dice expression")
+	}
+
+	var e diceExpr
+	var err error
+
+	// Split off a trailing flat modifier
+	if i := strings.LastIndexAny(s, "+-"); i > 0 {
+		e.Modifier, err = strconv.Atoi(s[i:])
+		if err != nil {
+			return diceExpr{}, fmt.Errorf("invalid modifier in dice expression %q", expr)
+		}
+		s = s[:i]
+	}
+
+	countStr, sidesStr, ok := strings.Cut(s, "d")
+	if !ok {
+		// A bare number is a flat value with no dice
+		e.Modifier, err = strconv.Atoi(s)
+		if err != nil {
+			return diceExpr{}, fmt.Errorf("invalid dice expression %q", expr)
+		}
+		return e, nil
+	}
+
+	e.Count = 1
+	if countStr != "" {
+		e.Count, err = strconv.Atoi(countStr)
+		if err != nil || e.Count < 0 || e.Count > maxDiceCount {
+			return diceExpr{}, fmt.Errorf("invalid dice count in %q (0 to %d)", expr, maxDiceCount)
+		}
+	}
+
+	// Split off a keep-highest or keep-lowest suffix
+	for _, suffix := range []string{"kh", "kl"} {
+		if before, keepStr, found := strings.Cut(sidesStr, suffix); found {
+			e.Keep, err = strconv.Atoi(keepStr)
+			if err != nil || e.Keep < 1 || e.Keep > e.Count {
+				return diceExpr{}, fmt.Errorf("invalid keep count in %q (1 to %d)", expr, e.Count)
+			}
+			e.KeepLowest = suffix == "kl"
+			sidesStr = before
+			break
+		}
+	}
+
+	e.Sides, err = strconv.Atoi(sidesStr)
+	if err != nil || e.Sides < 1 || e.Sides > maxDieSize {
+		return diceExpr{}, fmt.Errorf("invalid die size in %q", expr)
+	}
+
+	return e, nil
+}
+
+// parseDice splits an expression like "2d6+3" into dice count, die size and modifier
+func parseDice(expr string) (count, sides, modifier int, err error) {
+	e, err := parseDiceExpr(expr)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return e.Count, e.Sides, e.Modifier, nil
+}
+
+// roll rolls the expression's dice, multiplying their number by diceMultiplier, and
+// sums the kept dice with the modifier without clamping
+func (e diceExpr) roll(expr string, diceMultiplier int) DiceRoll {
+	result := DiceRoll{Expression: expr, Rolls: []int{}, Modifier: e.Modifier}
+	for i := 0; i < e.Count*diceMultiplier; i++ {
+		result.Rolls = append(result.Rolls, rand.Intn(e.Sides)+1)
+	}
+
+	kept := result.Rolls
+	if e.Keep > 0 {
+		sorted := slices.Clone(result.Rolls)
+		slices.Sort(sorted)
+		if !e.KeepLowest {
+			slices.Reverse(sorted)
+		}
+		keep := e.Keep * diceMultiplier
+		kept, result.Dropped = sorted[:keep], sorted[keep:]
+	}
+	for _, r := range kept {
+		result.Total += r
+	}
+	result.Total += e.Modifier
+
+	return result
+}
+
+// rollDice rolls a dice expression, multiplying the number of dice by diceMultiplier
+// (use 1 for a normal roll, 2 for a critical hit)
+func rollDice(expr string, diceMultiplier int) (DiceRoll, error) {
+	e, err := parseDiceExpr(expr)
+	if err != nil {
+		return DiceRoll{}, err
+	}
+
+	result := e.roll(expr, diceMultiplier)
+	if result.Total < 0 {
+		result.Total = 0
+	}
+
+	return result, nil
+}
+
+// RollExpressionInput defines rolling a dice expression
+type RollExpressionInput struct {
+	Expression string `json:"expression" jsonschema:"Dice notation such as 2d8+5, 4d6kh3, or 1d20-1"`
+}
+
+type RollExpressionOutput struct {
+	Roll    DiceRoll `json:"roll"`
+	Message string   `json:"message"`
+}
+
+func handleRollDice(ctx context.Context, req *mcp.CallToolRequest, input RollExpressionInput) (*mcp.CallToolResult, RollExpressionOutput, error) {
+	e, err := parseDiceExpr(input.Expression)
+	if err != nil {
+		return nil, RollExpressionOutput{}, err
+	}
+
+	// Unlike damage, a general roll may legitimately total below zero
+	result := e.roll(input.Expression, 1)
+
+	message := fmt.Sprintf("Rolled %s: %v", input.Expression, result.Rolls)
+	if len(result.Dropped) > 0 {
+		message += fmt.Sprintf(", dropping %v", result.Dropped)
+	}
+	if result.Modifier != 0 {
+		message += fmt.Sprintf(", %+d", result.Modifier)
+	}
+	message += fmt.Sprintf(" = %d", result.Total)
+
+	return nil, RollExpressionOutput{Roll: result, Message: message}, nil
+}
+
+// averageDice returns the expected total of a dice expression, e.g. 2d6+3 averages 10
+func averageDice(expr string) (float64, error) {
+	e, err := parseDiceExpr(expr)
+	if err != nil {
+		return 0, err
+	}
+	if e.Keep > 0 {
+		return 0, fmt.Errorf("can't average keep expression %q", expr)
+	}
+	count, sides, modifier := e.Count, e.Sides, e.Modifier
+	return float64(count)*float64(sides+1)/2 + float64(modifier), nil
+}
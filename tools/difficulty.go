@@ -0,0 +1,221 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kiriyms/dungeon-master-mcp/resources"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// DifficultyThresholds are the XP an encounter must reach to count as each difficulty
+type DifficultyThresholds struct {
+	Easy   int `json:"easy"`
+	Medium int `json:"medium"`
+	Hard   int `json:"hard"`
+	Deadly int `json:"deadly"`
+}
+
+// add sums another character's thresholds into the party's
+func (t DifficultyThresholds) add(o DifficultyThresholds) DifficultyThresholds {
+	return DifficultyThresholds{t.Easy + o.Easy, t.Medium + o.Medium, t.Hard + o.Hard, t.Deadly + o.Deadly}
+}
+
+// rate names the difficulty an adjusted XP total reaches
+func (t DifficultyThresholds) rate(xp int) string {
+	switch {
+	case xp >= t.Deadly:
+		return "deadly"
+	case xp >= t.Hard:
+		return "hard"
+	case xp >= t.Medium:
+		return "medium"
+	case xp >= t.Easy:
+		return "easy"
+	default:
+		return "trivial"
+	}
+}
+
+// levelThresholds are the XP thresholds of one character, by character level
+var levelThresholds = [20]DifficultyThresholds{
+	{25, 50, 75, 100},
+	{50, 100, 150, 200},
+	{75, 150, 225, 400},
+	{125, 250, 375, 500},
+	{250, 500, 750, 1100},
+	{300, 600, 900, 1400},
+	{350, 750, 1100, 1700},
+	{450, 900, 1400, 2100},
+	{550, 1100, 1600, 2400},
+	{600, 1200, 1900, 2800},
+	{800, 1600, 2400, 3600},
+	{1000, 2000, 3000, 4500},
+	{1100, 2200, 3400, 5100},
+	{1250, 2500, 3800, 5700},
+	{1400, 2800, 4300, 6400},
+	{1600, 3200, 4800, 7200},
+	{2000, 3900, 5900, 8800},
+	{2100, 4200, 6300, 9500},
+	{2400, 4900, 7300, 10900},
+	{2800, 5700, 8500, 12700},
+}
+
+// encounterMultipliers scale the monsters' XP for how many there are; the party's
+// size shifts the encounter one step along the list
+var encounterMultipliers = []float64{0.5, 1, 1.5, 2, 2.5, 3, 4, 5}
+
+// encounterMultiplier returns the multiplier for a number of monsters facing a
+// party: fewer than three characters use the next multiplier up, six or more the
+// next one down
+func encounterMultiplier(monsters, partySize int) float64 {
+	step := 6
+	switch {
+	case monsters <= 1:
+		step = 1
+	case monsters == 2:
+		step = 2
+	case monsters <= 6:
+		step = 3
+	case monsters <= 10:
+		step = 4
+	case monsters <= 14:
+		step = 5
+	}
+	switch {
+	case partySize < 3:
+		step++
+	case partySize >= 6:
+		step--
+	}
+	return encounterMultipliers[step]
+}
+
+// EncounterMonster is one kind of monster in a planned encounter
+type EncounterMonster struct {
+	Name  string   `json:"name,omitempty" jsonschema:"Monster from the catalog, which supplies its CR"`
+	CR    *float64 `json:"cr,omitempty" jsonschema:"Challenge rating, for a monster not in the catalog (e.g. 0.25 for CR 1/4)"`
+	Count int      `json:"count,omitempty" jsonschema:"How many (defaults to 1)"`
+}
+
+// MonsterXP is what one kind of monster adds to an encounter's XP
+type MonsterXP struct {
+	Name    string  `json:"name"`
+	CR      float64 `json:"cr"`
+	XP      int     `json:"xp" jsonschema:"XP for one of the monster"`
+	Count   int     `json:"count"`
+	TotalXP int     `json:"total_xp"`
+}
+
+// CalculateEncounterDifficultyInput defines a planned encounter to rate
+type CalculateEncounterDifficultyInput struct {
+	PartyLevel  int                `json:"party_level,omitempty" jsonschema:"Level of every character, with party_size"`
+	PartySize   int                `json:"party_size,omitempty" jsonschema:"Number of characters, with party_level"`
+	PartyLevels []int              `json:"party_levels,omitempty" jsonschema:"Level of each character, for a mixed-level party"`
+	Party       string             `json:"party,omitempty" jsonschema:"Imported party to take the character levels from"`
+	Monsters    []EncounterMonster `json:"monsters"`
+}
+
+type CalculateEncounterDifficultyOutput struct {
+	Thresholds   DifficultyThresholds `json:"thresholds" jsonschema:"The party's XP thresholds"`
+	Monsters     []MonsterXP          `json:"monsters"`
+	BaseXP       int                  `json:"base_xp" jsonschema:"The monsters' XP before the multiplier; what the party earns"`
+	MonsterCount int                  `json:"monster_count"`
+	Multiplier   float64              `json:"multiplier" jsonschema:"Encounter multiplier for the number of monsters and the party's size"`
+	AdjustedXP   int                  `json:"adjusted_xp" jsonschema:"XP compared against the thresholds"`
+	Difficulty   string               `json:"difficulty" jsonschema:"trivial, easy, medium, hard, or deadly"`
+	Message      string               `json:"message"`
+}
+
+func handleCalculateEncounterDifficulty(ctx context.Context, req *mcp.CallToolRequest, input CalculateEncounterDifficultyInput) (*mcp.CallToolResult, CalculateEncounterDifficultyOutput, error) {
+	levels, err := input.partyLevels()
+	if err != nil {
+		return nil, CalculateEncounterDifficultyOutput{}, err
+	}
+	if len(input.Monsters) == 0 {
+		return nil, CalculateEncounterDifficultyOutput{}, fmt.Errorf("monsters is required")
+	}
+
+	output := CalculateEncounterDifficultyOutput{Monsters: []MonsterXP{}}
+	for _, level := range levels {
+		output.Thresholds = output.Thresholds.add(levelThresholds[level-1])
+	}
+
+	lineup := []string{}
+	for _, m := range input.Monsters {
+		entry, err := m.xp()
+		if err != nil {
+			return nil, CalculateEncounterDifficultyOutput{}, err
+		}
+		output.Monsters = append(output.Monsters, entry)
+		output.BaseXP += entry.TotalXP
+		output.MonsterCount += entry.Count
+		lineup = append(lineup, fmt.Sprintf("%dx %s (CR %s, %d XP)", entry.Count, entry.Name, resources.FormatCR(entry.CR), entry.XP))
+	}
+
+	output.Multiplier = encounterMultiplier(output.MonsterCount, len(levels))
+	output.AdjustedXP = int(float64(output.BaseXP) * output.Multiplier)
+	output.Difficulty = output.Thresholds.rate(output.AdjustedXP)
+
+	t := output.Thresholds
+	output.Message = fmt.Sprintf("%s: %d XP x%g for %d monsters = %d adjusted XP against a party of %d: %s (easy %d, medium %d, hard %d, deadly %d). The party earns %d XP.",
+		strings.Join(lineup, ", "), output.BaseXP, output.Multiplier, output.MonsterCount, output.AdjustedXP, len(levels),
+		output.Difficulty, t.Easy, t.Medium, t.Hard, t.Deadly, output.BaseXP)
+	return nil, output, nil
+}
+
+// partyLevels returns the level of each character in the party the input describes
+func (input CalculateEncounterDifficultyInput) partyLevels() ([]int, error) {
+	var levels []int
+	switch {
+	case input.Party != "":
+		roster, ok := parties[strings.ToLower(input.Party)]
+		if !ok {
+			return nil, fmt.Errorf("party not found: %s", input.Party)
+		}
+		for _, m := range roster {
+			levels = append(levels, m.Level)
+		}
+	case len(input.PartyLevels) > 0:
+		levels = input.PartyLevels
+	case input.PartyLevel > 0 && input.PartySize > 0:
+		for range input.PartySize {
+			levels = append(levels, input.PartyLevel)
+		}
+	default:
+		return nil, fmt.Errorf("give party_level and party_size, party_levels, or party")
+	}
+	for _, level := range levels {
+		if level < 1 || level > 20 {
+			return nil, fmt.Errorf("level must be between 1 and 20, got %d", level)
+		}
+	}
+	return levels, nil
+}
+
+// xp looks up the monster's challenge rating, from the catalog unless given, and
+// the XP it is worth
+func (m EncounterMonster) xp() (MonsterXP, error) {
+	entry := MonsterXP{Name: m.Name, Count: max(m.Count, 1)}
+	switch {
+	case m.CR != nil:
+		entry.CR = *m.CR
+	case m.Name != "":
+		monster, ok := resources.GetMonster(m.Name)
+		if !ok {
+			return MonsterXP{}, fmt.Errorf("monster not found: %s; give its cr instead", m.Name)
+		}
+		entry.Name, entry.CR = monster.Name, monster.ChallengeRating
+	default:
+		return MonsterXP{}, fmt.Errorf("each monster needs a name or a cr")
+	}
+	if entry.Name == "" {
+		entry.Name = "CR " + resources.FormatCR(entry.CR) + " monster"
+	}
+	if entry.XP = resources.XPForCR(entry.CR); entry.XP == 0 {
+		return MonsterXP{}, fmt.Errorf("no XP for challenge rating %g", entry.CR)
+	}
+	entry.TotalXP = entry.XP * entry.Count
+	return entry, nil
+}
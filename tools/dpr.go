@@ -0,0 +1,183 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/kiriyms/dungeon-master-mcp/resources"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// saveSuccessChance is the assumed chance a target succeeds on a save-for-half ability
+const saveSuccessChance = 0.5
+
+// multiattackPart matches phrases like "two with its claws" in a Multiattack description
+var multiattackPart = regexp.MustCompile(`(one|two|three|four|five|six|\d+) with its (\w+)`)
+
+var numberWords = map[string]int{"one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6}
+
+// ActionDamage is the expected damage of a single monster action or routine
+type ActionDamage struct {
+	Name           string  `json:"name"`
+	Kind           string  `json:"kind" jsonschema:"attack, save, or multiattack"`
+	DamageDice     string  `json:"damage_dice,omitempty"`
+	AverageDamage  float64 `json:"average_damage" jsonschema:"average damage on a hit or failed save"`
+	ExpectedDamage float64 `json:"expected_damage" jsonschema:"expected damage per use"`
+	Note           string  `json:"note,omitempty"`
+}
+
+// MonsterDPRInput defines a damage-per-round estimate
+type MonsterDPRInput struct {
+	MonsterName string `json:"monster_name" jsonschema:"Monster stat block name"`
+}
+
+type MonsterDPROutput struct {
+	MonsterName string         `json:"monster_name"`
+	DPR         float64        `json:"dpr" jsonschema:"expected damage per round using the best routine"`
+	BestRoutine string         `json:"best_routine"`
+	Actions     []ActionDamage `json:"actions"`
+	Assumptions []string       `json:"assumptions"`
+	Message     string         `json:"message"`
+}
+
+func handleMonsterDPR(ctx context.Context, req *mcp.CallToolRequest, input MonsterDPRInput) (*mcp.CallToolResult, MonsterDPROutput, error) {
+	monster, ok := resources.GetMonster(input.MonsterName)
+	if !ok {
+		return nil, MonsterDPROutput{}, fmt.Errorf("monster not found: %s", input.MonsterName)
+	}
+
+	output := MonsterDPROutput{
+		MonsterName: monster.Name,
+		Actions:     []ActionDamage{},
+		Assumptions: []string{
+			"Every attack roll hits",
+			fmt.Sprintf("Targets succeed on %.0f%% of saves and take half damage on a success", saveSuccessChance*100),
+			"Recharge and limited-use abilities are available this round",
+		},
+	}
+
+	// Expected damage of each damaging action on its own
+	byName := make(map[string]ActionDamage)
+	for _, action := range monster.Actions {
+		if action.DamageDice == "" {
+			continue
+		}
+		avg, err := averageDice(action.DamageDice)
+		if err != nil {
+			return nil, MonsterDPROutput{}, fmt.Errorf("%s: %w", action.Name, err)
+		}
+
+		entry := ActionDamage{
+			Name:           action.Name,
+			Kind:           "attack",
+			DamageDice:     action.DamageDice,
+			AverageDamage:  avg,
+			ExpectedDamage: avg,
+		}
+		if action.SaveDC > 0 && action.AttackBonus == 0 {
+			entry.Kind = "save"
+			entry.ExpectedDamage = avg * (1 - saveSuccessChance/2)
+			entry.Note = fmt.Sprintf("DC %d %s save for half", action.SaveDC, action.SaveType)
+		}
+
+		output.Actions = append(output.Actions, entry)
+		byName[strings.ToLower(action.Name)] = entry
+	}
+
+	// Compose the Multiattack routine from its description
+	for _, action := range monster.Actions {
+		if !strings.EqualFold(action.Name, "Multiattack") {
+			continue
+		}
+
+		routine := ActionDamage{Name: "Multiattack", Kind: "multiattack"}
+		parts := []string{}
+		for _, mp := range parseMultiattack(action.Description) {
+			part, ok := byName[mp.name]
+			if !ok {
+				continue
+			}
+			routine.AverageDamage += part.AverageDamage * float64(mp.count)
+			routine.ExpectedDamage += part.ExpectedDamage * float64(mp.count)
+			parts = append(parts, fmt.Sprintf("%d x %s", mp.count, part.Name))
+		}
+		if len(parts) == 0 {
+			continue
+		}
+		routine.Note = strings.Join(parts, ", ")
+		output.Actions = append(output.Actions, routine)
+	}
+
+	for _, entry := range output.Actions {
+		if entry.ExpectedDamage > output.DPR {
+			output.DPR = entry.ExpectedDamage
+			output.BestRoutine = entry.Name
+		}
+	}
+	output.DPR = math.Round(output.DPR*10) / 10
+
+	if output.BestRoutine == "" {
+		output.Message = fmt.Sprintf("%s has no actions with damage dice.", monster.Name)
+	} else {
+		output.Message = fmt.Sprintf("%s deals about %.1f damage per round using %s.", monster.Name, output.DPR, output.BestRoutine)
+	}
+
+	return nil, output, nil
+}
+
+// multiattackPortion is one "N with its X" clause of a Multiattack description
+type multiattackPortion struct {
+	count int
+	name  string // lowercase, singular action name
+}
+
+// parseMultiattack splits a Multiattack description into its attack clauses
+func parseMultiattack(description string) []multiattackPortion {
+	portions := []multiattackPortion{}
+	for _, match := range multiattackPart.FindAllStringSubmatch(strings.ToLower(description), -1) {
+		count, ok := numberWords[match[1]]
+		if !ok {
+			count, _ = strconv.Atoi(match[1])
+		}
+		portions = append(portions, multiattackPortion{count: count, name: strings.TrimSuffix(match[2], "s")})
+	}
+	return portions
+}
+
+// attackRoutine returns the attacks a monster makes with its action: its Multiattack
+// expanded into individual attacks if it has one, otherwise its first attack
+func attackRoutine(monster resources.MonsterStat) []resources.MonsterAction {
+	attacks := make(map[string]resources.MonsterAction)
+	var first *resources.MonsterAction
+	for i, action := range monster.Actions {
+		if !isAttack(action) {
+			continue
+		}
+		attacks[strings.ToLower(action.Name)] = action
+		if first == nil {
+			first = &monster.Actions[i]
+		}
+	}
+
+	routine := []resources.MonsterAction{}
+	for _, action := range monster.Actions {
+		if !strings.EqualFold(action.Name, "Multiattack") {
+			continue
+		}
+		for _, mp := range parseMultiattack(action.Description) {
+			if attack, ok := attacks[mp.name]; ok {
+				for range mp.count {
+					routine = append(routine, attack)
+				}
+			}
+		}
+	}
+	if len(routine) == 0 && first != nil {
+		routine = append(routine, *first)
+	}
+	return routine
+}
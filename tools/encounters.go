@@ -0,0 +1,191 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// defaultEncounterID names the encounter tools use until the DM switches to another
+const defaultEncounterID = "default"
+
+// encounter is one named combat with its own undo history
+type encounter struct {
+	State       *CombatState
+	UndoHistory []undoEntry
+}
+
+var (
+	// encounterMu serializes tool calls, which temporarily select the encounter they target
+	encounterMu       sync.Mutex
+	encounters        map[string]*encounter
+	activeEncounterID string // encounter used when a tool call doesn't name one
+	selectedID        string // encounter combatState and undoHistory currently belong to
+)
+
+// newCombatState returns an empty combat state with the maps tools write to initialized
+func newCombatState() *CombatState {
+	return &CombatState{
+		Entities:  make(map[string]*Entity),
+		TurnOrder: []string{},
+	}
+}
+
+// resetEncounters drops every encounter and starts over with an empty default one
+func resetEncounters() {
+	encounters = map[string]*encounter{defaultEncounterID: {State: newCombatState()}}
+	activeEncounterID = defaultEncounterID
+	selectedID = defaultEncounterID
+	combatState = encounters[defaultEncounterID].State
+	undoHistory = nil
+}
+
+// selectEncounter points combatState and undoHistory at the named encounter, creating
+// it empty if it doesn't exist yet
+func selectEncounter(id string) {
+	encounters[selectedID].UndoHistory = undoHistory
+	if encounters[id] == nil {
+		encounters[id] = &encounter{State: newCombatState()}
+	}
+	selectedID = id
+	combatState = encounters[id].State
+	undoHistory = encounters[id].UndoHistory
+}
+
+// EncounterScope is embedded in the input of every tool that works on an encounter
+type EncounterScope struct {
+	EncounterID string `json:"encounter_id,omitempty" jsonschema:"Encounter to act on (defaults to the active encounter, see switch_encounter)"`
+}
+
+func (s EncounterScope) encounterID() string {
+	return s.EncounterID
+}
+
+// encounterScoped is satisfied by tool inputs that embed EncounterScope
+type encounterScoped interface {
+	encounterID() string
+}
+
+// inEncounter wraps a tool handler so it runs against the encounter its input names,
+// or the active encounter when it names none. A named encounter that doesn't exist
+// is created for the call and dropped again if the call leaves it without a combat.
+func inEncounter[In encounterScoped, Out any](h mcp.ToolHandlerFor[In, Out]) mcp.ToolHandlerFor[In, Out] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input In) (*mcp.CallToolResult, Out, error) {
+		encounterMu.Lock()
+		defer encounterMu.Unlock()
+
+		id := input.encounterID()
+		if id == "" {
+			id = activeEncounterID
+		}
+		_, existed := encounters[id]
+		selectEncounter(id)
+		defer func() {
+			if !existed && !combatState.active() {
+				delete(encounters, id)
+				selectedID = activeEncounterID
+				combatState = encounters[activeEncounterID].State
+				undoHistory = encounters[activeEncounterID].UndoHistory
+				return
+			}
+			selectEncounter(activeEncounterID)
+		}()
+
+		return h(ctx, req, input)
+	}
+}
+
+// EncounterSummary describes one encounter for list_encounters
+type EncounterSummary struct {
+	ID              string `json:"id"`
+	Active          bool   `json:"active" jsonschema:"Whether tools act on this encounter when no encounter_id is given"`
+	Started         bool   `json:"started"`
+	RoundNumber     int    `json:"round_number,omitempty"`
+	Combatants      int    `json:"combatants"`
+	CurrentEntityID string `json:"current_entity_id,omitempty"`
+}
+
+// ListEncountersInput defines listing the encounters in progress
+type ListEncountersInput struct{}
+
+type ListEncountersOutput struct {
+	Encounters []EncounterSummary `json:"encounters"`
+	ActiveID   string             `json:"active_id"`
+	Message    string             `json:"message"`
+}
+
+func handleListEncounters(ctx context.Context, req *mcp.CallToolRequest, input ListEncountersInput) (*mcp.CallToolResult, ListEncountersOutput, error) {
+	encounterMu.Lock()
+	defer encounterMu.Unlock()
+
+	ids := make([]string, 0, len(encounters))
+	for id := range encounters {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	output := ListEncountersOutput{Encounters: []EncounterSummary{}, ActiveID: activeEncounterID}
+	for _, id := range ids {
+		cs := encounters[id].State
+		summary := EncounterSummary{
+			ID:         id,
+			Active:     id == activeEncounterID,
+			Started:    cs.active(),
+			Combatants: len(cs.Entities),
+		}
+		if summary.Started {
+			summary.RoundNumber = cs.RoundNumber
+			if cs.CurrentTurn < len(cs.TurnOrder) {
+				summary.CurrentEntityID = cs.TurnOrder[cs.CurrentTurn]
+			}
+		}
+		output.Encounters = append(output.Encounters, summary)
+	}
+	output.Message = fmt.Sprintf("%d encounters; %s is active.", len(output.Encounters), activeEncounterID)
+
+	return nil, output, nil
+}
+
+// SwitchEncounterInput defines choosing the encounter tools act on by default
+type SwitchEncounterInput struct {
+	EncounterID string `json:"encounter_id" jsonschema:"Encounter to make active; a new name creates an empty encounter for start_combat"`
+}
+
+type SwitchEncounterOutput struct {
+	ActiveID string `json:"active_id"`
+	Created  bool   `json:"created"`
+	Started  bool   `json:"started" jsonschema:"Whether the encounter already has a combat in progress"`
+	Message  string `json:"message"`
+}
+
+func handleSwitchEncounter(ctx context.Context, req *mcp.CallToolRequest, input SwitchEncounterInput) (*mcp.CallToolResult, SwitchEncounterOutput, error) {
+	if input.EncounterID == "" {
+		return nil, SwitchEncounterOutput{}, fmt.Errorf("encounter_id is required")
+	}
+
+	encounterMu.Lock()
+	defer encounterMu.Unlock()
+
+	_, existed := encounters[input.EncounterID]
+	selectEncounter(input.EncounterID)
+	activeEncounterID = input.EncounterID
+
+	output := SwitchEncounterOutput{
+		ActiveID: activeEncounterID,
+		Created:  !existed,
+		Started:  combatState.active(),
+	}
+	switch {
+	case output.Created:
+		output.Message = fmt.Sprintf("Created and switched to encounter %s; use start_combat to begin it.", activeEncounterID)
+	case output.Started:
+		output.Message = fmt.Sprintf("Switched to encounter %s: round %d, %d combatants.", activeEncounterID, combatState.RoundNumber, len(combatState.Entities))
+	default:
+		output.Message = fmt.Sprintf("Switched to encounter %s, which hasn't started.", activeEncounterID)
+	}
+
+	return nil, output, nil
+}
@@ -0,0 +1,104 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kiriyms/dungeon-master-mcp/resources"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Exhaustion levels at which each cumulative penalty starts
+const (
+	exhaustionCheckDisadvantage = 1 // disadvantage on ability checks
+	exhaustionHalfSpeed         = 2
+	exhaustionRollDisadvantage  = 3 // disadvantage on attack rolls and saving throws
+	exhaustionHalfMaxHP         = 4
+	exhaustionNoSpeed           = 5
+	exhaustionDeath             = 6
+)
+
+// exhaustionEffects lists the penalties in force at the entity's exhaustion level,
+// taken from the SRD condition definition
+func (e *Entity) exhaustionEffects() []string {
+	definition, _ := resources.GetCondition("exhaustion")
+	return definition.Effects[:min(e.ExhaustionLevel, len(definition.Effects))]
+}
+
+// setExhaustion moves the entity to an exhaustion level, halving or restoring its
+// hit point maximum as it crosses level 4 and killing it at level 6
+func (e *Entity) setExhaustion(level int) {
+	e.ExhaustionLevel = level
+
+	if level >= exhaustionHalfMaxHP && e.ExhaustionMaxHP == 0 {
+		e.ExhaustionMaxHP = e.MaxHP
+		e.MaxHP /= 2
+		e.CurrentHP = min(e.CurrentHP, e.MaxHP)
+	} else if level < exhaustionHalfMaxHP && e.ExhaustionMaxHP != 0 {
+		e.MaxHP = e.ExhaustionMaxHP
+		e.ExhaustionMaxHP = 0
+	}
+
+	if level >= exhaustionDeath {
+		e.CurrentHP = 0
+		e.Dead = true
+	}
+}
+
+// SetExhaustionInput defines changing an entity's exhaustion level
+type SetExhaustionInput struct {
+	EncounterScope
+	EntityID string `json:"entity_id"`
+	Level    *int   `json:"level,omitempty" jsonschema:"New exhaustion level, 0 to 6"`
+	Change   int    `json:"change,omitempty" jsonschema:"Levels to add (or remove, if negative) instead of setting the level"`
+}
+
+type SetExhaustionOutput struct {
+	Level   int      `json:"level"`
+	Effects []string `json:"effects" jsonschema:"Cumulative penalties at this level"`
+	MaxHP   int      `json:"max_hp"`
+	Dead    bool     `json:"dead"`
+	Message string   `json:"message"`
+}
+
+func handleSetExhaustion(ctx context.Context, req *mcp.CallToolRequest, input SetExhaustionInput) (*mcp.CallToolResult, SetExhaustionOutput, error) {
+	entity := combatState.Entities[input.EntityID]
+	if entity == nil {
+		return nil, SetExhaustionOutput{}, fmt.Errorf("entity not found: %s", input.EntityID)
+	}
+	if (input.Level == nil) == (input.Change == 0) {
+		return nil, SetExhaustionOutput{}, fmt.Errorf("give either level or change")
+	}
+
+	level := entity.ExhaustionLevel + input.Change
+	if input.Level != nil {
+		level = *input.Level
+	}
+	if level < 0 || level > exhaustionDeath {
+		return nil, SetExhaustionOutput{}, fmt.Errorf("exhaustion level must be between 0 and %d, got %d", exhaustionDeath, level)
+	}
+
+	before := entity.ExhaustionLevel
+	entity.setExhaustion(level)
+	if level != before {
+		combatState.logEvent("%s's exhaustion goes from level %d to %d", entity.Name, before, level)
+	}
+
+	output := SetExhaustionOutput{
+		Level:   level,
+		Effects: entity.exhaustionEffects(),
+		MaxHP:   entity.MaxHP,
+		Dead:    entity.Dead,
+	}
+	switch {
+	case level >= exhaustionDeath:
+		output.Message = fmt.Sprintf("%s reaches exhaustion level %d and dies.", entity.Name, level)
+	case level == 0:
+		output.Message = fmt.Sprintf("%s is no longer exhausted.", entity.Name)
+	default:
+		output.Message = fmt.Sprintf("%s is at exhaustion level %d: %s.", entity.Name, level, strings.Join(output.Effects, "; "))
+	}
+
+	return nil, output, nil
+}
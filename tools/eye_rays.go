@@ -0,0 +1,133 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// RayEffect is one save-based effect that can be randomly triggered, like a beholder eye ray
+type RayEffect struct {
+	Name              string `json:"name"`
+	SaveType          string `json:"save_type" jsonschema:"STR, DEX, CON, INT, WIS, CHA"`
+	DC                int    `json:"dc"`
+	DamageDice        string `json:"damage_dice,omitempty"`
+	DamageType        string `json:"damage_type,omitempty"`
+	HalfOnSuccess     bool   `json:"half_on_success,omitempty"`
+	Condition         string `json:"condition,omitempty" jsonschema:"Condition imposed on a failed save"`
+	ConditionDuration int    `json:"condition_duration,omitempty" jsonschema:"Condition duration in turns, -1 for permanent (defaults to 1)"`
+}
+
+// RayOutcome is the resolution of one triggered ray
+type RayOutcome struct {
+	Ray              string `json:"ray"`
+	SaveRoll         int    `json:"save_roll"`
+	SaveTotal        int    `json:"save_total"`
+	SaveSuccess      bool   `json:"save_success"`
+	Damage           int    `json:"damage"`
+	ConditionApplied string `json:"condition_applied,omitempty"`
+	Message          string `json:"message"`
+}
+
+// RandomEyeRaysInput defines firing randomly selected rays at a target
+type RandomEyeRaysInput struct {
+	EncounterScope
+	AttackerID string      `json:"attacker_id"`
+	TargetID   string      `json:"target_id"`
+	Rays       []RayEffect `json:"rays" jsonschema:"Possible ray effects to choose from"`
+	Count      int         `json:"count" jsonschema:"How many distinct rays fire (e.g. 3 for a beholder)"`
+}
+
+type RandomEyeRaysOutput struct {
+	Outcomes    []RayOutcome `json:"outcomes"`
+	RemainingHP int          `json:"remaining_hp"`
+	Message     string       `json:"message"`
+}
+
+func handleRandomEyeRays(ctx context.Context, req *mcp.CallToolRequest, input RandomEyeRaysInput) (*mcp.CallToolResult, RandomEyeRaysOutput, error) {
+	attacker := combatState.Entities[input.AttackerID]
+	if attacker == nil {
+		return nil, RandomEyeRaysOutput{}, fmt.Errorf("attacker not found: %s", input.AttackerID)
+	}
+	target := combatState.Entities[input.TargetID]
+	if target == nil {
+		return nil, RandomEyeRaysOutput{}, fmt.Errorf("target not found: %s", input.TargetID)
+	}
+	if input.Count < 1 || input.Count > len(input.Rays) {
+		return nil, RandomEyeRaysOutput{}, fmt.Errorf("count must be between 1 and the number of rays (%d)", len(input.Rays))
+	}
+	for _, ray := range input.Rays {
+		if ray.DC <= 0 || ray.SaveType == "" {
+			return nil, RandomEyeRaysOutput{}, fmt.Errorf("ray %s needs a save type and DC", ray.Name)
+		}
+		if ray.DamageDice != "" {
+			if _, _, _, err := parseDice(ray.DamageDice); err != nil {
+				return nil, RandomEyeRaysOutput{}, fmt.Errorf("ray %s: %w", ray.Name, err)
+			}
+		}
+	}
+
+	// Draw distinct rays by rolling on a shrinking table
+	available := slices.Clone(input.Rays)
+	chosen := []RayEffect{}
+	for range input.Count {
+		entries := make([]TableEntry, len(available))
+		for i, ray := range available {
+			entries[i] = TableEntry{Result: ray.Name}
+		}
+		index, _, err := rollOnTable(entries)
+		if err != nil {
+			return nil, RandomEyeRaysOutput{}, err
+		}
+		chosen = append(chosen, available[index])
+		available = slices.Delete(available, index, index+1)
+	}
+
+	output := RandomEyeRaysOutput{Outcomes: []RayOutcome{}}
+	names := []string{}
+	for _, ray := range chosen {
+		names = append(names, ray.Name)
+		saveType := strings.ToUpper(ray.SaveType)
+		save := rollSavingThrow(target, saveType, ray.DC)
+		outcome := RayOutcome{
+			Ray:         ray.Name,
+			SaveRoll:    save.Roll,
+			SaveTotal:   save.Total,
+			SaveSuccess: save.Success,
+			Message:     fmt.Sprintf("%s (%s save): %s", ray.Name, saveType, save.describe(target, ray.DC)),
+		}
+
+		if ray.DamageDice != "" && (!save.Success || ray.HalfOnSuccess) {
+			roll, err := rollDice(ray.DamageDice, 1)
+			if err != nil {
+				return nil, RandomEyeRaysOutput{}, err
+			}
+			damage := roll.Total
+			if save.Success {
+				damage /= 2
+			}
+			dealt, modifier := applyDamage(target, damage, ray.DamageType)
+			attacker.DamageDealt += dealt
+			outcome.Damage = dealt
+			outcome.Message += fmt.Sprintf("; %d %s damage%s", dealt, ray.DamageType, modifier)
+		}
+		if ray.Condition != "" && !save.Success {
+			duration := ray.ConditionDuration
+			if duration == 0 {
+				duration = 1
+			}
+			target.Conditions[ray.Condition] = duration
+			target.setConditionSource(ray.Condition, attacker.ID)
+			outcome.ConditionApplied = ray.Condition
+			outcome.Message += fmt.Sprintf("; %s is now %s", target.Name, ray.Condition)
+		}
+		output.Outcomes = append(output.Outcomes, outcome)
+	}
+
+	output.RemainingHP = target.CurrentHP
+	output.Message = fmt.Sprintf("%s fires %s at %s. %d HP remaining.", attacker.Name, strings.Join(names, ", "), target.Name, target.CurrentHP)
+	return nil, output, nil
+}
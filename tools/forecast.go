@@ -0,0 +1,70 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// assumedCharacterDPR is the damage per round credited to each standing character
+// when the DM doesn't supply the party's damage output
+const assumedCharacterDPR = 10.0
+
+// CombatForecastInput defines a combat length estimate
+type CombatForecastInput struct {
+	EncounterScope
+	PartyDPR *float64 `json:"party_dpr,omitempty" jsonschema:"Party's average damage per round (inferred from standing characters if omitted)"`
+}
+
+type CombatForecastOutput struct {
+	EnemyHP         int      `json:"enemy_hp" jsonschema:"Total remaining HP of monsters still standing"`
+	PartyDPR        float64  `json:"party_dpr"`
+	EstimatedRounds int      `json:"estimated_rounds" jsonschema:"Rough estimate of rounds remaining, not a guarantee"`
+	Assumptions     []string `json:"assumptions"`
+	Message         string   `json:"message"`
+}
+
+func handleCombatForecast(ctx context.Context, req *mcp.CallToolRequest, input CombatForecastInput) (*mcp.CallToolResult, CombatForecastOutput, error) {
+	output := CombatForecastOutput{Assumptions: []string{
+		"Estimate only: dice variance, healing, and tactics can change the outcome",
+		"All party damage lands on enemies with no overkill",
+	}}
+
+	standing := 0
+	for _, e := range combatState.Entities {
+		if e.CurrentHP <= 0 {
+			continue
+		}
+		if e.IsMonster {
+			output.EnemyHP += e.CurrentHP
+		} else {
+			standing++
+		}
+	}
+
+	if input.PartyDPR != nil {
+		if *input.PartyDPR <= 0 {
+			return nil, CombatForecastOutput{}, fmt.Errorf("party DPR must be positive")
+		}
+		output.PartyDPR = *input.PartyDPR
+	} else {
+		output.PartyDPR = float64(standing) * assumedCharacterDPR
+		output.Assumptions = append(output.Assumptions,
+			fmt.Sprintf("Party DPR inferred as %.0f per standing character (%d standing)", assumedCharacterDPR, standing))
+	}
+
+	switch {
+	case output.EnemyHP == 0:
+		output.Message = "No enemies are left standing; combat is effectively over."
+	case output.PartyDPR == 0:
+		output.Message = "No party members are standing to deal damage; the party cannot end this fight by damage."
+	default:
+		output.EstimatedRounds = int(math.Ceil(float64(output.EnemyHP) / output.PartyDPR))
+		output.Message = fmt.Sprintf("Estimate: about %d more rounds (%d enemy HP at %.1f party damage per round).",
+			output.EstimatedRounds, output.EnemyHP, output.PartyDPR)
+	}
+
+	return nil, output, nil
+}
@@ -0,0 +1,90 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// EscapeGrappleInput defines an attempt to escape a grapple
+type EscapeGrappleInput struct {
+	EncounterScope
+	EntityID string `json:"entity_id" jsonschema:"Grappled creature"`
+	Skill    string `json:"skill,omitempty" jsonschema:"athletics or acrobatics (defaults to whichever bonus is higher)"`
+	DC       int    `json:"dc,omitempty" jsonschema:"Escape DC override, e.g. from a stat block (defaults to 8 + grappler's STR mod + proficiency)"`
+}
+
+type EscapeGrappleOutput struct {
+	Skill      string `json:"skill"`
+	Roll       int    `json:"roll"`
+	Bonus      int    `json:"bonus"`
+	Total      int    `json:"total"`
+	DC         int    `json:"dc"`
+	Escaped    bool   `json:"escaped"`
+	GrapplerID string `json:"grappler_id,omitempty"`
+	Message    string `json:"message"`
+}
+
+func handleEscapeGrapple(ctx context.Context, req *mcp.CallToolRequest, input EscapeGrappleInput) (*mcp.CallToolResult, EscapeGrappleOutput, error) {
+	entity := combatState.Entities[input.EntityID]
+	if entity == nil {
+		return nil, EscapeGrappleOutput{}, fmt.Errorf("entity not found: %s", input.EntityID)
+	}
+	if _, ok := entity.Conditions["grappled"]; !ok {
+		return nil, EscapeGrappleOutput{}, fmt.Errorf("%s is not grappled", entity.Name)
+	}
+
+	grappler := combatState.Entities[entity.ConditionSources["grappled"]]
+	dc := input.DC
+	if dc == 0 {
+		if grappler == nil {
+			return nil, EscapeGrappleOutput{}, fmt.Errorf("%s's grappler is unknown; provide the escape DC", entity.Name)
+		}
+		dc = 8 + abilityModifier(grappler, "STR") + grappler.ProficiencyBonus
+	}
+
+	skill := strings.ToLower(input.Skill)
+	switch skill {
+	case "":
+		skill = "athletics"
+		if skillBonus(entity, "acrobatics") > skillBonus(entity, "athletics") {
+			skill = "acrobatics"
+		}
+	case "athletics", "acrobatics":
+	default:
+		return nil, EscapeGrappleOutput{}, fmt.Errorf("a grapple is escaped with athletics or acrobatics, not %s", input.Skill)
+	}
+
+	output := EscapeGrappleOutput{
+		Skill: skill,
+		Bonus: skillBonus(entity, skill),
+		DC:    dc,
+	}
+	modifiers := entity.checkModifiers()
+	roll, rolls, mode := modifiers.roll()
+	output.Roll = roll
+	output.Total = output.Roll + output.Bonus
+	output.Escaped = output.Total >= dc
+
+	from := "the grapple"
+	if grappler != nil {
+		output.GrapplerID = grappler.ID
+		from = grappler.Name + "'s grapple"
+	}
+	output.Message = fmt.Sprintf("%s rolls %s %d+%d=%d vs DC %d", entity.Name, skill, output.Roll, output.Bonus, output.Total, dc)
+	if mode != "" {
+		output.Message += fmt.Sprintf(" (%s, rolled %v)", modifiers.describe(), rolls)
+	}
+	if output.Escaped {
+		delete(entity.Conditions, "grappled")
+		delete(entity.ConditionSources, "grappled")
+		output.Message += fmt.Sprintf(" and escapes %s.", from)
+		combatState.logEvent("%s escapes %s", entity.Name, from)
+	} else {
+		output.Message += fmt.Sprintf(" and remains held by %s.", from)
+	}
+
+	return nil, output, nil
+}
@@ -0,0 +1,138 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// actingGroup returns the entities that take the current turn: the current entity
+// and the group mates that follow it in the initiative order
+func (cs *CombatState) actingGroup() []*Entity {
+	return cs.groupAt(cs.CurrentTurn)
+}
+
+// groupAt returns the entity at index i of the turn order and the members of its
+// group immediately after it, who share its initiative slot
+func (cs *CombatState) groupAt(i int) []*Entity {
+	first := cs.Entities[cs.TurnOrder[i]]
+	group := []*Entity{first}
+	if first.GroupID == "" {
+		return group
+	}
+	for _, id := range cs.TurnOrder[i+1:] {
+		e := cs.Entities[id]
+		if e.GroupID != first.GroupID {
+			break
+		}
+		group = append(group, e)
+	}
+	return group
+}
+
+// groupStart returns the index where the group slot holding index i of the turn order begins
+func (cs *CombatState) groupStart(i int) int {
+	for i > 0 {
+		e, previous := cs.Entities[cs.TurnOrder[i]], cs.Entities[cs.TurnOrder[i-1]]
+		if e.GroupID == "" || e.GroupID != previous.GroupID {
+			break
+		}
+		i--
+	}
+	return i
+}
+
+// groupInitiative returns the initiative of an existing group, if any member is in combat
+func (cs *CombatState) groupInitiative(groupID string) (int, bool) {
+	for _, id := range cs.TurnOrder {
+		if e := cs.Entities[id]; e.GroupID == groupID {
+			return e.InitiativeRoll, true
+		}
+	}
+	return 0, false
+}
+
+// shareGroupInitiative gives every grouped entity the initiative of the first member
+// listed, dropping the rolls later members no longer use
+func (cs *CombatState) shareGroupInitiative(entities []EntityInit, rolled map[string]int) {
+	leaders := make(map[string]*Entity)
+	for _, init := range entities {
+		if init.GroupID == "" {
+			continue
+		}
+		e := cs.Entities[init.ID]
+		if leader, ok := leaders[init.GroupID]; ok {
+			e.InitiativeRoll = leader.InitiativeRoll
+			delete(rolled, e.ID)
+		} else {
+			leaders[init.GroupID] = e
+		}
+	}
+}
+
+// ListGroupInput defines looking up the members of an initiative group
+type ListGroupInput struct {
+	EncounterScope
+	GroupID string `json:"group_id,omitempty" jsonschema:"Group to list (defaults to the group whose turn it is)"`
+}
+
+type ListGroupOutput struct {
+	GroupID    string         `json:"group_id"`
+	Initiative int            `json:"initiative"`
+	Acting     bool           `json:"acting" jsonschema:"Whether it is the group's turn"`
+	Members    []EntityStatus `json:"members" jsonschema:"Every member, in initiative order"`
+	Standing   int            `json:"standing" jsonschema:"Members above 0 HP"`
+	Message    string         `json:"message"`
+}
+
+func handleListGroup(ctx context.Context, req *mcp.CallToolRequest, input ListGroupInput) (*mcp.CallToolResult, ListGroupOutput, error) {
+	groupID := input.GroupID
+	if groupID == "" {
+		if combatState.CurrentTurn < len(combatState.TurnOrder) {
+			groupID = combatState.Entities[combatState.TurnOrder[combatState.CurrentTurn]].GroupID
+		}
+		if groupID == "" {
+			return nil, ListGroupOutput{}, fmt.Errorf("the acting creature isn't in a group; give group_id")
+		}
+	}
+
+	output := ListGroupOutput{GroupID: groupID, Members: []EntityStatus{}}
+	for _, member := range combatState.actingGroup() {
+		if member.GroupID == groupID {
+			output.Acting = true
+		}
+	}
+	summary := []string{}
+	for _, id := range combatState.TurnOrder {
+		e := combatState.Entities[id]
+		if e.GroupID != groupID {
+			continue
+		}
+		output.Initiative = e.InitiativeRoll
+		output.Members = append(output.Members, e.status())
+		status := fmt.Sprintf("%s %d/%d", e.Name, e.CurrentHP, e.MaxHP)
+		switch {
+		case e.Dead:
+			status += " (dead)"
+		case e.CurrentHP > 0:
+			output.Standing++
+		}
+		if conditions := sortedKeys(e.Conditions); len(conditions) > 0 {
+			status += fmt.Sprintf(" [%s]", strings.Join(conditions, ", "))
+		}
+		summary = append(summary, status)
+	}
+	if len(output.Members) == 0 {
+		return nil, ListGroupOutput{}, fmt.Errorf("group not found: %s", groupID)
+	}
+
+	turn := ""
+	if output.Acting {
+		turn = ", acting now"
+	}
+	output.Message = fmt.Sprintf("Group %s (initiative %d%s), %d of %d standing: %s.",
+		groupID, output.Initiative, turn, output.Standing, len(output.Members), strings.Join(summary, ", "))
+	return nil, output, nil
+}
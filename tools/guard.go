@@ -0,0 +1,28 @@
+package tools
+
+import (
+	"context"
+	"errors"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// errCombatNotStarted is returned by tools that need an encounter when there is none
+var errCombatNotStarted = errors.New("combat not started; use start_combat or load a saved combat first")
+
+// active reports whether an encounter is in progress
+func (cs *CombatState) active() bool {
+	return cs != nil && len(cs.TurnOrder) > 0
+}
+
+// requiresCombat wraps a tool handler that only makes sense during an encounter, so
+// calling it before start_combat fails cleanly instead of indexing an empty turn order
+func requiresCombat[In, Out any](h mcp.ToolHandlerFor[In, Out]) mcp.ToolHandlerFor[In, Out] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input In) (*mcp.CallToolResult, Out, error) {
+		if !combatState.active() {
+			var zero Out
+			return nil, zero, errCombatNotStarted
+		}
+		return h(ctx, req, input)
+	}
+}
@@ -0,0 +1,195 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// worksWithoutCombat lists the tools that aren't wrapped in requiresCombat, because
+// they start or load an encounter, pass time outside combat, or don't touch one at all
+var worksWithoutCombat = map[string]bool{
+	"start_combat":                   true,
+	"load_snapshot":                  true,
+	"load_combat":                    true,
+	"undo_last_action":               true,
+	"list_encounters":                true,
+	"switch_encounter":               true,
+	"import_party":                   true,
+	"monster_dpr":                    true,
+	"random_monster":                 true,
+	"roll_table":                     true,
+	"get_condition":                  true,
+	"monster_saves":                  true,
+	"roll_dice":                      true,
+	"calculate_encounter_difficulty": true,
+	"advance_time":                   true,
+}
+
+// connectTools serves the combat tools over an in-memory transport and returns a
+// client session connected to them
+func connectTools(t *testing.T) *mcp.ClientSession {
+	t.Helper()
+	ctx := context.Background()
+	server := mcp.NewServer(&mcp.Implementation{Name: "test-server"}, nil)
+	RegisterCombatTools(server)
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	if _, err := server.Connect(ctx, serverTransport, nil); err != nil {
+		t.Fatalf("connect server: %v", err)
+	}
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, nil).Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("connect client: %v", err)
+	}
+	t.Cleanup(func() { session.Close() })
+	return session
+}
+
+// requiredArgs builds the smallest arguments a tool's input schema accepts: a zero
+// value for every required property
+func requiredArgs(schema map[string]any) map[string]any {
+	args := map[string]any{}
+	properties, _ := schema["properties"].(map[string]any)
+	required, _ := schema["required"].([]any)
+	for _, name := range required {
+		property, _ := properties[name.(string)].(map[string]any)
+		args[name.(string)] = zeroValue(property)
+	}
+	return args
+}
+
+// zeroValue returns a value of the schema's type, taking the first choice of an enum
+func zeroValue(schema map[string]any) any {
+	if choices, ok := schema["enum"].([]any); ok && len(choices) > 0 {
+		return choices[0]
+	}
+	schemaType, _ := schema["type"].(string)
+	if types, ok := schema["type"].([]any); ok {
+		for _, t := range types {
+			if t != "null" {
+				schemaType = t.(string)
+				break
+			}
+		}
+	}
+	switch schemaType {
+	case "string":
+		return ""
+	case "integer", "number":
+		return 0
+	case "boolean":
+		return false
+	case "array":
+		return []any{}
+	case "object":
+		return requiredArgs(schema)
+	}
+	return nil
+}
+
+func TestToolsRequireCombat(t *testing.T) {
+	resetEncounters()
+	t.Cleanup(resetEncounters)
+	session := connectTools(t)
+	ctx := context.Background()
+
+	listed, err := session.ListTools(ctx, nil)
+	if err != nil {
+		t.Fatalf("list tools: %v", err)
+	}
+
+	guarded := 0
+	for _, tool := range listed.Tools {
+		if worksWithoutCombat[tool.Name] {
+			continue
+		}
+		guarded++
+		t.Run(tool.Name, func(t *testing.T) {
+			schema, _ := tool.InputSchema.(map[string]any)
+			result, err := session.CallTool(ctx, &mcp.CallToolParams{
+				Name:      tool.Name,
+				Arguments: requiredArgs(schema),
+			})
+			if err != nil {
+				t.Fatalf("call failed before reaching the handler: %v", err)
+			}
+			if !result.IsError {
+				t.Fatalf("succeeded with no combat started")
+			}
+			text := result.Content[0].(*mcp.TextContent).Text
+			if text != errCombatNotStarted.Error() {
+				t.Errorf("got error %q, want %q", text, errCombatNotStarted.Error())
+			}
+		})
+	}
+
+	if guarded == 0 {
+		t.Fatal("no guarded tools were registered")
+	}
+	if combatState.active() {
+		t.Error("a guarded tool started an encounter")
+	}
+}
+
+func TestReferenceLookupsWorkWithoutCombat(t *testing.T) {
+	resetEncounters()
+	t.Cleanup(resetEncounters)
+	session := connectTools(t)
+
+	for _, call := range []*mcp.CallToolParams{
+		{Name: "get_condition", Arguments: map[string]any{"name": "restrained"}},
+		{Name: "monster_saves", Arguments: map[string]any{"monster_name": "Goblin"}},
+	} {
+		result, err := session.CallTool(context.Background(), call)
+		if err != nil || result.IsError {
+			t.Errorf("%s before start_combat failed: %v %v", call.Name, err, result)
+		}
+	}
+	if len(undoHistory) != 0 {
+		t.Errorf("reference lookups left %d undo entries", len(undoHistory))
+	}
+}
+
+func TestAdvanceTimeWorksWithoutCombat(t *testing.T) {
+	resetEncounters()
+	t.Cleanup(resetEncounters)
+	session := connectTools(t)
+
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: "advance_time", Arguments: map[string]any{"minutes": 10}})
+	if err != nil || result.IsError {
+		t.Errorf("advance_time before start_combat failed: %v %v", err, result)
+	}
+}
+
+func TestReadOnlyToolsAreNotUndoable(t *testing.T) {
+	t.Cleanup(resetEncounters)
+	session := connectTools(t)
+	ctx := context.Background()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name: "start_combat",
+		Arguments: map[string]any{"entities": []map[string]any{
+			{"id": "fighter", "name": "Fighter", "initiative": 15, "hp": 30, "ac": 16, "is_monster": false},
+			{"id": "orc", "name": "Orc", "initiative": 10, "hp": 15, "ac": 13, "is_monster": true},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("start_combat: %v", err)
+	}
+	undoable := len(undoHistory)
+
+	for _, call := range []*mcp.CallToolParams{
+		{Name: "legendary_opportunity", Arguments: map[string]any{}},
+		{Name: "contested_roll", Arguments: map[string]any{"initiator_id": "fighter", "initiator_check": "athletics", "defender_id": "orc", "defender_check": "acrobatics"}},
+	} {
+		result, err := session.CallTool(ctx, call)
+		if err != nil || result.IsError {
+			t.Fatalf("%s failed: %v %v", call.Name, err, result)
+		}
+	}
+	if len(undoHistory) != undoable {
+		t.Errorf("read-only tools added %d undo entries", len(undoHistory)-undoable)
+	}
+}
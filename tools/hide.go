@@ -0,0 +1,185 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kiriyms/dungeon-master-mcp/resources"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// passivePerception is 10 plus the entity's Perception bonus
+func passivePerception(e *Entity) int {
+	return passiveScore(e, "perception", rollModifiers{})
+}
+
+// HideInput defines a Hide action
+type HideInput struct {
+	EncounterScope
+	EntityID    string   `json:"entity_id"`
+	ObserverIDs []string `json:"observer_ids,omitempty" jsonschema:"Creatures that could spot the hider (defaults to every standing creature on the other side)"`
+}
+
+// Observer is one creature's passive Perception against a Stealth check
+type Observer struct {
+	EntityID          string `json:"entity_id"`
+	PassivePerception int    `json:"passive_perception"`
+	SpottedHider      bool   `json:"spotted_hider"`
+}
+
+type HideOutput struct {
+	StealthRoll  int        `json:"stealth_roll" jsonschema:"natural d20 result"`
+	StealthBonus int        `json:"stealth_bonus"`
+	StealthTotal int        `json:"stealth_total"`
+	Hidden       bool       `json:"hidden"`
+	Observers    []Observer `json:"observers"`
+	Message      string     `json:"message"`
+}
+
+func handleHide(ctx context.Context, req *mcp.CallToolRequest, input HideInput) (*mcp.CallToolResult, HideOutput, error) {
+	hider := combatState.Entities[input.EntityID]
+	if hider == nil {
+		return nil, HideOutput{}, fmt.Errorf("entity not found: %s", input.EntityID)
+	}
+	if hider.IsIncapacitated() {
+		return nil, HideOutput{}, fmt.Errorf("%s is incapacitated and can't take actions", hider.Name)
+	}
+
+	observers := []*Entity{}
+	if len(input.ObserverIDs) > 0 {
+		for _, id := range input.ObserverIDs {
+			observer := combatState.Entities[id]
+			if observer == nil {
+				return nil, HideOutput{}, fmt.Errorf("observer not found: %s", id)
+			}
+			observers = append(observers, observer)
+		}
+	} else {
+		for _, e := range combatState.Entities {
+			if e.IsMonster != hider.IsMonster && e.CurrentHP > 0 {
+				observers = append(observers, e)
+			}
+		}
+		slices.SortFunc(observers, func(a, b *Entity) int { return strings.Compare(a.ID, b.ID) })
+	}
+
+	modifiers := hider.checkModifiers()
+	roll, rolls, mode := modifiers.roll()
+	bonus := skillBonus(hider, "stealth")
+	output := HideOutput{
+		StealthRoll:  roll,
+		StealthBonus: bonus,
+		StealthTotal: roll + bonus,
+		Observers:    []Observer{},
+	}
+
+	spotters := []string{}
+	for _, observer := range observers {
+		// An observer that can't perceive anything doesn't spot the hider
+		passive := passivePerception(observer)
+		spotted := !observer.IsIncapacitated() && passive > output.StealthTotal
+		output.Observers = append(output.Observers, Observer{
+			EntityID:          observer.ID,
+			PassivePerception: passive,
+			SpottedHider:      spotted,
+		})
+		if spotted {
+			spotters = append(spotters, fmt.Sprintf("%s (passive %d)", observer.Name, passive))
+		}
+	}
+
+	output.Hidden = len(spotters) == 0
+	hider.Hidden = output.Hidden
+
+	check := fmt.Sprintf("%s rolls Stealth %d+%d=%d", hider.Name, roll, bonus, output.StealthTotal)
+	if mode != "" {
+		check += fmt.Sprintf(" (%s, rolled %v)", modifiers.describe(), rolls)
+	}
+	if output.Hidden {
+		output.Message = fmt.Sprintf("%s and is hidden from %d observers.", check, len(observers))
+		combatState.logEvent("%s hides (Stealth %d)", hider.Name, output.StealthTotal)
+	} else {
+		output.Message = fmt.Sprintf("%s but is spotted by %s.", check, strings.Join(spotters, ", "))
+	}
+
+	return nil, output, nil
+}
+
+// AttackRollInput defines a single attack roll
+type AttackRollInput struct {
+	EncounterScope
+	AttackerID   string `json:"attacker_id"`
+	TargetID     string `json:"target_id"`
+	ActionName   string `json:"action_name,omitempty" jsonschema:"Stat block attack to use (defaults to the first attack); ignored when damage_dice is given"`
+	AttackBonus  int    `json:"attack_bonus,omitempty" jsonschema:"Attack bonus for an attack without a stat block, e.g. a character's weapon"`
+	DamageDice   string `json:"damage_dice,omitempty" jsonschema:"Damage dice for an attack without a stat block, e.g. 1d8+3"`
+	DamageType   string `json:"damage_type,omitempty"`
+	Advantage    bool   `json:"advantage,omitempty" jsonschema:"Other sources of advantage"`
+	Disadvantage bool   `json:"disadvantage,omitempty" jsonschema:"Other sources of disadvantage"`
+}
+
+type AttackRollOutput struct {
+	Attack      AttackResult `json:"attack"`
+	RemainingHP int          `json:"remaining_hp"`
+	Message     string       `json:"message"`
+}
+
+func handleAttackRoll(ctx context.Context, req *mcp.CallToolRequest, input AttackRollInput) (*mcp.CallToolResult, AttackRollOutput, error) {
+	attacker := combatState.Entities[input.AttackerID]
+	if attacker == nil {
+		return nil, AttackRollOutput{}, fmt.Errorf("attacker not found: %s", input.AttackerID)
+	}
+	target := combatState.Entities[input.TargetID]
+	if target == nil {
+		return nil, AttackRollOutput{}, fmt.Errorf("target not found: %s", input.TargetID)
+	}
+	if attacker.IsIncapacitated() {
+		return nil, AttackRollOutput{}, fmt.Errorf("%s is incapacitated and can't take actions", attacker.Name)
+	}
+
+	var action resources.MonsterAction
+	if input.DamageDice != "" {
+		if _, _, _, err := parseDice(input.DamageDice); err != nil {
+			return nil, AttackRollOutput{}, err
+		}
+		name := input.ActionName
+		if name == "" {
+			name = "attack"
+		}
+		action = resources.MonsterAction{
+			Name:        name,
+			AttackBonus: input.AttackBonus,
+			DamageDice:  input.DamageDice,
+			DamageType:  input.DamageType,
+		}
+	} else {
+		var err error
+		if action, err = attackAction(attacker, input.ActionName); err != nil {
+			return nil, AttackRollOutput{}, err
+		}
+	}
+
+	result, err := resolveAttackWith(attacker, target, action, attackOptions{
+		Advantage:    input.Advantage,
+		Disadvantage: input.Disadvantage,
+	})
+	if err != nil {
+		return nil, AttackRollOutput{}, err
+	}
+
+	message := result.Message + "."
+	if target.Hidden {
+		message += fmt.Sprintf(" %s is hidden, so the attack had disadvantage.", target.Name)
+	}
+	if result.Revealed {
+		combatState.logEvent("%s attacks from hiding and is revealed", attacker.Name)
+	}
+
+	return nil, AttackRollOutput{
+		Attack:      result,
+		RemainingHP: target.CurrentHP,
+		Message:     message,
+	}, nil
+}
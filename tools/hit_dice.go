@@ -0,0 +1,56 @@
+package tools
+
+import (
+	"fmt"
+
+	"github.com/kiriyms/dungeon-master-mcp/resources"
+)
+
+// How a monster's hit points are set when it joins combat
+const (
+	hpModeFixed   = "fixed"   // the hp given for the entity
+	hpModeAverage = "average" // the average of the stat block's hit dice
+	hpModeRoll    = "roll"    // rolled from the stat block's hit dice
+)
+
+// checkHPMode reports an hp_mode that can't be applied to the entity, before any
+// combat state changes
+func (e EntityInit) checkHPMode() error {
+	switch e.HPMode {
+	case "", hpModeFixed:
+		return nil
+	case hpModeAverage, hpModeRoll:
+	default:
+		return fmt.Errorf("%s: unknown hp_mode %q (use fixed, average, or roll)", e.ID, e.HPMode)
+	}
+
+	monster, ok := resources.GetMonster(e.MonsterName)
+	if !e.IsMonster || !ok {
+		return fmt.Errorf("%s: hp_mode %s needs a monster with a stat block", e.ID, e.HPMode)
+	}
+	if monster.HitDice == "" {
+		return fmt.Errorf("%s: the %s stat block has no hit dice", e.ID, monster.Name)
+	}
+	if _, _, _, err := parseDice(monster.HitDice); err != nil {
+		return fmt.Errorf("%s: %s hit dice: %w", e.ID, monster.Name, err)
+	}
+	return nil
+}
+
+// hitDiceHP returns hit points from a hit dice formula such as "28d20+252", either
+// its average or a roll, and never less than 1
+func hitDiceHP(hitDice, mode string) (int, error) {
+	count, sides, modifier, err := parseDice(hitDice)
+	if err != nil {
+		return 0, err
+	}
+	hp := count*(sides+1)/2 + modifier
+	if mode == hpModeRoll {
+		roll, err := rollDice(hitDice, 1)
+		if err != nil {
+			return 0, err
+		}
+		hp = roll.Total
+	}
+	return max(hp, 1), nil
+}
@@ -0,0 +1,91 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// GrantImmunityInput defines a temporary damage immunity
+type GrantImmunityInput struct {
+	EncounterScope
+	TargetID   string `json:"target_id"`
+	DamageType string `json:"damage_type" jsonschema:"Damage type the target becomes immune to"`
+	Duration   int    `json:"duration,omitempty" jsonschema:"Rounds the immunity lasts, -1 for until revoked (defaults to -1)"`
+	Revoke     bool   `json:"revoke,omitempty" jsonschema:"Remove the temporary immunity instead of granting it"`
+}
+
+type GrantImmunityOutput struct {
+	TempImmunities []string `json:"temp_immunities"`
+	Message        string   `json:"message"`
+}
+
+func handleGrantImmunity(ctx context.Context, req *mcp.CallToolRequest, input GrantImmunityInput) (*mcp.CallToolResult, GrantImmunityOutput, error) {
+	target := combatState.Entities[input.TargetID]
+	if target == nil {
+		return nil, GrantImmunityOutput{}, fmt.Errorf("target not found: %s", input.TargetID)
+	}
+	damageType := strings.ToLower(input.DamageType)
+	if damageType == "" {
+		return nil, GrantImmunityOutput{}, fmt.Errorf("damage type is required")
+	}
+
+	var message string
+	if input.Revoke {
+		if _, ok := target.TempImmunities[damageType]; !ok {
+			return nil, GrantImmunityOutput{}, fmt.Errorf("%s has no temporary %s immunity", target.Name, damageType)
+		}
+		delete(target.TempImmunities, damageType)
+		message = fmt.Sprintf("%s is no longer immune to %s damage.", target.Name, damageType)
+	} else {
+		duration := input.Duration
+		if duration == 0 {
+			duration = -1
+		}
+		if target.TempImmunities == nil {
+			target.TempImmunities = make(map[string]int)
+		}
+		target.TempImmunities[damageType] = duration
+		message = fmt.Sprintf("%s is immune to %s damage", target.Name, damageType)
+		if duration > 0 {
+			message += fmt.Sprintf(" for %d rounds", duration)
+		}
+		message += "."
+	}
+
+	return nil, GrantImmunityOutput{
+		TempImmunities: sortedKeys(target.TempImmunities),
+		Message:        message,
+	}, nil
+}
+
+// tickTempImmunities counts down the entity's temporary immunities at the start of
+// its turn and describes the ones that end
+func tickTempImmunities(e *Entity) []string {
+	effects := []string{}
+	for damageType, duration := range e.TempImmunities {
+		if duration <= 0 {
+			continue
+		}
+		e.TempImmunities[damageType]--
+		if e.TempImmunities[damageType] == 0 {
+			delete(e.TempImmunities, damageType)
+			effects = append(effects, fmt.Sprintf("Temporary %s immunity ended", damageType))
+		}
+	}
+	sort.Strings(effects)
+	return effects
+}
+
+// sortedKeys returns the keys of a map in alphabetical order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
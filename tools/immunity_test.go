@@ -0,0 +1,47 @@
+package tools
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTemporaryImmunityBlocksDamageUntilItExpires(t *testing.T) {
+	ctx := context.Background()
+	startTestCombat(t,
+		EntityInit{ID: "fighter", Name: "Fighter", Initiative: 15, HP: 30, AC: 16},
+		EntityInit{ID: "orc", Name: "Orc", Initiative: 10, HP: 15, AC: 13, IsMonster: true},
+	)
+	fighter := combatState.Entities["fighter"]
+
+	if _, _, err := handleGrantImmunity(ctx, nil, GrantImmunityInput{TargetID: "fighter", DamageType: "Fire", Duration: 10}); err != nil {
+		t.Fatalf("grant_immunity: %v", err)
+	}
+
+	_, hit, err := handleApplyDamage(ctx, nil, ApplyDamageInput{TargetID: "fighter", Damage: 12, DamageType: "fire"})
+	if err != nil {
+		t.Fatalf("apply_damage: %v", err)
+	}
+	if hit.FinalDamage != 0 || fighter.CurrentHP != 30 {
+		t.Fatalf("fire hit while immune dealt %d damage and left %d HP, want 0 and 30", hit.FinalDamage, fighter.CurrentHP)
+	}
+
+	// The immunity counts down at the start of each of the fighter's turns
+	for round := 1; round <= 10; round++ {
+		if _, ok := fighter.TempImmunities["fire"]; !ok {
+			t.Fatalf("fire immunity ended before round %d's turn, want it to last 10 rounds", round)
+		}
+		handleNextTurn(ctx, nil, NextTurnInput{}) // orc
+		handleNextTurn(ctx, nil, NextTurnInput{}) // fighter, in the next round
+	}
+	if _, ok := fighter.TempImmunities["fire"]; ok {
+		t.Fatalf("fire immunity still has %d rounds left after 10 rounds", fighter.TempImmunities["fire"])
+	}
+
+	_, hit, err = handleApplyDamage(ctx, nil, ApplyDamageInput{TargetID: "fighter", Damage: 12, DamageType: "fire"})
+	if err != nil {
+		t.Fatalf("apply_damage: %v", err)
+	}
+	if hit.FinalDamage != 12 || fighter.CurrentHP != 18 {
+		t.Errorf("fire hit after the immunity expired dealt %d damage and left %d HP, want 12 and 18", hit.FinalDamage, fighter.CurrentHP)
+	}
+}
@@ -0,0 +1,207 @@
+package tools
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// rollInitiative rolls d20 plus the entity's DEX modifier
+func (e *Entity) rollInitiative() int {
+	return rand.Intn(20) + 1 + abilityModifier(e, "DEX")
+}
+
+// actsBefore orders two combatants by initiative. Ties go to the higher DEX score,
+// then to a coin flip that is seeded per combat, so re-sorting never reshuffles them.
+// Members of a group on the same count stay together, flipping as one.
+func (cs *CombatState) actsBefore(a, b *Entity) bool {
+	if a.InitiativeRoll != b.InitiativeRoll {
+		return a.InitiativeRoll > b.InitiativeRoll
+	}
+	if a.GroupID != "" && a.GroupID == b.GroupID {
+		return a.ID < b.ID
+	}
+	if dexA, dexB := cs.tiebreakDEX(a), cs.tiebreakDEX(b); dexA != dexB {
+		return dexA > dexB
+	}
+	if flipA, flipB := cs.initiativeCoinFlip(tiebreakKey(a)), cs.initiativeCoinFlip(tiebreakKey(b)); flipA != flipB {
+		return flipA > flipB
+	}
+	return a.ID < b.ID
+}
+
+// tiebreakDEX is the DEX score an entity breaks initiative ties with: the highest
+// among the group mates on its count, so a group is never split
+func (cs *CombatState) tiebreakDEX(e *Entity) int {
+	dex := e.AbilityScores["DEX"]
+	if e.GroupID == "" {
+		return dex
+	}
+	for _, other := range cs.Entities {
+		if other.GroupID == e.GroupID && other.InitiativeRoll == e.InitiativeRoll {
+			dex = max(dex, other.AbilityScores["DEX"])
+		}
+	}
+	return dex
+}
+
+// tiebreakKey is what an entity's initiative coin flip is seeded with: its group, if any
+func tiebreakKey(e *Entity) string {
+	if e.GroupID != "" {
+		return "group:" + e.GroupID
+	}
+	return e.ID
+}
+
+// initiativeCoinFlip derives a stable pseudo-random tiebreaker for an entity from the combat's seed
+func (cs *CombatState) initiativeCoinFlip(id string) uint64 {
+	h := fnv.New64a()
+	binary.Write(h, binary.LittleEndian, cs.InitiativeSeed)
+	h.Write([]byte(id))
+	return h.Sum64()
+}
+
+// RerollAllInitiativeInput defines re-rolling the whole initiative order
+type RerollAllInitiativeInput struct {
+	EncounterScope
+	Modifiers map[string]int `json:"modifiers,omitempty" jsonschema:"Initiative modifier per entity ID (defaults to the entity's DEX modifier)"`
+}
+
+type RerollAllInitiativeOutput struct {
+	TurnOrder []string       `json:"turn_order" jsonschema:"New initiative order by entity ID"`
+	Rolls     map[string]int `json:"rolls" jsonschema:"New initiative total per entity ID"`
+	Message   string         `json:"message"`
+}
+
+func handleRerollAllInitiative(ctx context.Context, req *mcp.CallToolRequest, input RerollAllInitiativeInput) (*mcp.CallToolResult, RerollAllInitiativeOutput, error) {
+	for id := range input.Modifiers {
+		if combatState.Entities[id] == nil {
+			return nil, RerollAllInitiativeOutput{}, fmt.Errorf("entity not found: %s", id)
+		}
+	}
+
+	rolls := make(map[string]int)
+	for id, e := range combatState.Entities {
+		modifier, ok := input.Modifiers[id]
+		if !ok {
+			modifier = abilityModifier(e, "DEX")
+		}
+		e.InitiativeRoll = rand.Intn(20) + 1 + modifier
+		rolls[id] = e.InitiativeRoll
+	}
+
+	combatState.sortTurnOrder()
+	combatState.CurrentTurn = 0
+	combatState.logEvent("Initiative re-rolled for all combatants")
+
+	order := []string{}
+	for _, id := range combatState.TurnOrder {
+		e := combatState.Entities[id]
+		order = append(order, fmt.Sprintf("%s (%d)", e.Name, e.InitiativeRoll))
+	}
+
+	return nil, RerollAllInitiativeOutput{
+		TurnOrder: combatState.TurnOrder,
+		Rolls:     rolls,
+		Message:   fmt.Sprintf("New initiative order for round %d: %s.", combatState.RoundNumber, strings.Join(order, ", ")),
+	}, nil
+}
+
+// DelayTurnInput defines the acting creature putting off its turn
+type DelayTurnInput struct {
+	EncounterScope
+	NewInitiative int `json:"new_initiative" jsonschema:"Initiative count to act on later this round; must be lower than the creature's current initiative"`
+}
+
+type DelayTurnOutput struct {
+	DelayedEntityID string   `json:"delayed_entity_id"`
+	NewInitiative   int      `json:"new_initiative"`
+	TurnOrder       []string `json:"turn_order" jsonschema:"Initiative order by entity ID after the delay"`
+	CurrentEntityID string   `json:"current_entity_id" jsonschema:"Entity whose turn begins now"`
+	TurnEffects     []string `json:"turn_effects" jsonschema:"Start of turn effects for the entity now acting"`
+	Message         string   `json:"message"`
+}
+
+func handleDelayTurn(ctx context.Context, req *mcp.CallToolRequest, input DelayTurnInput) (*mcp.CallToolResult, DelayTurnOutput, error) {
+	if combatState.CurrentTurn >= len(combatState.TurnOrder) {
+		return nil, DelayTurnOutput{}, fmt.Errorf("no one is acting")
+	}
+	// A group delays together
+	group := combatState.actingGroup()
+	entity := group[0]
+	if input.NewInitiative >= entity.InitiativeRoll {
+		return nil, DelayTurnOutput{}, fmt.Errorf("%s can only delay to an initiative below its current %d", entity.Name, entity.InitiativeRoll)
+	}
+	if combatState.CurrentTurn+len(group) == len(combatState.TurnOrder) {
+		return nil, DelayTurnOutput{}, fmt.Errorf("%s already acts last this round", entity.Name)
+	}
+
+	// Moving the delayer later shifts everyone after it up, so the next
+	// creature in line now occupies the current slot
+	oldInitiative := entity.InitiativeRoll
+	for _, member := range group {
+		member.InitiativeRoll = input.NewInitiative
+		member.DelayedTurn = true
+	}
+	combatState.sortTurnOrder()
+	turn := combatState.beginTurn(oldInitiative)
+	combatState.logEvent("%s delays its turn from initiative %d to %d", entity.Name, oldInitiative, input.NewInitiative)
+
+	return nil, DelayTurnOutput{
+		DelayedEntityID: entity.ID,
+		NewInitiative:   input.NewInitiative,
+		TurnOrder:       combatState.TurnOrder,
+		CurrentEntityID: turn.CurrentEntityID,
+		TurnEffects:     turn.Effects,
+		Message: fmt.Sprintf("%s delays to initiative %d. Now %s's turn (round %d).",
+			entity.Name, input.NewInitiative, turn.CurrentEntityName, turn.RoundNumber),
+	}, nil
+}
+
+// PreviousTurnInput defines rewinding the turn pointer by one
+type PreviousTurnInput struct {
+	EncounterScope
+}
+
+type PreviousTurnOutput struct {
+	CurrentEntityID   string `json:"current_entity_id"`
+	CurrentEntityName string `json:"current_entity_name"`
+	RoundNumber       int    `json:"round_number"`
+	Message           string `json:"message"`
+}
+
+func handlePreviousTurn(ctx context.Context, req *mcp.CallToolRequest, input PreviousTurnInput) (*mcp.CallToolResult, PreviousTurnOutput, error) {
+	if len(combatState.TurnOrder) == 0 {
+		return nil, PreviousTurnOutput{}, fmt.Errorf("no one is in the initiative order")
+	}
+	if combatState.RoundNumber <= 1 && combatState.CurrentTurn == 0 {
+		return nil, PreviousTurnOutput{}, fmt.Errorf("already at the first turn of round 1")
+	}
+
+	// Only the pointer moves; effects applied when the turns changed stay applied
+	if combatState.CurrentTurn == 0 {
+		combatState.RoundNumber--
+		combatState.CurrentTurn = len(combatState.TurnOrder) - 1
+	} else {
+		combatState.CurrentTurn--
+	}
+	if combatState.CurrentTurn >= len(combatState.TurnOrder) {
+		combatState.CurrentTurn = len(combatState.TurnOrder) - 1
+	}
+	combatState.CurrentTurn = combatState.groupStart(combatState.CurrentTurn)
+	current := combatState.Entities[combatState.TurnOrder[combatState.CurrentTurn]]
+	combatState.logEvent("Turn rewound to %s (round %d)", current.Name, combatState.RoundNumber)
+
+	return nil, PreviousTurnOutput{
+		CurrentEntityID:   current.ID,
+		CurrentEntityName: current.Name,
+		RoundNumber:       combatState.RoundNumber,
+		Message: fmt.Sprintf("Rewound to %s's turn (round %d). No turn effects were re-applied or reversed.",
+			current.Name, combatState.RoundNumber),
+	}, nil
+}
@@ -0,0 +1,105 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kiriyms/dungeon-master-mcp/resources"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// lairInitiative is the initiative count lair actions happen on, losing ties
+const lairInitiative = 20
+
+// lairActionDue reports whether moving from one turn to the next passes
+// initiative count 20, where wrapped means a new round began in between
+func lairActionDue(previousInit, currentInit int, wrapped bool) bool {
+	if wrapped {
+		return previousInit >= lairInitiative || currentInit < lairInitiative
+	}
+	return previousInit >= lairInitiative && currentInit < lairInitiative
+}
+
+// lairActionEffects lists the lair actions available this round, at most once per round
+func (cs *CombatState) lairActionEffects() []string {
+	if cs.LairActionRound >= cs.RoundNumber {
+		return nil
+	}
+	effects := []string{}
+	for _, id := range cs.TurnOrder {
+		e := cs.Entities[id]
+		if !e.IsMonster || e.CurrentHP <= 0 {
+			continue
+		}
+		monster, ok := resources.GetMonster(e.MonsterName)
+		if !ok || len(monster.LairActions) == 0 {
+			continue
+		}
+		options := []string{}
+		for i, action := range monster.LairActions {
+			options = append(options, fmt.Sprintf("%d) %s", i+1, describeLairAction(action)))
+		}
+		effects = append(effects, fmt.Sprintf("Initiative 20: %s can take a lair action (record it with lair_action): %s",
+			e.Name, strings.Join(options, " ")))
+	}
+	if len(effects) > 0 {
+		cs.LairActionRound = cs.RoundNumber
+	}
+	return effects
+}
+
+// describeLairAction summarizes a lair action with its save, if any
+func describeLairAction(action resources.LairAction) string {
+	if action.SaveDC == 0 {
+		return action.Description
+	}
+	return fmt.Sprintf("%s (DC %d %s)", action.Description, action.SaveDC, action.SaveType)
+}
+
+// LairActionInput defines recording the round's lair action
+type LairActionInput struct {
+	EncounterScope
+	EntityID string `json:"entity_id" jsonschema:"Monster whose lair it is"`
+	Action   int    `json:"action,omitempty" jsonschema:"Number of the lair action to use, as listed at initiative 20 (defaults to 1)"`
+}
+
+type LairActionOutput struct {
+	Description string `json:"description"`
+	SaveDC      int    `json:"save_dc,omitempty"`
+	SaveType    string `json:"save_type,omitempty"`
+	Message     string `json:"message"`
+}
+
+func handleLairAction(ctx context.Context, req *mcp.CallToolRequest, input LairActionInput) (*mcp.CallToolResult, LairActionOutput, error) {
+	entity := combatState.Entities[input.EntityID]
+	if entity == nil {
+		return nil, LairActionOutput{}, fmt.Errorf("entity not found: %s", input.EntityID)
+	}
+	monster, ok := resources.GetMonster(entity.MonsterName)
+	if !ok || len(monster.LairActions) == 0 {
+		return nil, LairActionOutput{}, fmt.Errorf("%s has no lair actions", entity.Name)
+	}
+	if combatState.LairActionUsedRound >= combatState.RoundNumber {
+		return nil, LairActionOutput{}, fmt.Errorf("a lair action has already been taken in round %d", combatState.RoundNumber)
+	}
+
+	index := input.Action
+	if index == 0 {
+		index = 1
+	}
+	if index < 1 || index > len(monster.LairActions) {
+		return nil, LairActionOutput{}, fmt.Errorf("%s has %d lair actions; action %d doesn't exist", entity.Name, len(monster.LairActions), index)
+	}
+	action := monster.LairActions[index-1]
+
+	combatState.LairActionUsedRound = combatState.RoundNumber
+	combatState.logEvent("%s used a lair action: %s", entity.Name, action.Description)
+
+	return nil, LairActionOutput{
+		Description: action.Description,
+		SaveDC:      action.SaveDC,
+		SaveType:    action.SaveType,
+		Message:     fmt.Sprintf("%s's lair acts on initiative 20: %s", entity.Name, describeLairAction(action)),
+	}, nil
+}
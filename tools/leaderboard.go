@@ -0,0 +1,63 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// DamageRank is one combatant's place on the damage leaderboard
+type DamageRank struct {
+	Rank        int    `json:"rank"`
+	EntityID    string `json:"entity_id"`
+	Name        string `json:"name"`
+	DamageDealt int    `json:"damage_dealt"`
+}
+
+// DamageLeaderboardInput defines a damage leaderboard request
+type DamageLeaderboardInput struct {
+	EncounterScope
+	PartyOnly bool `json:"party_only,omitempty" jsonschema:"Only rank player characters"`
+}
+
+type DamageLeaderboardOutput struct {
+	Rankings []DamageRank `json:"rankings"`
+	Message  string       `json:"message"`
+}
+
+func handleDamageLeaderboard(ctx context.Context, req *mcp.CallToolRequest, input DamageLeaderboardInput) (*mcp.CallToolResult, DamageLeaderboardOutput, error) {
+	rankings := []DamageRank{}
+	for id, e := range combatState.Entities {
+		if input.PartyOnly && e.IsMonster {
+			continue
+		}
+		rankings = append(rankings, DamageRank{EntityID: id, Name: e.Name, DamageDealt: e.DamageDealt})
+	}
+	sort.Slice(rankings, func(i, j int) bool {
+		if rankings[i].DamageDealt != rankings[j].DamageDealt {
+			return rankings[i].DamageDealt > rankings[j].DamageDealt
+		}
+		return rankings[i].Name < rankings[j].Name
+	})
+
+	lines := []string{}
+	for i := range rankings {
+		// Tied totals share a rank
+		if i > 0 && rankings[i].DamageDealt == rankings[i-1].DamageDealt {
+			rankings[i].Rank = rankings[i-1].Rank
+		} else {
+			rankings[i].Rank = i + 1
+		}
+		lines = append(lines, fmt.Sprintf("%d. %s (%d)", rankings[i].Rank, rankings[i].Name, rankings[i].DamageDealt))
+	}
+
+	message := "No combatants to rank."
+	if len(lines) > 0 {
+		message = "Damage dealt this encounter: " + strings.Join(lines, ", ")
+	}
+
+	return nil, DamageLeaderboardOutput{Rankings: rankings, Message: message}, nil
+}
@@ -0,0 +1,127 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kiriyms/dungeon-master-mcp/resources"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// LegendaryOpportunity lists what a legendary creature can do at the end of the current turn
+type LegendaryOpportunity struct {
+	EntityID         string                         `json:"entity_id"`
+	Name             string                         `json:"name"`
+	RemainingActions int                            `json:"remaining_actions"`
+	MaxActions       int                            `json:"max_actions"`
+	Options          []resources.LegendaryActionOpt `json:"options" jsonschema:"Legendary actions affordable with the remaining budget"`
+}
+
+// LegendaryOpportunityInput defines the end-of-turn legendary action check
+type LegendaryOpportunityInput struct {
+	EncounterScope
+}
+
+type LegendaryOpportunityOutput struct {
+	CurrentEntityID string                 `json:"current_entity_id" jsonschema:"Creature whose turn is ending"`
+	Opportunities   []LegendaryOpportunity `json:"opportunities"`
+	Message         string                 `json:"message"`
+}
+
+func handleLegendaryOpportunity(ctx context.Context, req *mcp.CallToolRequest, input LegendaryOpportunityInput) (*mcp.CallToolResult, LegendaryOpportunityOutput, error) {
+	currentID := combatState.TurnOrder[combatState.CurrentTurn]
+
+	output := LegendaryOpportunityOutput{
+		CurrentEntityID: currentID,
+		Opportunities:   []LegendaryOpportunity{},
+	}
+
+	for _, id := range combatState.TurnOrder {
+		e := combatState.Entities[id]
+		if id == currentID || e.MaxLegendaryActions == 0 || e.CurrentHP == 0 || e.Dead || e.IsIncapacitated() {
+			continue
+		}
+
+		// A refill is owed if the creature's turn this round was skipped over
+		remaining := e.LegendaryActions
+		if combatState.turnPassedThisRound(id) && e.LegendaryResetRound < combatState.RoundNumber {
+			remaining = e.MaxLegendaryActions
+		}
+		if remaining == 0 {
+			continue
+		}
+
+		opportunity := LegendaryOpportunity{
+			EntityID:         id,
+			Name:             e.Name,
+			RemainingActions: remaining,
+			MaxActions:       e.MaxLegendaryActions,
+			Options:          []resources.LegendaryActionOpt{},
+		}
+		if monster, ok := resources.GetMonster(e.MonsterName); ok && monster.LegendaryActions != nil {
+			for _, opt := range monster.LegendaryActions.Options {
+				if opt.Cost <= remaining {
+					opportunity.Options = append(opportunity.Options, opt)
+				}
+			}
+		}
+		output.Opportunities = append(output.Opportunities, opportunity)
+	}
+
+	current := combatState.Entities[currentID]
+	if len(output.Opportunities) == 0 {
+		output.Message = fmt.Sprintf("No legendary actions are available at the end of %s's turn.", current.Name)
+	} else {
+		output.Message = fmt.Sprintf("%s's turn is ending: %d legendary creature(s) may act. Use use_legendary_action to spend them.", current.Name, len(output.Opportunities))
+	}
+
+	return nil, output, nil
+}
+
+// ResetLegendaryResistancesInput defines refilling legendary resistances
+type ResetLegendaryResistancesInput struct {
+	EncounterScope
+	EntityIDs []string `json:"entity_ids,omitempty" jsonschema:"Creatures to reset (defaults to every creature with legendary resistances)"`
+}
+
+type ResetLegendaryResistancesOutput struct {
+	Restored map[string]int `json:"restored" jsonschema:"Legendary resistances each reset creature now has"`
+	Message  string         `json:"message"`
+}
+
+func handleResetLegendaryResistances(ctx context.Context, req *mcp.CallToolRequest, input ResetLegendaryResistancesInput) (*mcp.CallToolResult, ResetLegendaryResistancesOutput, error) {
+	ids := input.EntityIDs
+	if len(ids) == 0 {
+		for _, id := range sortedKeys(combatState.Entities) {
+			if combatState.Entities[id].MaxLegendaryResistances > 0 {
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	output := ResetLegendaryResistancesOutput{Restored: make(map[string]int)}
+	reset := []string{}
+	for _, id := range ids {
+		entity := combatState.Entities[id]
+		if entity == nil {
+			return nil, ResetLegendaryResistancesOutput{}, fmt.Errorf("entity not found: %s", id)
+		}
+		if entity.MaxLegendaryResistances == 0 {
+			return nil, ResetLegendaryResistancesOutput{}, fmt.Errorf("%s has no legendary resistances", entity.Name)
+		}
+		if entity.LegendaryResistances < entity.MaxLegendaryResistances {
+			reset = append(reset, fmt.Sprintf("%s %d->%d", entity.Name, entity.LegendaryResistances, entity.MaxLegendaryResistances))
+		}
+		entity.LegendaryResistances = entity.MaxLegendaryResistances
+		output.Restored[id] = entity.LegendaryResistances
+	}
+
+	if len(reset) == 0 {
+		output.Message = "No legendary resistances were spent; nothing to reset."
+		return nil, output, nil
+	}
+	combatState.logEvent("Legendary resistances reset: %s", strings.Join(reset, ", "))
+	output.Message = fmt.Sprintf("Legendary resistances reset: %s.", strings.Join(reset, ", "))
+	return nil, output, nil
+}
@@ -0,0 +1,33 @@
+package tools
+
+import "testing"
+
+func TestLegendaryBudgetFromStatBlock(t *testing.T) {
+	loadTestMonster(t, "Test Lich", `{"name": "Test Lich", "size": "Medium", "type": "undead", "hp": 135, "ac": 17,
+		"traits": [{"name": "Legendary Resistance (2/Day)", "description": "If the lich fails a saving throw, it can choose to succeed instead."}],
+		"actions": [{"name": "Paralyzing Touch", "attack_bonus": 12, "damage_dice": "3d6", "damage_type": "cold"}],
+		"legendary_actions": {"actions_per_round": 3, "options": [{"name": "Cantrip", "cost": 1}]}}`)
+	startTestCombat(t,
+		EntityInit{ID: "lich", Name: "Lich", Initiative: 18, HP: 135, AC: 17, IsMonster: true, MonsterName: "Test Lich"},
+		EntityInit{ID: "dragon", Name: "Red", Initiative: 20, HP: 546, AC: 22, IsMonster: true, MonsterName: "Ancient Red Dragon"},
+		EntityInit{ID: "orc", Name: "Orc", Initiative: 10, HP: 15, AC: 13, IsMonster: true, MonsterName: "Orc"},
+	)
+
+	tests := []struct {
+		id                   string
+		actions, resistances int
+	}{
+		{"lich", 3, 2},
+		{"dragon", 3, 3},
+		{"orc", 0, 0},
+	}
+	for _, tt := range tests {
+		e := combatState.Entities[tt.id]
+		if e.MaxLegendaryActions != tt.actions || e.LegendaryActions != tt.actions {
+			t.Errorf("%s legendary actions = %d/%d, want %d", tt.id, e.LegendaryActions, e.MaxLegendaryActions, tt.actions)
+		}
+		if e.MaxLegendaryResistances != tt.resistances || e.LegendaryResistances != tt.resistances {
+			t.Errorf("%s legendary resistances = %d/%d, want %d", tt.id, e.LegendaryResistances, e.MaxLegendaryResistances, tt.resistances)
+		}
+	}
+}
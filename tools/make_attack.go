@@ -0,0 +1,160 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kiriyms/dungeon-master-mcp/resources"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// MakeAttackInput defines an attack roll to hit, with damage applied separately
+type MakeAttackInput struct {
+	EncounterScope
+	AttackerID   string `json:"attacker_id"`
+	TargetID     string `json:"target_id"`
+	AttackBonus  *int   `json:"attack_bonus,omitempty" jsonschema:"Attack bonus to add; when omitted it comes from the attacker's stat block action"`
+	ActionName   string `json:"action_name,omitempty" jsonschema:"Stat block attack to take the bonus from (defaults to the first attack)"`
+	Ranged       bool   `json:"ranged,omitempty" jsonschema:"The attack is a ranged attack, for conditions such as prone when no distance is tracked"`
+	Advantage    bool   `json:"advantage,omitempty" jsonschema:"Other sources of advantage"`
+	Disadvantage bool   `json:"disadvantage,omitempty" jsonschema:"Other sources of disadvantage"`
+	Cover        string `json:"cover,omitempty" jsonschema:"Cover the target has: half (+2 AC), three_quarters (+5 AC), or total (the attack misses)"`
+	// Declared reasons are counted alongside the creatures' conditions
+	AdvantageSources    []string `json:"advantage_sources,omitempty" jsonschema:"Reasons the attack has advantage, e.g. flanking, help, or prone target within 5 feet"`
+	DisadvantageSources []string `json:"disadvantage_sources,omitempty" jsonschema:"Reasons the attack has disadvantage, e.g. long range or heavily obscured"`
+}
+
+type MakeAttackOutput struct {
+	Roll          int    `json:"roll" jsonschema:"natural d20 result"`
+	Rolls         []int  `json:"rolls,omitempty" jsonschema:"both d20s when rolled with advantage or disadvantage"`
+	RollMode      string `json:"roll_mode,omitempty"`
+	RollReason    string `json:"roll_reason,omitempty" jsonschema:"What gave the roll advantage or disadvantage"`
+	Bonus         int    `json:"bonus"`
+	Total         int    `json:"total"`
+	TargetAC      int    `json:"target_ac" jsonschema:"AC the roll had to meet, including any cover"`
+	Hit           bool   `json:"hit"`
+	Critical      bool   `json:"critical" jsonschema:"A natural 20, or a hit against a creature that is critically hit within 5 feet; the next apply_damage on the target doubles its dice"`
+	NaturalTwenty bool   `json:"natural_twenty" jsonschema:"A natural 20 hits and crits regardless of the total"`
+	NaturalOne    bool   `json:"natural_one" jsonschema:"A natural 1 misses regardless of the total"`
+	Margin        int    `json:"margin" jsonschema:"Total minus the target's AC"`
+	DamageDice    string `json:"damage_dice,omitempty" jsonschema:"Damage to roll with apply_damage on a hit, when the bonus came from a stat block"`
+	DamageType    string `json:"damage_type,omitempty"`
+	Cover         string `json:"cover,omitempty"`
+	CoverBonus    int    `json:"cover_bonus,omitempty" jsonschema:"AC the cover added"`
+	Message       string `json:"message"`
+	// Every source counted; any advantage and any disadvantage cancel out to a straight roll
+	AdvantageFrom    []string `json:"advantage_from,omitempty"`
+	DisadvantageFrom []string `json:"disadvantage_from,omitempty"`
+}
+
+func handleMakeAttack(ctx context.Context, req *mcp.CallToolRequest, input MakeAttackInput) (*mcp.CallToolResult, MakeAttackOutput, error) {
+	attacker := combatState.Entities[input.AttackerID]
+	if attacker == nil {
+		return nil, MakeAttackOutput{}, fmt.Errorf("attacker not found: %s", input.AttackerID)
+	}
+	target := combatState.Entities[input.TargetID]
+	if target == nil {
+		return nil, MakeAttackOutput{}, fmt.Errorf("target not found: %s", input.TargetID)
+	}
+	if attacker.IsIncapacitated() {
+		return nil, MakeAttackOutput{}, fmt.Errorf("%s is incapacitated and can't take actions", attacker.Name)
+	}
+	cover, err := parseCover(input.Cover)
+	if err != nil {
+		return nil, MakeAttackOutput{}, err
+	}
+
+	var action resources.MonsterAction
+	if input.AttackBonus != nil {
+		action = resources.MonsterAction{Name: input.ActionName, AttackBonus: *input.AttackBonus}
+		if action.Name == "" {
+			action.Name = "attack"
+		}
+	} else {
+		if action, err = attackAction(attacker, input.ActionName); err != nil {
+			return nil, MakeAttackOutput{}, fmt.Errorf("%w; give attack_bonus for an attack without a stat block", err)
+		}
+	}
+	if input.Ranged {
+		action.Description = "Ranged Weapon Attack"
+	}
+
+	// Total cover means the target can't be targeted at all
+	if cover == coverTotal {
+		return nil, MakeAttackOutput{
+			TargetAC:   target.AC,
+			DamageDice: action.DamageDice,
+			DamageType: action.DamageType,
+			Cover:      cover,
+			Message:    fmt.Sprintf("%s's %s can't target %s, who has total cover: automatic miss.", attacker.Name, action.Name, target.Name),
+		}, nil
+	}
+
+	opts := attackOptions{
+		Advantage:           input.Advantage,
+		Disadvantage:        input.Disadvantage,
+		AdvantageSources:    input.AdvantageSources,
+		DisadvantageSources: input.DisadvantageSources,
+	}
+	modifiers, autoCrit := opts.modifiers(attacker, target, action)
+	roll, rolls, mode := modifiers.roll()
+	total := roll + action.AttackBonus
+	ac := target.AC + coverBonus(cover)
+
+	output := MakeAttackOutput{
+		Roll:          roll,
+		Rolls:         rolls,
+		RollMode:      mode,
+		RollReason:    modifiers.describe(),
+		Bonus:         action.AttackBonus,
+		Total:         total,
+		TargetAC:      ac,
+		Hit:           roll == 20 || (roll != 1 && total >= ac),
+		NaturalTwenty: roll == 20,
+		NaturalOne:    roll == 1,
+		Margin:        total - ac,
+		DamageDice:    action.DamageDice,
+		DamageType:    action.DamageType,
+		Cover:         cover,
+		CoverBonus:    coverBonus(cover),
+	}
+	output.AdvantageFrom, output.DisadvantageFrom = modifiers.Advantage, modifiers.Disadvantage
+	output.Critical = roll == 20 || (output.Hit && autoCrit != "")
+	target.PendingCritical = output.Critical
+
+	message := fmt.Sprintf("%s's %s against %s: %d%+d=%d vs AC %d", attacker.Name, action.Name, target.Name, roll, action.AttackBonus, total, ac)
+	if output.CoverBonus != 0 {
+		message += fmt.Sprintf(" (%+d from %s)", output.CoverBonus, describeCover(cover))
+	}
+	if mode != "" {
+		message += fmt.Sprintf(" (%s, rolled %v)", output.RollReason, rolls)
+	} else if output.RollReason != "" {
+		message += fmt.Sprintf(" (%s)", output.RollReason)
+	}
+	switch {
+	case roll == 20:
+		message += ": natural 20, CRITICAL HIT"
+	case output.Critical:
+		message += fmt.Sprintf(": CRITICAL HIT (%s within 5 feet)", autoCrit)
+	case roll == 1:
+		message += ": natural 1, automatic miss"
+	case output.Hit:
+		message += fmt.Sprintf(": HIT (margin %+d)", output.Margin)
+	case total >= target.AC:
+		message += fmt.Sprintf(": MISS (margin %+d; the cover turned a hit into a miss)", output.Margin)
+	default:
+		message += fmt.Sprintf(": MISS (margin %+d)", output.Margin)
+	}
+	if output.Critical {
+		message += ". The next apply_damage on " + target.Name + " rolls double dice"
+	}
+
+	if attacker.Hidden {
+		attacker.Hidden = false
+		message += fmt.Sprintf(". %s is no longer hidden", attacker.Name)
+		combatState.logEvent("%s attacks from hiding and is revealed", attacker.Name)
+	}
+	output.Message = message + "."
+
+	return nil, output, nil
+}
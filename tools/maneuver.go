@@ -0,0 +1,203 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// DicePool is a set of expendable dice of one size, such as superiority dice. The
+// number of dice left is the entity's resource of the same name.
+type DicePool struct {
+	Name     string
+	DieSize  int
+	Max      int
+	Recharge string // short_rest or long_rest
+}
+
+// die returns the pool's die as a dice expression, e.g. 1d8
+func (p *DicePool) die() string {
+	return fmt.Sprintf("1d%d", p.DieSize)
+}
+
+// defaultPoolName is the pool use_maneuver spends from when none is named
+const defaultPoolName = "superiority dice"
+
+// maneuverRider is what a maneuver does to the target beyond extra damage
+type maneuverRider struct {
+	SaveType          string // "" when there is no save
+	Condition         string // condition imposed on a failed save
+	ConditionDuration int
+	MaxSize           string // largest size the rider works on, if limited
+	Effect            string // what happens on a failed save, for riders without a condition
+}
+
+// maneuvers lists the Battle Master maneuvers that add the die to damage
+var maneuvers = map[string]maneuverRider{
+	"trip attack":        {SaveType: "STR", Condition: "prone", ConditionDuration: -1, MaxSize: "Large"},
+	"menacing attack":    {SaveType: "WIS", Condition: "frightened", ConditionDuration: 1},
+	"pushing attack":     {SaveType: "STR", MaxSize: "Large", Effect: "is pushed up to 15 feet away"},
+	"disarming attack":   {SaveType: "STR", Effect: "drops an object of the attacker's choice"},
+	"goading attack":     {SaveType: "WIS", Effect: "has disadvantage on attacks against targets other than the attacker until the end of the attacker's next turn"},
+	"distracting strike": {Effect: "grants advantage on the next attack against it by someone other than the attacker"},
+	"sweeping attack":    {Effect: "lets the die's damage hit a second creature within reach instead"},
+	"riposte":            {},
+	"lunging attack":     {},
+}
+
+// maneuverSaveDC is 8 + proficiency bonus + the better of STR and DEX
+func maneuverSaveDC(e *Entity) int {
+	return 8 + e.ProficiencyBonus + max(abilityModifier(e, "STR"), abilityModifier(e, "DEX"))
+}
+
+// SetDicePoolInput defines an entity's pool of expendable dice
+type SetDicePoolInput struct {
+	EncounterScope
+	EntityID string `json:"entity_id"`
+	Name     string `json:"name,omitempty" jsonschema:"Pool name (defaults to superiority dice)"`
+	DieSize  int    `json:"die_size" jsonschema:"Die size, e.g. 8 for d8"`
+	Count    int    `json:"count" jsonschema:"Number of dice when the pool is full"`
+	Recharge string `json:"recharge,omitempty" jsonschema:"short_rest or long_rest (defaults to short_rest)"`
+}
+
+type SetDicePoolOutput struct {
+	Remaining int    `json:"remaining"`
+	Message   string `json:"message"`
+}
+
+func handleSetDicePool(ctx context.Context, req *mcp.CallToolRequest, input SetDicePoolInput) (*mcp.CallToolResult, SetDicePoolOutput, error) {
+	entity := combatState.Entities[input.EntityID]
+	if entity == nil {
+		return nil, SetDicePoolOutput{}, fmt.Errorf("entity not found: %s", input.EntityID)
+	}
+	if !slices.Contains([]int{4, 6, 8, 10, 12, 20}, input.DieSize) {
+		return nil, SetDicePoolOutput{}, fmt.Errorf("unsupported die size: d%d", input.DieSize)
+	}
+	if input.Count <= 0 {
+		return nil, SetDicePoolOutput{}, fmt.Errorf("count must be positive")
+	}
+	recharge := strings.ToLower(input.Recharge)
+	switch recharge {
+	case "":
+		recharge = "short_rest"
+	case "short_rest", "long_rest":
+	default:
+		return nil, SetDicePoolOutput{}, fmt.Errorf("unknown recharge: %s", input.Recharge)
+	}
+
+	name := strings.ToLower(input.Name)
+	if name == "" {
+		name = defaultPoolName
+	}
+	if entity.DicePools == nil {
+		entity.DicePools = make(map[string]*DicePool)
+	}
+	entity.DicePools[name] = &DicePool{Name: name, DieSize: input.DieSize, Max: input.Count, Recharge: recharge}
+	entity.Resources[name] = input.Count
+
+	return nil, SetDicePoolOutput{
+		Remaining: input.Count,
+		Message:   fmt.Sprintf("%s has %d %s (d%d), recharging on a %s.", entity.Name, input.Count, name, input.DieSize, strings.ReplaceAll(recharge, "_", " ")),
+	}, nil
+}
+
+// UseManeuverInput defines spending a pool die on a maneuver
+type UseManeuverInput struct {
+	EncounterScope
+	EntityID     string `json:"entity_id"`
+	Maneuver     string `json:"maneuver" jsonschema:"Maneuver name, e.g. Trip Attack"`
+	TargetID     string `json:"target_id"`
+	AttackDamage int    `json:"attack_damage,omitempty" jsonschema:"Damage of the hit the maneuver is added to, not yet applied; the total is applied together"`
+	DamageType   string `json:"damage_type,omitempty" jsonschema:"The attack's damage type"`
+	Pool         string `json:"pool,omitempty" jsonschema:"Pool to spend from (defaults to superiority dice)"`
+}
+
+type UseManeuverOutput struct {
+	DieRoll       int    `json:"die_roll"`
+	TotalDamage   int    `json:"total_damage" jsonschema:"Damage dealt after resistances, attack damage plus the die"`
+	SaveDC        int    `json:"save_dc,omitempty"`
+	SaveSuccess   bool   `json:"save_success,omitempty"`
+	Effect        string `json:"effect,omitempty" jsonschema:"The rider that took hold, if any"`
+	DiceRemaining int    `json:"dice_remaining"`
+	Message       string `json:"message"`
+}
+
+func handleUseManeuver(ctx context.Context, req *mcp.CallToolRequest, input UseManeuverInput) (*mcp.CallToolResult, UseManeuverOutput, error) {
+	entity := combatState.Entities[input.EntityID]
+	if entity == nil {
+		return nil, UseManeuverOutput{}, fmt.Errorf("entity not found: %s", input.EntityID)
+	}
+	target := combatState.Entities[input.TargetID]
+	if target == nil {
+		return nil, UseManeuverOutput{}, fmt.Errorf("target not found: %s", input.TargetID)
+	}
+	rider, ok := maneuvers[strings.ToLower(input.Maneuver)]
+	if !ok {
+		return nil, UseManeuverOutput{}, fmt.Errorf("unknown maneuver: %s", input.Maneuver)
+	}
+
+	poolName := strings.ToLower(input.Pool)
+	if poolName == "" {
+		poolName = defaultPoolName
+	}
+	pool := entity.DicePools[poolName]
+	if pool == nil {
+		return nil, UseManeuverOutput{}, fmt.Errorf("%s has no %s", entity.Name, poolName)
+	}
+	if entity.Resources[poolName] <= 0 {
+		return nil, UseManeuverOutput{}, fmt.Errorf("%s has no %s left", entity.Name, poolName)
+	}
+
+	roll, err := rollDice(pool.die(), 1)
+	if err != nil {
+		return nil, UseManeuverOutput{}, err
+	}
+	entity.Resources[poolName]--
+
+	dealt, modifier := applyDamage(target, input.AttackDamage+roll.Total, input.DamageType)
+	entity.DamageDealt += dealt
+
+	output := UseManeuverOutput{
+		DieRoll:       roll.Total,
+		TotalDamage:   dealt,
+		DiceRemaining: entity.Resources[poolName],
+	}
+	message := fmt.Sprintf("%s uses %s on %s, rolling %d on the d%d. %s takes %d damage%s (%d HP left)",
+		entity.Name, input.Maneuver, target.Name, roll.Total, pool.DieSize, target.Name, dealt, modifier, target.CurrentHP)
+
+	switch {
+	case rider.SaveType == "" && rider.Effect != "":
+		output.Effect = rider.Effect
+		message += fmt.Sprintf(". %s %s", target.Name, rider.Effect)
+
+	case rider.SaveType != "":
+		if reason := restrictionReason(target, nil, rider.MaxSize); reason != "" {
+			message += fmt.Sprintf(". The rider has no effect: %s", reason)
+			break
+		}
+		output.SaveDC = maneuverSaveDC(entity)
+		save := rollSavingThrow(target, rider.SaveType, output.SaveDC)
+		output.SaveSuccess = save.Success
+		message += fmt.Sprintf(". %s save: %s", rider.SaveType, save.describe(target, output.SaveDC))
+		if save.Success {
+			break
+		}
+		if rider.Condition != "" {
+			target.Conditions[rider.Condition] = rider.ConditionDuration
+			target.setConditionSource(rider.Condition, entity.ID)
+			output.Effect = rider.Condition
+			message += fmt.Sprintf(". %s is now %s", target.Name, rider.Condition)
+		} else {
+			output.Effect = rider.Effect
+			message += fmt.Sprintf(". %s %s", target.Name, rider.Effect)
+		}
+	}
+
+	output.Message = message + fmt.Sprintf(". %d %s left.", output.DiceRemaining, poolName)
+	combatState.logEvent("%s uses %s on %s", entity.Name, input.Maneuver, target.Name)
+
+	return nil, output, nil
+}
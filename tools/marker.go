@@ -0,0 +1,88 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// NextHitBonus is a single-use damage amplifier that is consumed by the next hit
+type NextHitBonus struct {
+	Source    string
+	Double    bool   // the next hit deals double damage
+	ExtraDice string // extra dice added to the next hit, e.g. "2d6"
+}
+
+// MarkNextHitInput defines marking a target for extra damage on the next hit
+type MarkNextHitInput struct {
+	EncounterScope
+	TargetID  string `json:"target_id"`
+	Source    string `json:"source" jsonschema:"Ability or spell that marked the target"`
+	Double    bool   `json:"double,omitempty" jsonschema:"Target is vulnerable to the next hit (damage doubled)"`
+	ExtraDice string `json:"extra_dice,omitempty" jsonschema:"Extra dice the next hit deals, e.g. 2d6"`
+}
+
+type MarkNextHitOutput struct {
+	Message string `json:"message"`
+}
+
+func handleMarkNextHit(ctx context.Context, req *mcp.CallToolRequest, input MarkNextHitInput) (*mcp.CallToolResult, MarkNextHitOutput, error) {
+	target := combatState.Entities[input.TargetID]
+	if target == nil {
+		return nil, MarkNextHitOutput{}, fmt.Errorf("target not found: %s", input.TargetID)
+	}
+	if !input.Double && input.ExtraDice == "" {
+		return nil, MarkNextHitOutput{}, fmt.Errorf("specify double or extra_dice")
+	}
+	if input.ExtraDice != "" {
+		if _, _, _, err := parseDice(input.ExtraDice); err != nil {
+			return nil, MarkNextHitOutput{}, err
+		}
+	}
+
+	replaced := target.NextHitBonus
+	target.NextHitBonus = &NextHitBonus{
+		Source:    input.Source,
+		Double:    input.Double,
+		ExtraDice: input.ExtraDice,
+	}
+
+	message := fmt.Sprintf("%s is marked by %s: the next hit %s.", target.Name, input.Source, describeNextHitBonus(target.NextHitBonus))
+	if replaced != nil {
+		message += fmt.Sprintf(" This replaces the unused mark from %s.", replaced.Source)
+	}
+
+	return nil, MarkNextHitOutput{Message: message}, nil
+}
+
+// describeNextHitBonus summarizes what a next-hit marker does
+func describeNextHitBonus(bonus *NextHitBonus) string {
+	switch {
+	case bonus.Double && bonus.ExtraDice != "":
+		return fmt.Sprintf("deals +%s and double damage", bonus.ExtraDice)
+	case bonus.Double:
+		return "deals double damage"
+	default:
+		return fmt.Sprintf("deals +%s damage", bonus.ExtraDice)
+	}
+}
+
+// consumeNextHitBonus applies and clears the target's next-hit marker, returning the
+// amplified damage and a note describing what was consumed
+func consumeNextHitBonus(target *Entity, damage int) (int, string) {
+	bonus := target.NextHitBonus
+	target.NextHitBonus = nil
+
+	amplified := damage
+	if bonus.ExtraDice != "" {
+		if extra, err := rollDice(bonus.ExtraDice, 1); err == nil {
+			amplified += extra.Total
+		}
+	}
+	if bonus.Double {
+		amplified *= 2
+	}
+
+	return amplified, fmt.Sprintf(" (%s mark consumed: %d -> %d)", bonus.Source, damage, amplified)
+}
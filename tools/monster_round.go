@@ -0,0 +1,144 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// MonsterAssignment is one minion's action for the round
+type MonsterAssignment struct {
+	MonsterID string `json:"monster_id"`
+	Action    string `json:"action,omitempty" jsonschema:"Stat block attack to use (defaults to the monster's first attack)"`
+	TargetID  string `json:"target_id"`
+}
+
+// ResolveMonsterRoundInput defines a batch of minion turns
+type ResolveMonsterRoundInput struct {
+	EncounterScope
+	Assignments []MonsterAssignment `json:"assignments" jsonschema:"Attacks to resolve; they are executed in initiative order starting from the current turn"`
+}
+
+// MonsterTurnResult reports one resolved minion turn
+type MonsterTurnResult struct {
+	MonsterID   string       `json:"monster_id"`
+	TargetID    string       `json:"target_id"`
+	Attack      AttackResult `json:"attack"`
+	TurnEffects []string     `json:"turn_effects,omitempty" jsonschema:"Start-of-turn effects for the monster"`
+	Note        string       `json:"note,omitempty" jsonschema:"Why the monster didn't attack, if it didn't"`
+	// Set when a hit leaves the target to save for its concentration
+	ConcentrationDC int `json:"concentration_dc,omitempty" jsonschema:"DC of the concentration save the hit forces on the target"`
+}
+
+type ResolveMonsterRoundOutput struct {
+	Turns         []MonsterTurnResult `json:"turns"`
+	Unresolved    []MonsterAssignment `json:"unresolved,omitempty" jsonschema:"Assignments not executed because the sequence stopped"`
+	StoppedReason string              `json:"stopped_reason,omitempty"`
+	NextEntityID  string              `json:"next_entity_id" jsonschema:"Entity whose turn it is now"`
+	NextEffects   []string            `json:"next_effects,omitempty" jsonschema:"Start-of-turn effects for the entity whose turn it is now"`
+	RoundNumber   int                 `json:"round_number"`
+	Message       string              `json:"message"`
+}
+
+func handleResolveMonsterRound(ctx context.Context, req *mcp.CallToolRequest, input ResolveMonsterRoundInput) (*mcp.CallToolResult, ResolveMonsterRoundOutput, error) {
+	if len(input.Assignments) == 0 {
+		return nil, ResolveMonsterRoundOutput{}, fmt.Errorf("no assignments given")
+	}
+
+	// Validate everything and order the assignments by initiative from the current turn
+	order := make(map[string]int)
+	for i := range combatState.TurnOrder {
+		idx := (combatState.CurrentTurn + i) % len(combatState.TurnOrder)
+		order[combatState.TurnOrder[idx]] = i
+	}
+	pending := make([]MonsterAssignment, 0, len(input.Assignments))
+	for _, a := range input.Assignments {
+		monster := combatState.Entities[a.MonsterID]
+		if monster == nil {
+			return nil, ResolveMonsterRoundOutput{}, fmt.Errorf("monster not found: %s", a.MonsterID)
+		}
+		if combatState.Entities[a.TargetID] == nil {
+			return nil, ResolveMonsterRoundOutput{}, fmt.Errorf("target not found: %s", a.TargetID)
+		}
+		if _, err := attackAction(monster, a.Action); err != nil {
+			return nil, ResolveMonsterRoundOutput{}, err
+		}
+		pending = append(pending, a)
+	}
+	sort.SliceStable(pending, func(i, j int) bool {
+		return order[pending[i].MonsterID] < order[pending[j].MonsterID]
+	})
+
+	output := ResolveMonsterRoundOutput{Turns: []MonsterTurnResult{}}
+	turnEffects := []string{}
+	for i, a := range pending {
+		// Grouped minions share a turn, so any member of the acting group may go
+		group := combatState.actingGroup()
+		if !inGroup(group, a.MonsterID) {
+			output.StoppedReason = fmt.Sprintf("it is %s's turn, which has no assignment; resolve it before continuing", group[0].Name)
+			output.Unresolved = pending[i:]
+			break
+		}
+
+		monster := combatState.Entities[a.MonsterID]
+		target := combatState.Entities[a.TargetID]
+		if target.CurrentHP == 0 {
+			output.StoppedReason = fmt.Sprintf("%s is down; reassign %s's target", target.Name, monster.Name)
+			output.Unresolved = pending[i:]
+			break
+		}
+
+		turn := MonsterTurnResult{
+			MonsterID:   a.MonsterID,
+			TargetID:    a.TargetID,
+			TurnEffects: turnEffects,
+		}
+		if monster.IsIncapacitated() {
+			// An incapacitated monster loses its turn
+			turn.Note = fmt.Sprintf("%s is incapacitated and can't attack", monster.Name)
+		} else {
+			action, _ := attackAction(monster, a.Action)
+			hpBefore := target.CurrentHP
+			result, err := resolveAttack(monster, target, action, false)
+			if err != nil {
+				return nil, ResolveMonsterRoundOutput{}, err
+			}
+			// A hit settles like apply_damage: bloodied, dropping or dying, and concentration
+			if result.Hit {
+				after := combatState.afterDamage(target, hpBefore, result.Damage, false, false)
+				turn.ConcentrationDC = after.ConcentrationDC
+				if after.Note != "" {
+					result.Message += "." + strings.TrimSuffix(after.Note, ".")
+				}
+			}
+			turn.Attack = result
+		}
+		output.Turns = append(output.Turns, turn)
+
+		// The turn passes once the last assigned member of the acting group has gone
+		turnEffects = nil
+		if i+1 == len(pending) || !inGroup(group, pending[i+1].MonsterID) {
+			turnEffects = combatState.advanceTurn().Effects
+		}
+	}
+
+	output.NextEntityID = combatState.TurnOrder[combatState.CurrentTurn]
+	output.NextEffects = turnEffects
+	output.RoundNumber = combatState.RoundNumber
+	output.Message = fmt.Sprintf("Resolved %d of %d monster turns. Now %s's turn (round %d).",
+		len(output.Turns), len(pending), combatState.Entities[output.NextEntityID].Name, output.RoundNumber)
+	if output.StoppedReason != "" {
+		output.Message += " Stopped: " + output.StoppedReason + "."
+	}
+
+	return nil, output, nil
+}
+
+// inGroup reports whether id is one of the group's members
+func inGroup(group []*Entity, id string) bool {
+	return slices.ContainsFunc(group, func(e *Entity) bool { return e.ID == id })
+}
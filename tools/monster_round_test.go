@@ -0,0 +1,41 @@
+package tools
+
+import (
+	"context"
+	"testing"
+)
+
+func TestResolveMonsterRoundGroupMembers(t *testing.T) {
+	ctx := context.Background()
+	startTestCombat(t,
+		EntityInit{ID: "wizard", Name: "Wizard", Initiative: 15, HP: 40, AC: 5},
+		EntityInit{ID: "g1", Name: "Goblin 1", Initiative: 12, HP: 7, AC: 15, IsMonster: true, MonsterName: "Goblin", GroupID: "goblins"},
+		EntityInit{ID: "g2", Name: "Goblin 2", HP: 7, AC: 15, IsMonster: true, MonsterName: "Goblin", GroupID: "goblins"},
+		EntityInit{ID: "g3", Name: "Goblin 3", HP: 7, AC: 15, IsMonster: true, MonsterName: "Goblin", GroupID: "goblins"},
+	)
+	combatState.Entities["wizard"].Concentrating = "Haste"
+	if turn := combatState.advanceTurn(); turn.CurrentEntityID != "g1" {
+		t.Fatalf("next turn went to %s, want the goblins led by g1", turn.CurrentEntityID)
+	}
+	round := combatState.RoundNumber
+
+	// g1 leads the group but has no assignment; the others still act on the group's turn
+	_, output, err := handleResolveMonsterRound(ctx, nil, ResolveMonsterRoundInput{Assignments: []MonsterAssignment{
+		{MonsterID: "g3", TargetID: "wizard"},
+		{MonsterID: "g2", TargetID: "wizard"},
+	}})
+	if err != nil {
+		t.Fatalf("resolve_monster_round: %v", err)
+	}
+	if output.StoppedReason != "" || len(output.Turns) != 2 {
+		t.Fatalf("resolved %d turns, stopped: %q; want both group members resolved", len(output.Turns), output.StoppedReason)
+	}
+	if output.NextEntityID != "wizard" || output.RoundNumber != round+1 {
+		t.Errorf("turn is %s in round %d, want wizard in round %d after one group turn", output.NextEntityID, output.RoundNumber, round+1)
+	}
+	for _, turn := range output.Turns {
+		if turn.Attack.Hit && turn.ConcentrationDC != 10 {
+			t.Errorf("%s hit for %d without a DC 10 concentration save: %s", turn.MonsterID, turn.Attack.Damage, turn.Attack.Message)
+		}
+	}
+}
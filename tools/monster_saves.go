@@ -0,0 +1,51 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kiriyms/dungeon-master-mcp/resources"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// SaveLine is one ability's saving throw bonus
+type SaveLine struct {
+	Ability    string `json:"ability"`
+	Bonus      int    `json:"bonus"`
+	Proficient bool   `json:"proficient"`
+}
+
+// MonsterSavesInput defines a saving throw lookup
+type MonsterSavesInput struct {
+	MonsterName string `json:"monster_name" jsonschema:"Monster stat block name"`
+}
+
+type MonsterSavesOutput struct {
+	MonsterName string     `json:"monster_name"`
+	Saves       []SaveLine `json:"saves"`
+	Message     string     `json:"message"`
+}
+
+func handleMonsterSaves(ctx context.Context, req *mcp.CallToolRequest, input MonsterSavesInput) (*mcp.CallToolResult, MonsterSavesOutput, error) {
+	monster, ok := resources.GetMonster(input.MonsterName)
+	if !ok {
+		return nil, MonsterSavesOutput{}, fmt.Errorf("monster not found: %s", input.MonsterName)
+	}
+
+	output := MonsterSavesOutput{MonsterName: monster.Name, Saves: []SaveLine{}}
+	parts := []string{}
+	for _, ability := range resources.Abilities {
+		bonus, proficient := monster.SaveBonus(ability)
+		output.Saves = append(output.Saves, SaveLine{Ability: ability, Bonus: bonus, Proficient: proficient})
+
+		part := fmt.Sprintf("%s %+d", ability, bonus)
+		if proficient {
+			part += "*"
+		}
+		parts = append(parts, part)
+	}
+	output.Message = fmt.Sprintf("%s saves: %s (* proficient)", monster.Name, strings.Join(parts, ", "))
+
+	return nil, output, nil
+}
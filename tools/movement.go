@@ -0,0 +1,89 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ForcedMovementInput defines pushing or pulling a creature
+type ForcedMovementInput struct {
+	EncounterScope
+	TargetID         string `json:"target_id"`
+	Distance         int    `json:"distance" jsonschema:"Feet moved"`
+	Direction        string `json:"direction" jsonschema:"Direction or description, e.g. 'away from the dragon' or 'toward the pit'"`
+	KnocksProne      bool   `json:"knocks_prone,omitempty" jsonschema:"Whether the effect also knocks the target prone"`
+	OriginID         string `json:"origin_id,omitempty" jsonschema:"Entity the movement is relative to, for distance tracking"`
+	StartingDistance *int   `json:"starting_distance,omitempty" jsonschema:"Feet between target and origin before the movement (defaults to the last tracked distance)"`
+}
+
+type ForcedMovementOutput struct {
+	DistanceFromOrigin *int   `json:"distance_from_origin,omitempty" jsonschema:"Tracked feet between target and origin after the movement"`
+	Prone              bool   `json:"prone"`
+	Message            string `json:"message"`
+}
+
+func handleForcedMovement(ctx context.Context, req *mcp.CallToolRequest, input ForcedMovementInput) (*mcp.CallToolResult, ForcedMovementOutput, error) {
+	target := combatState.Entities[input.TargetID]
+	if target == nil {
+		return nil, ForcedMovementOutput{}, fmt.Errorf("target not found: %s", input.TargetID)
+	}
+	if input.Distance < 0 {
+		return nil, ForcedMovementOutput{}, fmt.Errorf("distance must be positive; use the direction to describe a pull")
+	}
+
+	output := ForcedMovementOutput{}
+	message := fmt.Sprintf("%s is moved %d feet %s.", target.Name, input.Distance, input.Direction)
+
+	if input.OriginID != "" {
+		origin := combatState.Entities[input.OriginID]
+		if origin == nil {
+			return nil, ForcedMovementOutput{}, fmt.Errorf("origin not found: %s", input.OriginID)
+		}
+
+		start, known := target.Distances[origin.ID]
+		if input.StartingDistance != nil {
+			start, known = *input.StartingDistance, true
+		}
+		if known {
+			// Movement toward the origin closes the gap, anything else widens it
+			end := start + input.Distance
+			if strings.Contains(strings.ToLower(input.Direction), "toward") {
+				end = max(start-input.Distance, 0)
+			}
+			setDistance(target, origin, end)
+			output.DistanceFromOrigin = &end
+			message += fmt.Sprintf(" Now %d feet from %s (was %d).", end, origin.Name, start)
+		}
+	}
+
+	if input.KnocksProne {
+		_, alreadyProne := target.Conditions["prone"]
+		target.Conditions["prone"] = -1
+		output.Prone = true
+		if alreadyProne {
+			message += fmt.Sprintf(" %s remains prone.", target.Name)
+		} else {
+			message += fmt.Sprintf(" %s is knocked prone.", target.Name)
+		}
+	}
+
+	combatState.logEvent("%s", message)
+	output.Message = message
+
+	return nil, output, nil
+}
+
+// setDistance records the distance between two entities in both directions
+func setDistance(a, b *Entity, feet int) {
+	if a.Distances == nil {
+		a.Distances = make(map[string]int)
+	}
+	if b.Distances == nil {
+		b.Distances = make(map[string]int)
+	}
+	a.Distances[b.ID] = feet
+	b.Distances[a.ID] = feet
+}
@@ -0,0 +1,104 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// AddNoteInput defines attaching a free-form note to a combatant
+type AddNoteInput struct {
+	EncounterScope
+	EntityID string `json:"entity_id"`
+	Note     string `json:"note" jsonschema:"Free-form note, e.g. guarding the door or has the amulet"`
+}
+
+type AddNoteOutput struct {
+	EntityID string   `json:"entity_id"`
+	Notes    []string `json:"notes" jsonschema:"Every note on the entity, oldest first"`
+	Message  string   `json:"message"`
+}
+
+func handleAddNote(ctx context.Context, req *mcp.CallToolRequest, input AddNoteInput) (*mcp.CallToolResult, AddNoteOutput, error) {
+	entity := combatState.Entities[input.EntityID]
+	if entity == nil {
+		return nil, AddNoteOutput{}, fmt.Errorf("entity not found: %s", input.EntityID)
+	}
+	note := strings.TrimSpace(input.Note)
+	if note == "" {
+		return nil, AddNoteOutput{}, fmt.Errorf("note is required")
+	}
+	if slices.Contains(entity.Notes, note) {
+		return nil, AddNoteOutput{}, fmt.Errorf("%s already has the note %q", entity.Name, note)
+	}
+
+	entity.Notes = append(entity.Notes, note)
+	combatState.logEvent("Note on %s: %s", entity.Name, note)
+
+	return nil, AddNoteOutput{
+		EntityID: entity.ID,
+		Notes:    entity.Notes,
+		Message:  fmt.Sprintf("Noted on %s: %s. Notes: %s.", entity.Name, note, strings.Join(entity.Notes, "; ")),
+	}, nil
+}
+
+// RemoveNoteInput defines dropping a note from a combatant
+type RemoveNoteInput struct {
+	EncounterScope
+	EntityID string `json:"entity_id"`
+	Note     string `json:"note,omitempty" jsonschema:"Exact text of the note to remove"`
+	All      bool   `json:"all,omitempty" jsonschema:"Remove every note on the entity"`
+}
+
+type RemoveNoteOutput struct {
+	EntityID string   `json:"entity_id"`
+	Removed  []string `json:"removed"`
+	Notes    []string `json:"notes" jsonschema:"Notes left on the entity"`
+	Message  string   `json:"message"`
+}
+
+func handleRemoveNote(ctx context.Context, req *mcp.CallToolRequest, input RemoveNoteInput) (*mcp.CallToolResult, RemoveNoteOutput, error) {
+	entity := combatState.Entities[input.EntityID]
+	if entity == nil {
+		return nil, RemoveNoteOutput{}, fmt.Errorf("entity not found: %s", input.EntityID)
+	}
+
+	var removed []string
+	switch {
+	case input.All:
+		removed = entity.Notes
+		entity.Notes = nil
+	case input.Note == "":
+		return nil, RemoveNoteOutput{}, fmt.Errorf("give the note to remove, or all")
+	default:
+		note := strings.TrimSpace(input.Note)
+		i := slices.Index(entity.Notes, note)
+		if i < 0 {
+			return nil, RemoveNoteOutput{}, fmt.Errorf("%s has no note %q", entity.Name, note)
+		}
+		removed = []string{note}
+		entity.Notes = slices.Delete(entity.Notes, i, i+1)
+	}
+	if removed == nil {
+		removed = []string{}
+	}
+	notes := entity.Notes
+	if notes == nil {
+		notes = []string{}
+	}
+
+	message := fmt.Sprintf("Removed %d notes from %s.", len(removed), entity.Name)
+	if len(notes) > 0 {
+		message += fmt.Sprintf(" Notes left: %s.", strings.Join(notes, "; "))
+	}
+
+	return nil, RemoveNoteOutput{
+		EntityID: entity.ID,
+		Removed:  removed,
+		Notes:    notes,
+		Message:  message,
+	}, nil
+}
@@ -0,0 +1,161 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// OngoingEffect deals damage at the start of the afflicted creature's turn until a save ends it
+type OngoingEffect struct {
+	Name            string
+	DamageDice      string
+	DamageType      string
+	SaveType        string // "" means no save can end the effect early
+	DC              int
+	RoundsRemaining int    // -1 = until saved against
+	Condition       string // condition imposed while the effect lasts, removed with it
+}
+
+// OngoingSaveEffectInput defines registering a recurring damage effect
+type OngoingSaveEffectInput struct {
+	EncounterScope
+	TargetID   string `json:"target_id"`
+	Name       string `json:"name" jsonschema:"Effect name, e.g. Wyvern Poison"`
+	DamageDice string `json:"damage_dice" jsonschema:"Damage rolled at the start of each turn, e.g. 1d6"`
+	DamageType string `json:"damage_type"`
+	SaveType   string `json:"save_type,omitempty" jsonschema:"Save that ends the effect (STR, DEX, CON, INT, WIS, CHA)"`
+	DC         int    `json:"dc,omitempty" jsonschema:"DC of the save that ends the effect"`
+	Duration   int    `json:"duration,omitempty" jsonschema:"Maximum rounds the effect lasts, 0 or -1 for until saved"`
+	Condition  string `json:"condition,omitempty" jsonschema:"Condition imposed while the effect lasts (e.g. poisoned)"`
+}
+
+type OngoingSaveEffectOutput struct {
+	Message string `json:"message"`
+}
+
+func handleOngoingSaveEffect(ctx context.Context, req *mcp.CallToolRequest, input OngoingSaveEffectInput) (*mcp.CallToolResult, OngoingSaveEffectOutput, error) {
+	target := combatState.Entities[input.TargetID]
+	if target == nil {
+		return nil, OngoingSaveEffectOutput{}, fmt.Errorf("target not found: %s", input.TargetID)
+	}
+	if _, _, _, err := parseDice(input.DamageDice); err != nil {
+		return nil, OngoingSaveEffectOutput{}, err
+	}
+	if input.SaveType != "" && input.DC <= 0 {
+		return nil, OngoingSaveEffectOutput{}, fmt.Errorf("a save type needs a DC")
+	}
+	if input.SaveType == "" && input.Duration <= 0 {
+		return nil, OngoingSaveEffectOutput{}, fmt.Errorf("an effect with no save needs a duration")
+	}
+
+	condition := ""
+	if input.Condition != "" {
+		var err error
+		if condition, err = canonicalCondition(input.Condition); err != nil {
+			return nil, OngoingSaveEffectOutput{}, err
+		}
+	}
+
+	duration := input.Duration
+	if duration <= 0 {
+		duration = -1
+	}
+
+	effect := &OngoingEffect{
+		Name:            input.Name,
+		DamageDice:      input.DamageDice,
+		DamageType:      input.DamageType,
+		SaveType:        strings.ToUpper(input.SaveType),
+		DC:              input.DC,
+		RoundsRemaining: duration,
+		Condition:       condition,
+	}
+	if effect.Condition != "" {
+		target.Conditions[effect.Condition] = -1
+	}
+
+	replaced := addOngoingEffect(target, effect)
+
+	message := fmt.Sprintf("%s suffers %s: %s %s damage at the start of each turn", target.Name, effect.Name, effect.DamageDice, effect.DamageType)
+	if effect.SaveType != "" {
+		message += fmt.Sprintf(", DC %d %s save ends", effect.DC, effect.SaveType)
+	}
+	if duration > 0 {
+		message += fmt.Sprintf(", lasting up to %d rounds", duration)
+	}
+	if effect.Condition != "" {
+		message += fmt.Sprintf(", %s until it ends", effect.Condition)
+	}
+	if replaced {
+		message += " (refreshed)"
+	}
+
+	return nil, OngoingSaveEffectOutput{Message: message + "."}, nil
+}
+
+// addOngoingEffect attaches an ongoing effect to the target, refreshing an existing
+// effect of the same name rather than stacking it, and reports whether one was replaced
+func addOngoingEffect(target *Entity, effect *OngoingEffect) bool {
+	for i, existing := range target.OngoingEffects {
+		if strings.EqualFold(existing.Name, effect.Name) {
+			target.OngoingEffects[i] = effect
+			return true
+		}
+	}
+	target.OngoingEffects = append(target.OngoingEffects, effect)
+	return false
+}
+
+// processOngoingEffects rolls each ongoing effect's damage, settling it like
+// apply_damage, then its save, removing effects that are saved against or run out,
+// and returns a description of each tick
+func (cs *CombatState) processOngoingEffects(e *Entity) []string {
+	effects := []string{}
+	remaining := []*OngoingEffect{}
+
+	for _, effect := range e.OngoingEffects {
+		roll, err := rollDice(effect.DamageDice, 1)
+		if err != nil {
+			effects = append(effects, fmt.Sprintf("%s: %v", effect.Name, err))
+			continue
+		}
+		hpBefore := e.CurrentHP
+		dealt, modifier := applyDamage(e, roll.Total, effect.DamageType)
+		line := fmt.Sprintf("%s deals %d %s damage%s (%d HP left)", effect.Name, dealt, effect.DamageType, modifier, e.CurrentHP)
+		if after := cs.afterDamage(e, hpBefore, dealt, false, false); after.Note != "" {
+			line += "." + strings.TrimSuffix(after.Note, ".")
+		}
+
+		ended := false
+		if effect.SaveType != "" {
+			save := rollSavingThrow(e, effect.SaveType, effect.DC)
+			line += fmt.Sprintf("; %s save: %s", save.Ability, save.describe(e, effect.DC))
+			if save.Success {
+				ended = true
+				line += ", effect ends"
+			}
+		}
+
+		if !ended && effect.RoundsRemaining > 0 {
+			effect.RoundsRemaining--
+			if effect.RoundsRemaining == 0 {
+				ended = true
+				line += "; duration expired, effect ends"
+			}
+		}
+
+		if !ended {
+			remaining = append(remaining, effect)
+		} else if effect.Condition != "" {
+			delete(e.Conditions, effect.Condition)
+			line += fmt.Sprintf(" and %s is no longer %s", e.Name, effect.Condition)
+		}
+		effects = append(effects, line)
+	}
+
+	e.OngoingEffects = remaining
+	return effects
+}
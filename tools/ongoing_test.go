@@ -0,0 +1,42 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestOngoingEffectTick(t *testing.T) {
+	ctx := context.Background()
+	startTestCombat(t,
+		EntityInit{ID: "fighter", Name: "Fighter", Initiative: 15, HP: 30, AC: 16},
+		EntityInit{ID: "shaman", Name: "Shaman", Initiative: 10, HP: 20, AC: 13, IsMonster: true},
+	)
+	shaman := combatState.Entities["shaman"]
+	shaman.Concentrating = "Bless"
+	// Paralyzed creatures fail DEX saves automatically, even against DC 1
+	shaman.Conditions["paralyzed"] = -1
+
+	if _, _, err := handleOngoingSaveEffect(ctx, nil, OngoingSaveEffectInput{
+		TargetID: "shaman", Name: "Acid", DamageDice: "2", DamageType: "acid", SaveType: "DEX", DC: 1, Condition: "poisond",
+	}); err == nil {
+		t.Error("an unknown condition was accepted")
+	}
+	if _, _, err := handleOngoingSaveEffect(ctx, nil, OngoingSaveEffectInput{
+		TargetID: "shaman", Name: "Acid", DamageDice: "2", DamageType: "acid", SaveType: "DEX", DC: 1, Condition: "Poisoned",
+	}); err != nil {
+		t.Fatalf("ongoing_save_effect: %v", err)
+	}
+	if _, ok := shaman.Conditions["poisoned"]; !ok {
+		t.Fatalf("conditions = %v, want poisoned stored under its canonical name", shaman.Conditions)
+	}
+
+	turn := combatState.advanceTurn()
+	tick := strings.Join(turn.Effects, "\n")
+	if !strings.Contains(tick, "must make a DC 10 CON save to keep concentrating on Bless") {
+		t.Errorf("the tick didn't call for a concentration save:\n%s", tick)
+	}
+	if !strings.Contains(tick, "automatically fails") || len(shaman.OngoingEffects) != 1 {
+		t.Errorf("the paralyzed shaman's DEX save didn't fail automatically:\n%s", tick)
+	}
+}
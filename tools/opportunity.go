@@ -0,0 +1,160 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kiriyms/dungeon-master-mcp/resources"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// defaultReach is the melee reach of a creature without a longer weapon or limb
+const defaultReach = 5
+
+// reachPattern finds a stat block action's reach, e.g. "Reach 20 ft."
+var reachPattern = regexp.MustCompile(`(?i)reach (\d+) ?ft`)
+
+// statBlockReach returns the longest reach among a monster's actions
+func statBlockReach(monster resources.MonsterStat) int {
+	reach := defaultReach
+	for _, action := range monster.Actions {
+		if m := reachPattern.FindStringSubmatch(action.Description); m != nil {
+			feet, _ := strconv.Atoi(m[1])
+			reach = max(reach, feet)
+		}
+	}
+	return reach
+}
+
+// provokedBy returns the enemies whose reach a creature leaves by moving from one
+// grid point to another. Only the endpoints are compared, so a path that passes
+// through an enemy's reach without starting in it isn't caught.
+func (cs *CombatState) provokedBy(mover *Entity, from, to [2]int) []*Entity {
+	provoked := []*Entity{}
+	for _, e := range cs.Entities {
+		if e == mover || e.IsMonster == mover.IsMonster || e.Position == nil || e.CurrentHP <= 0 {
+			continue
+		}
+		if e.ReactionUsed || e.IsIncapacitated() {
+			continue
+		}
+		reach := max(e.Reach, defaultReach)
+		if gridDistance(from, *e.Position) <= reach && gridDistance(to, *e.Position) > reach {
+			provoked = append(provoked, e)
+		}
+	}
+	slices.SortFunc(provoked, func(a, b *Entity) int { return strings.Compare(a.ID, b.ID) })
+	return provoked
+}
+
+// opportunityAttack spends the attacker's reaction on a single attack against the target
+func (cs *CombatState) opportunityAttack(attacker, target *Entity, action resources.MonsterAction) (AttackResult, error) {
+	result, err := resolveAttack(attacker, target, action, false)
+	if err != nil {
+		return AttackResult{}, err
+	}
+	attacker.ReactionUsed = true
+	cs.logEvent("%s makes an opportunity attack against %s", attacker.Name, target.Name)
+	return result, nil
+}
+
+// DisengageInput defines taking the Disengage action
+type DisengageInput struct {
+	EncounterScope
+	EntityID    string `json:"entity_id"`
+	BonusAction bool   `json:"bonus_action,omitempty" jsonschema:"Disengage as a bonus action, e.g. with Cunning Action"`
+}
+
+type DisengageOutput struct {
+	Message string `json:"message"`
+}
+
+func handleDisengage(ctx context.Context, req *mcp.CallToolRequest, input DisengageInput) (*mcp.CallToolResult, DisengageOutput, error) {
+	entity := combatState.Entities[input.EntityID]
+	if entity == nil {
+		return nil, DisengageOutput{}, fmt.Errorf("entity not found: %s", input.EntityID)
+	}
+	available := entity.actionEconomy()
+	if input.BonusAction {
+		if !available.BonusAction {
+			return nil, DisengageOutput{}, fmt.Errorf("%s has no bonus action available", entity.Name)
+		}
+		entity.BonusActionUsed = true
+	} else {
+		if !available.Action {
+			return nil, DisengageOutput{}, fmt.Errorf("%s has no action available", entity.Name)
+		}
+		entity.ActionUsed = true
+	}
+
+	entity.Disengaged = true
+	combatState.logEvent("%s disengages", entity.Name)
+
+	return nil, DisengageOutput{
+		Message: fmt.Sprintf("%s disengages; its movement doesn't provoke opportunity attacks for the rest of the turn.", entity.Name),
+	}, nil
+}
+
+// OpportunityAttackInput defines an opportunity attack
+type OpportunityAttackInput struct {
+	EncounterScope
+	AttackerID  string `json:"attacker_id" jsonschema:"Creature whose reach was left"`
+	TargetID    string `json:"target_id" jsonschema:"Creature that provoked the attack"`
+	ActionName  string `json:"action_name,omitempty" jsonschema:"Stat block attack to use (defaults to the first attack); ignored when damage_dice is given"`
+	AttackBonus int    `json:"attack_bonus,omitempty" jsonschema:"Attack bonus for an attack without a stat block"`
+	DamageDice  string `json:"damage_dice,omitempty" jsonschema:"Damage dice for an attack without a stat block, e.g. 1d8+3"`
+	DamageType  string `json:"damage_type,omitempty"`
+}
+
+type OpportunityAttackOutput struct {
+	Attack      AttackResult `json:"attack"`
+	RemainingHP int          `json:"remaining_hp"`
+	Message     string       `json:"message"`
+}
+
+func handleOpportunityAttack(ctx context.Context, req *mcp.CallToolRequest, input OpportunityAttackInput) (*mcp.CallToolResult, OpportunityAttackOutput, error) {
+	attacker := combatState.Entities[input.AttackerID]
+	if attacker == nil {
+		return nil, OpportunityAttackOutput{}, fmt.Errorf("attacker not found: %s", input.AttackerID)
+	}
+	target := combatState.Entities[input.TargetID]
+	if target == nil {
+		return nil, OpportunityAttackOutput{}, fmt.Errorf("target not found: %s", input.TargetID)
+	}
+	if !attacker.actionEconomy().Reaction {
+		return nil, OpportunityAttackOutput{}, fmt.Errorf("%s has no reaction available", attacker.Name)
+	}
+
+	var action resources.MonsterAction
+	if input.DamageDice != "" {
+		if _, _, _, err := parseDice(input.DamageDice); err != nil {
+			return nil, OpportunityAttackOutput{}, err
+		}
+		action = resources.MonsterAction{
+			Name:        "opportunity attack",
+			AttackBonus: input.AttackBonus,
+			DamageDice:  input.DamageDice,
+			DamageType:  input.DamageType,
+		}
+	} else {
+		var err error
+		if action, err = attackAction(attacker, input.ActionName); err != nil {
+			return nil, OpportunityAttackOutput{}, err
+		}
+	}
+
+	result, err := combatState.opportunityAttack(attacker, target, action)
+	if err != nil {
+		return nil, OpportunityAttackOutput{}, err
+	}
+
+	return nil, OpportunityAttackOutput{
+		Attack:      result,
+		RemainingHP: target.CurrentHP,
+		Message:     fmt.Sprintf("Opportunity attack: %s. %s has used its reaction.", result.Message, attacker.Name),
+	}, nil
+}
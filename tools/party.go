@@ -0,0 +1,209 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math/rand"
+	"slices"
+	"strings"
+
+	"github.com/kiriyms/dungeon-master-mcp/resources"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// PartyMember is a player character as stored for reuse across combats
+type PartyMember struct {
+	ID                string         `json:"id,omitempty"`
+	Name              string         `json:"name"`
+	Level             int            `json:"level"`
+	MaxHP             int            `json:"max_hp"`
+	AC                int            `json:"ac"`
+	AbilityScores     map[string]int `json:"ability_scores"`
+	SaveProficiencies []string       `json:"save_proficiencies,omitempty"`
+	Skills            map[string]int `json:"skills,omitempty"`
+	Speed             int            `json:"speed,omitempty"`
+	// Derived on import
+	ProficiencyBonus int            `json:"proficiency_bonus"`
+	SavingThrows     map[string]int `json:"saving_throws"`
+}
+
+// parties holds imported parties by lowercase name; they outlive any single combat
+var parties = map[string][]PartyMember{}
+
+// proficiencyForLevel derives a character's proficiency bonus from its level
+func proficiencyForLevel(level int) int {
+	return 2 + (level-1)/4
+}
+
+// validate checks an imported character, normalizes its ability keys, and derives
+// its proficiency bonus and saving throws
+func (m *PartyMember) validate() error {
+	if m.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if m.Level < 1 || m.Level > 20 {
+		return fmt.Errorf("level must be between 1 and 20, got %d", m.Level)
+	}
+	if m.MaxHP <= 0 {
+		return fmt.Errorf("max_hp must be positive")
+	}
+	if m.AC <= 0 {
+		return fmt.Errorf("ac must be positive")
+	}
+
+	scores := make(map[string]int, len(resources.Abilities))
+	for ability, score := range m.AbilityScores {
+		key := strings.ToUpper(ability)
+		if !slices.Contains(resources.Abilities, key) {
+			return fmt.Errorf("unknown ability: %s", ability)
+		}
+		if score < 1 || score > 30 {
+			return fmt.Errorf("%s score must be between 1 and 30, got %d", key, score)
+		}
+		scores[key] = score
+	}
+	for _, ability := range resources.Abilities {
+		if _, ok := scores[ability]; !ok {
+			return fmt.Errorf("missing %s score", ability)
+		}
+	}
+	m.AbilityScores = scores
+
+	proficient := make([]string, 0, len(m.SaveProficiencies))
+	for _, ability := range m.SaveProficiencies {
+		key := strings.ToUpper(ability)
+		if !slices.Contains(resources.Abilities, key) {
+			return fmt.Errorf("unknown save proficiency: %s", ability)
+		}
+		proficient = append(proficient, key)
+	}
+	m.SaveProficiencies = proficient
+
+	if m.ID == "" {
+		m.ID = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(m.Name)), " ", "-")
+	}
+	m.ProficiencyBonus = proficiencyForLevel(m.Level)
+	m.SavingThrows = make(map[string]int, len(resources.Abilities))
+	for _, ability := range resources.Abilities {
+		bonus := resources.AbilityModifier(scores[ability])
+		if slices.Contains(proficient, ability) {
+			bonus += m.ProficiencyBonus
+		}
+		m.SavingThrows[ability] = bonus
+	}
+	return nil
+}
+
+// ImportPartyInput defines a party import
+type ImportPartyInput struct {
+	PartyName string `json:"party_name" jsonschema:"Name to store the party under, e.g. thursday-group"`
+	Payload   string `json:"payload" jsonschema:"JSON array of characters: name, level, max_hp, ac, ability_scores, save_proficiencies, and optionally id, skills, speed"`
+	Replace   bool   `json:"replace,omitempty" jsonschema:"Replace the stored party instead of adding to it"`
+}
+
+// ImportError is a rejected entry in a party import
+type ImportError struct {
+	Index int    `json:"index" jsonschema:"Position of the entry in the payload"`
+	Name  string `json:"name,omitempty"`
+	Error string `json:"error"`
+}
+
+type ImportPartyOutput struct {
+	Roster  []PartyMember `json:"roster" jsonschema:"Every character now stored in the party"`
+	Errors  []ImportError `json:"errors,omitempty"`
+	Message string        `json:"message"`
+}
+
+func handleImportParty(ctx context.Context, req *mcp.CallToolRequest, input ImportPartyInput) (*mcp.CallToolResult, ImportPartyOutput, error) {
+	name := strings.ToLower(strings.TrimSpace(input.PartyName))
+	if name == "" {
+		return nil, ImportPartyOutput{}, fmt.Errorf("party name is required")
+	}
+	var entries []json.RawMessage
+	if err := json.Unmarshal([]byte(input.Payload), &entries); err != nil {
+		return nil, ImportPartyOutput{}, fmt.Errorf("payload must be a JSON array of characters: %w", err)
+	}
+
+	roster := []PartyMember{}
+	if !input.Replace {
+		roster = slices.Clone(parties[name])
+	}
+	output := ImportPartyOutput{}
+	imported := 0
+	for i, raw := range entries {
+		var member PartyMember
+		if err := json.Unmarshal(raw, &member); err != nil {
+			output.Errors = append(output.Errors, ImportError{Index: i, Error: err.Error()})
+			continue
+		}
+		if err := member.validate(); err != nil {
+			output.Errors = append(output.Errors, ImportError{Index: i, Name: member.Name, Error: err.Error()})
+			continue
+		}
+		// Re-importing a character updates it in place
+		if at := slices.IndexFunc(roster, func(m PartyMember) bool { return m.ID == member.ID }); at >= 0 {
+			roster[at] = member
+		} else {
+			roster = append(roster, member)
+		}
+		imported++
+	}
+	parties[name] = roster
+
+	output.Roster = roster
+	names := []string{}
+	for _, m := range roster {
+		names = append(names, fmt.Sprintf("%s (level %d, %d HP, AC %d)", m.Name, m.Level, m.MaxHP, m.AC))
+	}
+	output.Message = fmt.Sprintf("Imported %d of %d characters into %s: %s.", imported, len(entries), name, strings.Join(names, ", "))
+	if len(output.Errors) > 0 {
+		output.Message += fmt.Sprintf(" %d entries were rejected.", len(output.Errors))
+	}
+
+	return nil, output, nil
+}
+
+// partyCombatants turns a stored party into combatants, rolling d20 + DEX for
+// members without a given initiative and describing those rolls
+func partyCombatants(partyName string, initiative map[string]int) ([]EntityInit, []string, error) {
+	roster, ok := parties[strings.ToLower(partyName)]
+	if !ok {
+		return nil, nil, fmt.Errorf("party not found: %s", partyName)
+	}
+
+	members := make([]EntityInit, 0, len(roster))
+	rolled := []string{}
+	for _, m := range roster {
+		init, ok := initiative[m.ID]
+		if !ok {
+			roll := rand.Intn(20) + 1
+			init = roll + resources.AbilityModifier(m.AbilityScores["DEX"])
+			rolled = append(rolled, fmt.Sprintf("%s %d", m.Name, init))
+		}
+		members = append(members, EntityInit{
+			ID:               m.ID,
+			Name:             m.Name,
+			Initiative:       init,
+			HP:               m.MaxHP,
+			AC:               m.AC,
+			Speed:            m.Speed,
+			AbilityScores:    maps.Clone(m.AbilityScores),
+			Skills:           maps.Clone(m.Skills),
+			ProficiencyBonus: m.ProficiencyBonus,
+			SavingThrows:     proficientSaves(m),
+		})
+	}
+	return members, rolled, nil
+}
+
+// proficientSaves returns the member's bonuses for its proficient saves only; the
+// others fall back to the ability modifier in combat
+func proficientSaves(m PartyMember) map[string]int {
+	saves := make(map[string]int, len(m.SaveProficiencies))
+	for _, ability := range m.SaveProficiencies {
+		saves[ability] = m.SavingThrows[ability]
+	}
+	return saves
+}
@@ -0,0 +1,211 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// PendingEffect is a declared save-or-suffer effect waiting for its targets to roll
+type PendingEffect struct {
+	ID                string
+	Name              string
+	SourceID          string // creature the effect comes from, credited with its damage
+	SaveType          string
+	DC                int
+	TargetIDs         []string // targets that have yet to roll
+	DamageDice        string
+	DamageType        string
+	HalfOnSuccess     bool
+	RolledDamage      *int // damage is rolled once and shared by every target
+	Condition         string
+	ConditionDuration int
+}
+
+// RegisterPendingEffectInput defines declaring an effect before its saves are rolled
+type RegisterPendingEffectInput struct {
+	EncounterScope
+	Name              string   `json:"name" jsonschema:"Ability name, e.g. Mind Blast"`
+	SourceID          string   `json:"source_id,omitempty" jsonschema:"Creature using the ability, credited with the damage it deals"`
+	TargetIDs         []string `json:"target_ids" jsonschema:"Creatures that must save"`
+	SaveType          string   `json:"save_type" jsonschema:"STR, DEX, CON, INT, WIS, CHA"`
+	DC                int      `json:"dc"`
+	DamageDice        string   `json:"damage_dice,omitempty" jsonschema:"Damage on a failed save, e.g. 4d6"`
+	DamageType        string   `json:"damage_type,omitempty"`
+	HalfOnSuccess     bool     `json:"half_on_success,omitempty" jsonschema:"Whether a successful save still takes half damage"`
+	Condition         string   `json:"condition,omitempty" jsonschema:"Condition imposed on a failed save"`
+	ConditionDuration int      `json:"condition_duration,omitempty" jsonschema:"Condition duration in turns, -1 for permanent (defaults to 1)"`
+}
+
+type RegisterPendingEffectOutput struct {
+	EffectID string `json:"effect_id"`
+	Message  string `json:"message"`
+}
+
+func handleRegisterPendingEffect(ctx context.Context, req *mcp.CallToolRequest, input RegisterPendingEffectInput) (*mcp.CallToolResult, RegisterPendingEffectOutput, error) {
+	if len(input.TargetIDs) == 0 {
+		return nil, RegisterPendingEffectOutput{}, fmt.Errorf("at least one target is required")
+	}
+	for _, id := range input.TargetIDs {
+		if combatState.Entities[id] == nil {
+			return nil, RegisterPendingEffectOutput{}, fmt.Errorf("target not found: %s", id)
+		}
+	}
+	if input.SourceID != "" && combatState.Entities[input.SourceID] == nil {
+		return nil, RegisterPendingEffectOutput{}, fmt.Errorf("source not found: %s", input.SourceID)
+	}
+	if input.DC <= 0 {
+		return nil, RegisterPendingEffectOutput{}, fmt.Errorf("DC must be positive")
+	}
+	if input.DamageDice == "" && input.Condition == "" {
+		return nil, RegisterPendingEffectOutput{}, fmt.Errorf("a pending effect needs damage or a condition")
+	}
+	if input.DamageDice != "" {
+		if _, _, _, err := parseDice(input.DamageDice); err != nil {
+			return nil, RegisterPendingEffectOutput{}, err
+		}
+	}
+
+	duration := input.ConditionDuration
+	if duration == 0 {
+		duration = 1
+	}
+
+	if combatState.PendingEffects == nil {
+		combatState.PendingEffects = make(map[string]*PendingEffect)
+	}
+	combatState.PendingSeq++
+	effect := &PendingEffect{
+		ID:                fmt.Sprintf("pending-%d", combatState.PendingSeq),
+		Name:              input.Name,
+		SourceID:          input.SourceID,
+		SaveType:          strings.ToUpper(input.SaveType),
+		DC:                input.DC,
+		TargetIDs:         slices.Clone(input.TargetIDs),
+		DamageDice:        input.DamageDice,
+		DamageType:        input.DamageType,
+		HalfOnSuccess:     input.HalfOnSuccess,
+		Condition:         input.Condition,
+		ConditionDuration: duration,
+	}
+	combatState.PendingEffects[effect.ID] = effect
+
+	consequences := []string{}
+	if effect.DamageDice != "" {
+		consequences = append(consequences, fmt.Sprintf("%s %s damage", effect.DamageDice, effect.DamageType))
+	}
+	if effect.Condition != "" {
+		consequences = append(consequences, effect.Condition)
+	}
+
+	return nil, RegisterPendingEffectOutput{
+		EffectID: effect.ID,
+		Message: fmt.Sprintf("%s declared against %d targets: DC %d %s save or %s.",
+			effect.Name, len(effect.TargetIDs), effect.DC, effect.SaveType, strings.Join(consequences, " and ")),
+	}, nil
+}
+
+// ResolvePendingSaveInput defines rolling one target's save against a pending effect
+type ResolvePendingSaveInput struct {
+	EncounterScope
+	EffectID string `json:"effect_id"`
+	TargetID string `json:"target_id"`
+	Evasion  bool   `json:"evasion,omitempty" jsonschema:"Target has Evasion: a successful DEX save takes no damage and a failed one takes half"`
+}
+
+type ResolvePendingSaveOutput struct {
+	Roll                    int      `json:"roll"`
+	Bonus                   int      `json:"bonus"`
+	Total                   int      `json:"total"`
+	Success                 bool     `json:"success"`
+	UsedLegendaryResistance bool     `json:"used_legendary_resistance"`
+	DamageDealt             int      `json:"damage_dealt"`
+	ConditionApplied        string   `json:"condition_applied,omitempty"`
+	SpecialRule             string   `json:"special_rule,omitempty" jsonschema:"Rule that changed the damage outcome, e.g. evasion"`
+	RemainingTargets        []string `json:"remaining_targets" jsonschema:"Targets still to roll; the effect is cleared once empty"`
+	Message                 string   `json:"message"`
+}
+
+func handleResolvePendingSave(ctx context.Context, req *mcp.CallToolRequest, input ResolvePendingSaveInput) (*mcp.CallToolResult, ResolvePendingSaveOutput, error) {
+	effect := combatState.PendingEffects[input.EffectID]
+	if effect == nil {
+		return nil, ResolvePendingSaveOutput{}, fmt.Errorf("pending effect not found: %s", input.EffectID)
+	}
+	index := slices.Index(effect.TargetIDs, input.TargetID)
+	if index < 0 {
+		return nil, ResolvePendingSaveOutput{}, fmt.Errorf("%s is not waiting on a save from %s", effect.Name, input.TargetID)
+	}
+	target := combatState.Entities[input.TargetID]
+	if target == nil {
+		return nil, ResolvePendingSaveOutput{}, fmt.Errorf("target not found: %s", input.TargetID)
+	}
+
+	save := rollSavingThrow(target, effect.SaveType, effect.DC)
+	output := ResolvePendingSaveOutput{
+		Roll:                    save.Roll,
+		Bonus:                   save.Bonus,
+		Total:                   save.Total,
+		Success:                 save.Success,
+		UsedLegendaryResistance: save.UsedLegendaryResistance,
+	}
+	message := fmt.Sprintf("%s save against %s: %s", effect.SaveType, effect.Name, save.describe(target, effect.DC))
+
+	// Evasion only improves DEX saves against effects that deal half damage on a success
+	evasion := input.Evasion && effect.SaveType == "DEX" && effect.HalfOnSuccess
+	if input.Evasion && !evasion {
+		message += ". Evasion doesn't apply (needs a DEX save for half damage)"
+	}
+
+	if effect.DamageDice != "" && (!output.Success || effect.HalfOnSuccess) {
+		if effect.RolledDamage == nil {
+			roll, err := rollDice(effect.DamageDice, 1)
+			if err != nil {
+				return nil, ResolvePendingSaveOutput{}, err
+			}
+			effect.RolledDamage = &roll.Total
+		}
+		damage := *effect.RolledDamage
+		switch {
+		case evasion && output.Success:
+			damage = 0
+			output.SpecialRule = "evasion: no damage on a successful save"
+		case evasion:
+			damage /= 2
+			output.SpecialRule = "evasion: half damage on a failed save"
+		case output.Success:
+			damage /= 2
+		}
+
+		if damage > 0 {
+			dealt, modifier := applyDamage(target, damage, effect.DamageType)
+			output.DamageDealt = dealt
+			if source := combatState.Entities[effect.SourceID]; source != nil {
+				source.DamageDealt += dealt
+			}
+			message += fmt.Sprintf(". Takes %d %s damage%s (%d HP left)", dealt, effect.DamageType, modifier, target.CurrentHP)
+		} else {
+			message += ". Takes no damage"
+		}
+		if output.SpecialRule != "" {
+			message += fmt.Sprintf(" (%s)", output.SpecialRule)
+		}
+	}
+	if effect.Condition != "" && !output.Success {
+		target.Conditions[effect.Condition] = effect.ConditionDuration
+		output.ConditionApplied = effect.Condition
+		message += fmt.Sprintf(". %s is now %s", target.Name, effect.Condition)
+	}
+
+	effect.TargetIDs = slices.Delete(effect.TargetIDs, index, index+1)
+	output.RemainingTargets = slices.Clone(effect.TargetIDs)
+	if len(effect.TargetIDs) == 0 {
+		delete(combatState.PendingEffects, effect.ID)
+		message += fmt.Sprintf(". %s is fully resolved", effect.Name)
+	}
+	output.Message = message + "."
+
+	return nil, output, nil
+}
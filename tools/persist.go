@@ -0,0 +1,166 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// SaveDirEnv names the environment variable pointing at the directory for saved combats
+const SaveDirEnv = "DM_SAVE_DIR"
+
+// saveNamePattern keeps save names to plain file names, so they can't escape the save directory
+var saveNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// saveDir returns the directory saved combats live in, defaulting to the user's config directory
+func saveDir() (string, error) {
+	if dir := os.Getenv(SaveDirEnv); dir != "" {
+		return dir, nil
+	}
+	config, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("no save directory: set %s (%w)", SaveDirEnv, err)
+	}
+	return filepath.Join(config, "dungeon-master-mcp", "combats"), nil
+}
+
+// savePath returns the file a named save is stored in
+func savePath(name string) (string, error) {
+	if !saveNamePattern.MatchString(name) {
+		return "", fmt.Errorf("invalid save name %q: use letters, digits, - and _", name)
+	}
+	dir, err := saveDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name+".json"), nil
+}
+
+// savedCombats lists the names of the combats in the save directory
+func savedCombats() []string {
+	dir, err := saveDir()
+	if err != nil {
+		return nil
+	}
+	paths, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	names := []string{}
+	for _, path := range paths {
+		names = append(names, strings.TrimSuffix(filepath.Base(path), ".json"))
+	}
+	return names
+}
+
+// validate checks that a decoded combat state is internally consistent
+func (cs *CombatState) validate() error {
+	for _, id := range cs.TurnOrder {
+		if cs.Entities[id] == nil {
+			return fmt.Errorf("turn order lists unknown entity %s", id)
+		}
+	}
+	if len(cs.TurnOrder) > 0 && (cs.CurrentTurn < 0 || cs.CurrentTurn >= len(cs.TurnOrder)) {
+		return fmt.Errorf("current turn %d is outside the turn order", cs.CurrentTurn)
+	}
+	return nil
+}
+
+// SaveCombatInput defines saving the combat to disk
+type SaveCombatInput struct {
+	EncounterScope
+	Name      string `json:"name" jsonschema:"Save name, e.g. dragon-lair (letters, digits, - and _)"`
+	Overwrite bool   `json:"overwrite,omitempty" jsonschema:"Replace an existing save with the same name"`
+}
+
+type SaveCombatOutput struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func handleSaveCombat(ctx context.Context, req *mcp.CallToolRequest, input SaveCombatInput) (*mcp.CallToolResult, SaveCombatOutput, error) {
+	path, err := savePath(input.Name)
+	if err != nil {
+		return nil, SaveCombatOutput{}, err
+	}
+	if _, err := os.Stat(path); err == nil && !input.Overwrite {
+		return nil, SaveCombatOutput{}, fmt.Errorf("a combat is already saved as %s; set overwrite to replace it", input.Name)
+	}
+
+	data, err := json.MarshalIndent(combatState, "", "  ")
+	if err != nil {
+		return nil, SaveCombatOutput{}, fmt.Errorf("serializing combat state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, SaveCombatOutput{}, fmt.Errorf("creating save directory: %w", err)
+	}
+
+	// Write to a temporary file first so a failed write never clobbers an older save
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return nil, SaveCombatOutput{}, fmt.Errorf("writing save: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return nil, SaveCombatOutput{}, fmt.Errorf("writing save: %w", err)
+	}
+
+	return nil, SaveCombatOutput{
+		Path:    path,
+		Message: fmt.Sprintf("Saved combat as %s: %d combatants, round %d.", input.Name, len(combatState.Entities), combatState.RoundNumber),
+	}, nil
+}
+
+// LoadCombatInput defines restoring a combat saved to disk
+type LoadCombatInput struct {
+	EncounterScope
+	Name string `json:"name" jsonschema:"Name the combat was saved under"`
+}
+
+type LoadCombatOutput struct {
+	TurnOrder       []string `json:"turn_order"`
+	RoundNumber     int      `json:"round_number"`
+	CurrentEntityID string   `json:"current_entity_id,omitempty"`
+	Message         string   `json:"message"`
+}
+
+func handleLoadCombat(ctx context.Context, req *mcp.CallToolRequest, input LoadCombatInput) (*mcp.CallToolResult, LoadCombatOutput, error) {
+	path, err := savePath(input.Name)
+	if err != nil {
+		return nil, LoadCombatOutput{}, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, LoadCombatOutput{}, fmt.Errorf("saved combat not found: %s (saved: %s)", input.Name, strings.Join(savedCombats(), ", "))
+	}
+	if err != nil {
+		return nil, LoadCombatOutput{}, fmt.Errorf("reading save: %w", err)
+	}
+
+	restored, err := decodeCombatState(data)
+	if err != nil {
+		return nil, LoadCombatOutput{}, fmt.Errorf("decoding save %s: %w", input.Name, err)
+	}
+	if err := restored.validate(); err != nil {
+		return nil, LoadCombatOutput{}, fmt.Errorf("save %s is corrupt: %w", input.Name, err)
+	}
+
+	*combatState = *restored
+
+	output := LoadCombatOutput{
+		TurnOrder:   combatState.TurnOrder,
+		RoundNumber: combatState.RoundNumber,
+	}
+	message := fmt.Sprintf("Loaded combat %s: %d combatants, round %d", input.Name, len(combatState.Entities), combatState.RoundNumber)
+	if len(combatState.TurnOrder) > 0 {
+		output.CurrentEntityID = combatState.TurnOrder[combatState.CurrentTurn]
+		message += fmt.Sprintf(", %s's turn", combatState.Entities[output.CurrentEntityID].Name)
+	}
+	output.Message = message + "."
+
+	return nil, output, nil
+}
@@ -0,0 +1,148 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// gridDistance is the distance in feet between two grid points, counting a diagonal
+// step the same as a straight one as on a standard 5-foot grid
+func gridDistance(a, b [2]int) int {
+	dx, dy := a[0]-b[0], a[1]-b[1]
+	return max(dx, -dx, dy, -dy)
+}
+
+// distanceBetween returns the feet between two entities from their grid positions,
+// falling back to a tracked relative distance when either isn't on the grid
+func distanceBetween(a, b *Entity) (int, bool) {
+	if a.Position != nil && b.Position != nil {
+		return gridDistance(*a.Position, *b.Position), true
+	}
+	feet, ok := a.Distances[b.ID]
+	return feet, ok
+}
+
+// SetPositionInput defines placing an entity on the grid
+type SetPositionInput struct {
+	EncounterScope
+	EntityID string `json:"entity_id"`
+	X        int    `json:"x" jsonschema:"Feet along the horizontal axis"`
+	Y        int    `json:"y" jsonschema:"Feet along the vertical axis"`
+	Clear    bool   `json:"clear,omitempty" jsonschema:"Remove the entity from the grid instead"`
+}
+
+type SetPositionOutput struct {
+	Position *[2]int `json:"position,omitempty"`
+	Message  string  `json:"message"`
+}
+
+func handleSetPosition(ctx context.Context, req *mcp.CallToolRequest, input SetPositionInput) (*mcp.CallToolResult, SetPositionOutput, error) {
+	entity := combatState.Entities[input.EntityID]
+	if entity == nil {
+		return nil, SetPositionOutput{}, fmt.Errorf("entity not found: %s", input.EntityID)
+	}
+
+	if input.Clear {
+		entity.Position = nil
+		return nil, SetPositionOutput{Message: fmt.Sprintf("%s is no longer on the grid.", entity.Name)}, nil
+	}
+
+	entity.Position = &[2]int{input.X, input.Y}
+	return nil, SetPositionOutput{
+		Position: entity.Position,
+		Message:  fmt.Sprintf("%s is at (%d, %d).", entity.Name, input.X, input.Y),
+	}, nil
+}
+
+// CreaturesInRangeInput defines an area query around a point or entity
+type CreaturesInRangeInput struct {
+	EncounterScope
+	CenterID      string  `json:"center_id,omitempty" jsonschema:"Entity at the center of the area"`
+	Center        *[2]int `json:"center,omitempty" jsonschema:"Grid point at the center of the area, in feet"`
+	Radius        int     `json:"radius" jsonschema:"Radius in feet"`
+	IncludeCenter bool    `json:"include_center,omitempty" jsonschema:"Include the center entity itself"`
+}
+
+// RangeMatch is a creature inside the area
+type RangeMatch struct {
+	EntityID string `json:"entity_id"`
+	Name     string `json:"name"`
+	Distance int    `json:"distance" jsonschema:"Feet from the center"`
+}
+
+type CreaturesInRangeOutput struct {
+	TargetIDs []string     `json:"target_ids" jsonschema:"Matched entity IDs, ready to use as an area effect's targets"`
+	Matches   []RangeMatch `json:"matches"`
+	Unplaced  []string     `json:"unplaced,omitempty" jsonschema:"Entities with no position or tracked distance, which the DM must judge"`
+	Message   string       `json:"message"`
+}
+
+func handleCreaturesInRange(ctx context.Context, req *mcp.CallToolRequest, input CreaturesInRangeInput) (*mcp.CallToolResult, CreaturesInRangeOutput, error) {
+	if input.Radius < 0 {
+		return nil, CreaturesInRangeOutput{}, fmt.Errorf("radius can't be negative")
+	}
+	if (input.CenterID == "") == (input.Center == nil) {
+		return nil, CreaturesInRangeOutput{}, fmt.Errorf("give exactly one of center_id or center")
+	}
+
+	var center *Entity
+	if input.CenterID != "" {
+		if center = combatState.Entities[input.CenterID]; center == nil {
+			return nil, CreaturesInRangeOutput{}, fmt.Errorf("entity not found: %s", input.CenterID)
+		}
+	}
+
+	output := CreaturesInRangeOutput{TargetIDs: []string{}, Matches: []RangeMatch{}}
+	for _, id := range sortedKeys(combatState.Entities) {
+		e := combatState.Entities[id]
+		var feet int
+		var known bool
+		switch {
+		case center == e:
+			if !input.IncludeCenter {
+				continue
+			}
+			known = true
+		case center != nil:
+			feet, known = distanceBetween(center, e)
+		case e.Position != nil:
+			feet, known = gridDistance(*input.Center, *e.Position), true
+		}
+
+		if !known {
+			output.Unplaced = append(output.Unplaced, id)
+			continue
+		}
+		if feet <= input.Radius {
+			output.Matches = append(output.Matches, RangeMatch{EntityID: id, Name: e.Name, Distance: feet})
+		}
+	}
+	sort.SliceStable(output.Matches, func(i, j int) bool { return output.Matches[i].Distance < output.Matches[j].Distance })
+
+	described := []string{}
+	for _, m := range output.Matches {
+		output.TargetIDs = append(output.TargetIDs, m.EntityID)
+		described = append(described, fmt.Sprintf("%s (%d ft)", m.Name, m.Distance))
+	}
+
+	var where string
+	if center != nil {
+		where = center.Name
+	} else {
+		where = fmt.Sprintf("(%d, %d)", input.Center[0], input.Center[1])
+	}
+	output.Message = fmt.Sprintf("%d creatures within %d ft of %s", len(output.Matches), input.Radius, where)
+	if len(described) > 0 {
+		output.Message += ": " + strings.Join(described, ", ")
+	}
+	output.Message += "."
+	if len(output.Unplaced) > 0 {
+		output.Message += fmt.Sprintf(" %d creatures have no position and need a DM ruling.", len(output.Unplaced))
+	}
+
+	return nil, output, nil
+}
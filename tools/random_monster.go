@@ -0,0 +1,78 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kiriyms/dungeon-master-mcp/resources"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// RandomMonsterInput defines the filter for picking a random monster
+type RandomMonsterInput struct {
+	CR    *float64 `json:"cr,omitempty" jsonschema:"Exact challenge rating"`
+	MinCR *float64 `json:"min_cr,omitempty" jsonschema:"Lowest challenge rating to consider"`
+	MaxCR *float64 `json:"max_cr,omitempty" jsonschema:"Highest challenge rating to consider"`
+	Type  string   `json:"type,omitempty" jsonschema:"Creature type (dragon, humanoid, undead, etc)"`
+}
+
+type RandomMonsterOutput struct {
+	Monster    resources.MonsterStat `json:"monster"`
+	Roll       int                   `json:"roll" jsonschema:"roll on the table of matching monsters"`
+	Candidates []string              `json:"candidates"`
+	Reason     string                `json:"reason"`
+	Message    string                `json:"message"`
+}
+
+func handleRandomMonster(ctx context.Context, req *mcp.CallToolRequest, input RandomMonsterInput) (*mcp.CallToolResult, RandomMonsterOutput, error) {
+	filter := resources.MonsterFilter{Type: input.Type, MinCR: input.MinCR, MaxCR: input.MaxCR}
+	if input.CR != nil {
+		filter.MinCR = input.CR
+		filter.MaxCR = input.CR
+	}
+
+	criteria := []string{}
+	if filter.Type != "" {
+		criteria = append(criteria, fmt.Sprintf("type %s", filter.Type))
+	}
+	switch {
+	case input.CR != nil:
+		criteria = append(criteria, fmt.Sprintf("CR %g", *input.CR))
+	case filter.MinCR != nil && filter.MaxCR != nil:
+		criteria = append(criteria, fmt.Sprintf("CR %g-%g", *filter.MinCR, *filter.MaxCR))
+	case filter.MinCR != nil:
+		criteria = append(criteria, fmt.Sprintf("CR %g or higher", *filter.MinCR))
+	case filter.MaxCR != nil:
+		criteria = append(criteria, fmt.Sprintf("CR %g or lower", *filter.MaxCR))
+	}
+	criteriaStr := "any monster"
+	if len(criteria) > 0 {
+		criteriaStr = strings.Join(criteria, ", ")
+	}
+
+	matches := resources.FilterMonsters(filter)
+	if len(matches) == 0 {
+		return nil, RandomMonsterOutput{}, fmt.Errorf("no monsters in the catalog match %s", criteriaStr)
+	}
+
+	table := make([]TableEntry, len(matches))
+	candidates := make([]string, len(matches))
+	for i, monster := range matches {
+		table[i] = TableEntry{Result: monster.Name, Weight: 1}
+		candidates[i] = monster.Name
+	}
+	index, roll, err := rollOnTable(table)
+	if err != nil {
+		return nil, RandomMonsterOutput{}, err
+	}
+	chosen := matches[index]
+
+	return nil, RandomMonsterOutput{
+		Monster:    chosen,
+		Roll:       roll,
+		Candidates: candidates,
+		Reason:     fmt.Sprintf("%s is a CR %g %s matching %s", chosen.Name, chosen.ChallengeRating, chosen.Type, criteriaStr),
+		Message:    fmt.Sprintf("Rolled %d of %d: %s (CR %g %s).", roll, len(matches), chosen.Name, chosen.ChallengeRating, chosen.Type),
+	}, nil
+}
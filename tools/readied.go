@@ -0,0 +1,149 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ReadiedAction is an action held until a trigger occurs or the holder's next turn starts
+type ReadiedAction struct {
+	Action             string
+	Trigger            string
+	Spell              string // spell being held, if the readied action is casting one
+	ConcentrationSpell bool   // whether the spell itself requires concentration once released
+	ReadiedRound       int
+	ExpiresRound       int // round in which the holder's next turn starts
+}
+
+// ReadyActionInput defines readying an action
+type ReadyActionInput struct {
+	EncounterScope
+	EntityID           string `json:"entity_id"`
+	Action             string `json:"action" jsonschema:"Action to take when the trigger occurs"`
+	Trigger            string `json:"trigger" jsonschema:"Perceivable circumstance that releases the action"`
+	Spell              string `json:"spell,omitempty" jsonschema:"Spell being readied; holding a spell requires concentration"`
+	ConcentrationSpell bool   `json:"concentration_spell,omitempty" jsonschema:"Whether the spell requires concentration after it is released (e.g. Hold Person)"`
+}
+
+type ReadyActionOutput struct {
+	Concentrating        string `json:"concentrating,omitempty" jsonschema:"Spell now held with concentration"`
+	DroppedConcentration string `json:"dropped_concentration,omitempty" jsonschema:"Spell whose concentration ended to hold this one"`
+	Deadline             string `json:"deadline"`
+	Message              string `json:"message"`
+}
+
+func handleReadyAction(ctx context.Context, req *mcp.CallToolRequest, input ReadyActionInput) (*mcp.CallToolResult, ReadyActionOutput, error) {
+	entity := combatState.Entities[input.EntityID]
+	if entity == nil {
+		return nil, ReadyActionOutput{}, fmt.Errorf("entity not found: %s", input.EntityID)
+	}
+	if entity.IsIncapacitated() {
+		return nil, ReadyActionOutput{}, fmt.Errorf("%s is incapacitated and can't take actions", entity.Name)
+	}
+
+	output := ReadyActionOutput{}
+
+	// Holding a readied spell takes concentration, so any other concentration ends now
+	if input.Spell != "" {
+		output.DroppedConcentration = combatState.endConcentration(entity)
+		entity.Concentrating = input.Spell
+		output.Concentrating = input.Spell
+	} else if entity.ReadiedAction != nil && entity.ReadiedAction.Spell != "" {
+		// Replacing a held spell with a mundane action releases the spell's concentration
+		output.DroppedConcentration = combatState.endConcentration(entity)
+	}
+
+	entity.ReadiedAction = &ReadiedAction{
+		Action:             input.Action,
+		Trigger:            input.Trigger,
+		Spell:              input.Spell,
+		ConcentrationSpell: input.ConcentrationSpell,
+		ReadiedRound:       combatState.RoundNumber,
+		ExpiresRound:       combatState.nextTurnRound(entity.ID),
+	}
+
+	output.Deadline = fmt.Sprintf("start of %s's turn in round %d", entity.Name, entity.ReadiedAction.ExpiresRound)
+	output.Message = fmt.Sprintf("%s readies %s (trigger: %s) until the %s.", entity.Name, input.Action, input.Trigger, output.Deadline)
+	if input.Spell != "" {
+		output.Message += fmt.Sprintf(" Holding %s requires concentration.", input.Spell)
+	}
+	if output.DroppedConcentration != "" && output.DroppedConcentration != input.Spell {
+		output.Message += fmt.Sprintf(" Concentration on %s ends.", output.DroppedConcentration)
+	}
+
+	return nil, output, nil
+}
+
+// TriggerReadiedActionInput defines releasing a readied action
+type TriggerReadiedActionInput struct {
+	EncounterScope
+	EntityID string `json:"entity_id"`
+}
+
+type TriggerReadiedActionOutput struct {
+	Action        string `json:"action"`
+	Spell         string `json:"spell,omitempty"`
+	Concentrating string `json:"concentrating,omitempty" jsonschema:"Spell still being concentrated on after release"`
+	Message       string `json:"message"`
+}
+
+func handleTriggerReadiedAction(ctx context.Context, req *mcp.CallToolRequest, input TriggerReadiedActionInput) (*mcp.CallToolResult, TriggerReadiedActionOutput, error) {
+	entity := combatState.Entities[input.EntityID]
+	if entity == nil {
+		return nil, TriggerReadiedActionOutput{}, fmt.Errorf("entity not found: %s", input.EntityID)
+	}
+
+	readied := entity.ReadiedAction
+	if readied == nil {
+		return nil, TriggerReadiedActionOutput{}, fmt.Errorf("%s has no readied action", entity.Name)
+	}
+	// Releasing a readied action takes the holder's reaction
+	if entity.IsIncapacitated() {
+		return nil, TriggerReadiedActionOutput{}, fmt.Errorf("%s is incapacitated and can't take reactions", entity.Name)
+	}
+	if entity.ReactionUsed {
+		return nil, TriggerReadiedActionOutput{}, fmt.Errorf("%s has already used its reaction this round", entity.Name)
+	}
+	entity.ReadiedAction = nil
+	entity.ReactionUsed = true
+
+	output := TriggerReadiedActionOutput{Action: readied.Action, Spell: readied.Spell}
+	output.Message = fmt.Sprintf("%s's trigger (%s) occurs: %s takes the readied action, %s.", entity.Name, readied.Trigger, entity.Name, readied.Action)
+
+	if readied.Spell != "" {
+		if readied.ConcentrationSpell {
+			output.Concentrating = entity.Concentrating
+			output.Message += fmt.Sprintf(" %s is released and %s keeps concentrating on it.", readied.Spell, entity.Name)
+		} else {
+			entity.Concentrating = ""
+			output.Message += fmt.Sprintf(" %s is released; concentration used to hold it ends.", readied.Spell)
+		}
+	}
+
+	return nil, output, nil
+}
+
+// expireReadiedAction clears a readied action whose trigger never fired, dropping
+// the concentration used to hold a readied spell
+func (cs *CombatState) expireReadiedAction(e *Entity) string {
+	readied := e.ReadiedAction
+	if readied.Spell != "" {
+		cs.endConcentration(e)
+		return fmt.Sprintf("Readied %s (%s) expired untriggered; concentration on %s ends", readied.Action, readied.Trigger, readied.Spell)
+	}
+
+	e.ReadiedAction = nil
+	return fmt.Sprintf("Readied %s (%s) expired untriggered", readied.Action, readied.Trigger)
+}
+
+// nextTurnRound returns the round in which the entity's next turn will start
+func (cs *CombatState) nextTurnRound(id string) int {
+	for i, turnID := range cs.TurnOrder {
+		if turnID == id && i > cs.CurrentTurn {
+			return cs.RoundNumber
+		}
+	}
+	return cs.RoundNumber + 1
+}
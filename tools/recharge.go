@@ -0,0 +1,111 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"regexp"
+	"strconv"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// defaultRechargeOn is the lowest d6 roll that recharges an ability, as in "Recharge 5-6"
+const defaultRechargeOn = 5
+
+// rechargePattern finds a stat block action's recharge range, e.g. "Fire Breath (Recharge 5-6)"
+var rechargePattern = regexp.MustCompile(`(?i)recharge ([1-6])`)
+
+// rechargeThreshold returns the lowest d6 roll that recharges the entity's ability
+func (e *Entity) rechargeThreshold(ability string) int {
+	if on, ok := e.RechargeOn[ability]; ok {
+		return on
+	}
+	return defaultRechargeOn
+}
+
+// rollRecharges rolls a d6 for each of the entity's spent recharge abilities at
+// the start of its turn, reporting which came back
+func (e *Entity) rollRecharges() []string {
+	effects := []string{}
+	for _, ability := range sortedKeys(e.RechargeAbilities) {
+		if e.RechargeAbilities[ability] {
+			continue
+		}
+		roll := rand.Intn(6) + 1
+		on := e.rechargeThreshold(ability)
+		if roll >= on {
+			e.RechargeAbilities[ability] = true
+			effects = append(effects, fmt.Sprintf("%s recharged (rolled %d, needed %d+)", ability, roll, on))
+		} else {
+			effects = append(effects, fmt.Sprintf("%s did not recharge (rolled %d, needed %d+)", ability, roll, on))
+		}
+	}
+	return effects
+}
+
+// RechargeAbilityInput defines tracking or spending a recharge ability
+type RechargeAbilityInput struct {
+	EncounterScope
+	EntityID   string `json:"entity_id"`
+	Ability    string `json:"ability" jsonschema:"Ability name, e.g. Fire Breath"`
+	RechargeOn int    `json:"recharge_on,omitempty" jsonschema:"Lowest d6 roll that recharges it: 5 for Recharge 5-6, 6 for Recharge 6 (defaults to 5)"`
+	Used       bool   `json:"used,omitempty" jsonschema:"Mark the ability as spent; it is rolled for at the start of the creature's turns until it recharges"`
+}
+
+type RechargeAbilityOutput struct {
+	Available  bool   `json:"available"`
+	RechargeOn int    `json:"recharge_on"`
+	Message    string `json:"message"`
+}
+
+func handleRechargeAbility(ctx context.Context, req *mcp.CallToolRequest, input RechargeAbilityInput) (*mcp.CallToolResult, RechargeAbilityOutput, error) {
+	entity := combatState.Entities[input.EntityID]
+	if entity == nil {
+		return nil, RechargeAbilityOutput{}, fmt.Errorf("entity not found: %s", input.EntityID)
+	}
+	if input.Ability == "" {
+		return nil, RechargeAbilityOutput{}, fmt.Errorf("ability name is required")
+	}
+	if input.RechargeOn != 0 && (input.RechargeOn < 2 || input.RechargeOn > 6) {
+		return nil, RechargeAbilityOutput{}, fmt.Errorf("recharge_on must be between 2 and 6")
+	}
+
+	if entity.RechargeAbilities == nil {
+		entity.RechargeAbilities = make(map[string]bool)
+	}
+	available, tracked := entity.RechargeAbilities[input.Ability]
+	if !tracked {
+		available = true
+	}
+	if input.RechargeOn != 0 {
+		if entity.RechargeOn == nil {
+			entity.RechargeOn = make(map[string]int)
+		}
+		entity.RechargeOn[input.Ability] = input.RechargeOn
+	}
+	if input.Used {
+		if !available {
+			return nil, RechargeAbilityOutput{}, fmt.Errorf("%s's %s hasn't recharged yet", entity.Name, input.Ability)
+		}
+		available = false
+	}
+	entity.RechargeAbilities[input.Ability] = available
+
+	on := entity.rechargeThreshold(input.Ability)
+	rangeText := strconv.Itoa(on)
+	if on < 6 {
+		rangeText += "-6"
+	}
+	message := fmt.Sprintf("%s's %s (Recharge %s) is available.", entity.Name, input.Ability, rangeText)
+	if !available {
+		message = fmt.Sprintf("%s's %s (Recharge %s) is spent; it recharges on a d6 roll of %d+ at the start of %s's turn.",
+			entity.Name, input.Ability, rangeText, on, entity.Name)
+	}
+
+	return nil, RechargeAbilityOutput{
+		Available:  available,
+		RechargeOn: on,
+		Message:    message,
+	}, nil
+}
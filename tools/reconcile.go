@@ -0,0 +1,104 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// reconcileHP clamps an entity's HP into [0, max] and recomputes the status flags
+// that depend on it, returning a description of every correction made
+func reconcileHP(e *Entity) []string {
+	corrections := []string{}
+
+	if e.MaxHP < 1 {
+		corrections = append(corrections, fmt.Sprintf("%s: max HP %d raised to 1", e.ID, e.MaxHP))
+		e.MaxHP = 1
+	}
+	if e.CurrentHP > e.MaxHP {
+		corrections = append(corrections, fmt.Sprintf("%s: current HP %d clamped to max %d", e.ID, e.CurrentHP, e.MaxHP))
+		e.CurrentHP = e.MaxHP
+	}
+	if e.CurrentHP < 0 {
+		corrections = append(corrections, fmt.Sprintf("%s: current HP %d raised to 0", e.ID, e.CurrentHP))
+		e.CurrentHP = 0
+	}
+
+	if e.CurrentHP > 0 {
+		if e.Dead {
+			corrections = append(corrections, fmt.Sprintf("%s: no longer dead at %d HP", e.ID, e.CurrentHP))
+			e.Dead = false
+		}
+		// Coming back up clears the death save tally, as healing does
+		if _, ok := e.Conditions["unconscious"]; ok {
+			corrections = append(corrections, fmt.Sprintf("%s: no longer unconscious at %d HP", e.ID, e.CurrentHP))
+			e.revive()
+		} else if e.DeathSaveSuccesses != 0 || e.DeathSaveFailures != 0 {
+			corrections = append(corrections, fmt.Sprintf("%s: death saves reset at %d HP", e.ID, e.CurrentHP))
+			e.resetDeathSaves()
+		}
+		return corrections
+	}
+
+	// Characters at 0 HP are unconscious; whether a creature died is left to the damage
+	// that dropped it, so no one is marked dead here
+	if _, ok := e.Conditions["unconscious"]; !ok && !e.IsMonster {
+		corrections = append(corrections, fmt.Sprintf("%s: marked unconscious at 0 HP", e.ID))
+		e.Conditions["unconscious"] = -1
+	}
+
+	return corrections
+}
+
+// ReconcileEntityInput defines an HP correction
+type ReconcileEntityInput struct {
+	EncounterScope
+	EntityID  string `json:"entity_id"`
+	MaxHP     *int   `json:"max_hp,omitempty" jsonschema:"Corrected max hit points"`
+	CurrentHP *int   `json:"current_hp,omitempty" jsonschema:"Corrected current hit points"`
+}
+
+type ReconcileEntityOutput struct {
+	MaxHP         int      `json:"max_hp"`
+	CurrentHP     int      `json:"current_hp"`
+	Bloodied      bool     `json:"bloodied"`
+	IsUnconscious bool     `json:"is_unconscious"`
+	Dead          bool     `json:"dead"`
+	Corrections   []string `json:"corrections"`
+	Message       string   `json:"message"`
+}
+
+func handleReconcileEntity(ctx context.Context, req *mcp.CallToolRequest, input ReconcileEntityInput) (*mcp.CallToolResult, ReconcileEntityOutput, error) {
+	entity := combatState.Entities[input.EntityID]
+	if entity == nil {
+		return nil, ReconcileEntityOutput{}, fmt.Errorf("entity not found: %s", input.EntityID)
+	}
+
+	if input.MaxHP != nil {
+		entity.MaxHP = *input.MaxHP
+	}
+	if input.CurrentHP != nil {
+		entity.CurrentHP = *input.CurrentHP
+	}
+
+	corrections := reconcileHP(entity)
+	_, unconscious := entity.Conditions["unconscious"]
+
+	message := fmt.Sprintf("%s is at %d/%d HP.", entity.Name, entity.CurrentHP, entity.MaxHP)
+	if len(corrections) == 0 {
+		message += " No corrections needed."
+	} else {
+		message += fmt.Sprintf(" %d corrections made.", len(corrections))
+	}
+
+	return nil, ReconcileEntityOutput{
+		MaxHP:         entity.MaxHP,
+		CurrentHP:     entity.CurrentHP,
+		Bloodied:      entity.IsBloodied(),
+		IsUnconscious: unconscious,
+		Dead:          entity.Dead,
+		Corrections:   corrections,
+		Message:       message,
+	}, nil
+}
@@ -0,0 +1,66 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestImportedHPIsClamped(t *testing.T) {
+	over, under := 45, -3
+	output := startTestCombat(t,
+		EntityInit{ID: "cleric", Name: "Cleric", Initiative: 12, HP: 30, AC: 18, CurrentHP: &over},
+		EntityInit{ID: "rogue", Name: "Rogue", Initiative: 18, HP: 24, AC: 15, CurrentHP: &under},
+	)
+
+	cleric := combatState.Entities["cleric"]
+	if cleric.CurrentHP != 30 {
+		t.Errorf("cleric imported with 45/30 HP has %d HP, want it clamped to 30", cleric.CurrentHP)
+	}
+	rogue := combatState.Entities["rogue"]
+	if rogue.CurrentHP != 0 {
+		t.Errorf("rogue imported with -3 HP has %d HP, want 0", rogue.CurrentHP)
+	}
+	if _, ok := rogue.Conditions["unconscious"]; !ok {
+		t.Error("rogue imported at 0 HP isn't unconscious")
+	}
+
+	for _, want := range []string{"cleric: current HP 45 clamped to max 30", "rogue: current HP -3 raised to 0"} {
+		found := false
+		for _, correction := range output.Corrections {
+			found = found || strings.Contains(correction, want)
+		}
+		if !found {
+			t.Errorf("corrections %v don't report %q", output.Corrections, want)
+		}
+	}
+}
+
+func TestReconcileDoesNotInventDeaths(t *testing.T) {
+	ctx := context.Background()
+	startTestCombat(t,
+		EntityInit{ID: "rogue", Name: "Rogue", Initiative: 18, HP: 24, AC: 15},
+		EntityInit{ID: "orc", Name: "Orc", Initiative: 10, HP: 15, AC: 13, IsMonster: true},
+	)
+	zero, ten := 0, 10
+
+	_, output, err := handleReconcileEntity(ctx, nil, ReconcileEntityInput{EntityID: "orc", CurrentHP: &zero})
+	if err != nil {
+		t.Fatalf("reconcile_entity: %v", err)
+	}
+	if output.Dead {
+		t.Errorf("the orc was marked dead at 0 HP: %v", output.Corrections)
+	}
+
+	rogue := combatState.Entities["rogue"]
+	rogue.CurrentHP = 0
+	rogue.Conditions["unconscious"] = -1
+	rogue.DeathSaveSuccesses, rogue.DeathSaveFailures = 1, 2
+	if _, _, err := handleReconcileEntity(ctx, nil, ReconcileEntityInput{EntityID: "rogue", CurrentHP: &ten}); err != nil {
+		t.Fatalf("reconcile_entity: %v", err)
+	}
+	if _, ok := rogue.Conditions["unconscious"]; ok || rogue.DeathSaveSuccesses != 0 || rogue.DeathSaveFailures != 0 {
+		t.Errorf("revived rogue: conditions %v, death saves %d/%d, want conscious with the tally reset",
+			rogue.Conditions, rogue.DeathSaveSuccesses, rogue.DeathSaveFailures)
+	}
+}
@@ -0,0 +1,103 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// releaseReferences clears what other combatants hold onto from an entity that is
+// leaving combat: its concentration spells, its grapples, and pending saves it owes
+func (cs *CombatState) releaseReferences(leaving *Entity) []string {
+	notes := []string{}
+	if spell := cs.endConcentration(leaving); spell != "" {
+		notes = append(notes, fmt.Sprintf("%s ends", spell))
+	}
+	for _, e := range cs.Entities {
+		if e == leaving {
+			continue
+		}
+		if _, ok := e.Conditions["grappled"]; ok && e.ConditionSources["grappled"] == leaving.ID {
+			delete(e.Conditions, "grappled")
+			delete(e.ConditionSources, "grappled")
+			notes = append(notes, fmt.Sprintf("%s is no longer grappled", e.Name))
+		}
+		delete(e.Distances, leaving.ID)
+	}
+	for id, effect := range cs.PendingEffects {
+		effect.TargetIDs = slices.DeleteFunc(effect.TargetIDs, func(t string) bool { return t == leaving.ID })
+		if len(effect.TargetIDs) == 0 {
+			delete(cs.PendingEffects, id)
+		}
+	}
+	slices.Sort(notes)
+	return notes
+}
+
+// RemoveEntityInput defines taking a creature out of combat
+type RemoveEntityInput struct {
+	EncounterScope
+	EntityID string `json:"entity_id"`
+	Reason   string `json:"reason,omitempty" jsonschema:"Why the creature left, e.g. died or fled, for the combat log"`
+}
+
+type RemoveEntityOutput struct {
+	TurnOrder       []string `json:"turn_order"`
+	CurrentEntityID string   `json:"current_entity_id,omitempty"`
+	RoundNumber     int      `json:"round_number"`
+	TurnEffects     []string `json:"turn_effects,omitempty" jsonschema:"Start-of-turn effects for the next combatant, when the acting creature was removed"`
+	Message         string   `json:"message"`
+}
+
+func handleRemoveEntity(ctx context.Context, req *mcp.CallToolRequest, input RemoveEntityInput) (*mcp.CallToolResult, RemoveEntityOutput, error) {
+	entity := combatState.Entities[input.EntityID]
+	if entity == nil {
+		return nil, RemoveEntityOutput{}, fmt.Errorf("entity not found: %s", input.EntityID)
+	}
+
+	index := slices.Index(combatState.TurnOrder, entity.ID)
+	acting := index >= 0 && index == combatState.CurrentTurn
+	wasLast := index == len(combatState.TurnOrder)-1
+	// Group mates behind the acting entity have already started this turn
+	groupContinues := acting && !wasLast && entity.GroupID != "" &&
+		combatState.Entities[combatState.TurnOrder[index+1]].GroupID == entity.GroupID
+
+	notes := combatState.releaseReferences(entity)
+	combatState.removeEntity(entity.ID)
+
+	message := fmt.Sprintf("%s leaves combat", entity.Name)
+	if input.Reason != "" {
+		message += fmt.Sprintf(" (%s)", input.Reason)
+	}
+	combatState.logEvent("%s", message)
+	if len(notes) > 0 {
+		message += "; " + strings.Join(notes, "; ")
+	}
+
+	output := RemoveEntityOutput{}
+	// Removing the acting creature hands the turn to the next one in line, unless the
+	// rest of its group is still taking the turn
+	if groupContinues {
+		message += fmt.Sprintf("; the rest of group %s keeps the turn", entity.GroupID)
+	} else if acting && len(combatState.TurnOrder) > 0 {
+		if wasLast {
+			combatState.CurrentTurn = len(combatState.TurnOrder)
+		}
+		output.TurnEffects = combatState.beginTurn(entity.InitiativeRoll).Effects
+	}
+
+	output.TurnOrder = combatState.TurnOrder
+	output.RoundNumber = combatState.RoundNumber
+	if len(combatState.TurnOrder) > 0 {
+		output.CurrentEntityID = combatState.TurnOrder[combatState.CurrentTurn]
+		message += fmt.Sprintf(". Now %s's turn (round %d)", combatState.Entities[output.CurrentEntityID].Name, combatState.RoundNumber)
+	} else {
+		message += ". No combatants remain"
+	}
+	output.Message = message + "."
+
+	return nil, output, nil
+}
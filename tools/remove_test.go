@@ -0,0 +1,52 @@
+package tools
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRemovingGroupLeaderKeepsTheGroupsTurn(t *testing.T) {
+	ctx := context.Background()
+	startTestCombat(t,
+		EntityInit{ID: "fighter", Name: "Fighter", Initiative: 15, HP: 30, AC: 16},
+		EntityInit{ID: "g1", Name: "Goblin 1", Initiative: 12, HP: 7, AC: 15, IsMonster: true, GroupID: "goblins"},
+		EntityInit{ID: "g2", Name: "Goblin 2", HP: 7, AC: 15, IsMonster: true, GroupID: "goblins"},
+		EntityInit{ID: "g3", Name: "Goblin 3", HP: 7, AC: 15, IsMonster: true, GroupID: "goblins"},
+	)
+	g2 := combatState.Entities["g2"]
+	g2.Conditions["poisoned"] = 3
+
+	// The goblins start their turn together, then g2 spends its reaction
+	if turn := combatState.advanceTurn(); turn.CurrentEntityID != "g1" {
+		t.Fatalf("next turn went to %s, want the goblins led by g1", turn.CurrentEntityID)
+	}
+	if g2.Conditions["poisoned"] != 2 {
+		t.Fatalf("poisoned = %d after the goblins' turn started, want 2", g2.Conditions["poisoned"])
+	}
+	g2.ReactionUsed = true
+	round := combatState.RoundNumber
+
+	_, output, err := handleRemoveEntity(ctx, nil, RemoveEntityInput{EntityID: "g1", Reason: "died"})
+	if err != nil {
+		t.Fatalf("remove_entity: %v", err)
+	}
+	if output.CurrentEntityID != "g2" || output.RoundNumber != round {
+		t.Errorf("turn is %s in round %d, want g2 still in round %d", output.CurrentEntityID, output.RoundNumber, round)
+	}
+	if len(output.TurnEffects) != 0 {
+		t.Errorf("turn effects %v were applied again", output.TurnEffects)
+	}
+	if g2.Conditions["poisoned"] != 2 || !g2.ReactionUsed {
+		t.Errorf("g2 started its turn twice: poisoned = %d, reaction used = %v", g2.Conditions["poisoned"], g2.ReactionUsed)
+	}
+
+	// Once the whole group is gone the turn moves on to the next round
+	for _, id := range []string{"g2", "g3"} {
+		if _, output, err = handleRemoveEntity(ctx, nil, RemoveEntityInput{EntityID: id}); err != nil {
+			t.Fatalf("remove_entity %s: %v", id, err)
+		}
+	}
+	if output.CurrentEntityID != "fighter" || output.RoundNumber != round+1 {
+		t.Errorf("turn is %s in round %d, want fighter in round %d", output.CurrentEntityID, output.RoundNumber, round+1)
+	}
+}
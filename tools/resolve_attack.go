@@ -0,0 +1,165 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kiriyms/dungeon-master-mcp/resources"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ResolveAttackInput defines a whole attack, from the roll to hit to the damage dealt
+type ResolveAttackInput struct {
+	EncounterScope
+	AttackerID        string `json:"attacker_id"`
+	TargetID          string `json:"target_id"`
+	AttackBonus       int    `json:"attack_bonus,omitempty" jsonschema:"Attack bonus for an attack without a stat block, used with damage_dice"`
+	DamageDice        string `json:"damage_dice,omitempty" jsonschema:"Damage dice, e.g. 1d8+3; when omitted the attack comes from the attacker's stat block"`
+	DamageType        string `json:"damage_type,omitempty"`
+	ActionName        string `json:"action_name,omitempty" jsonschema:"Name of the attack, or the stat block attack to use when damage_dice is omitted"`
+	Ranged            bool   `json:"ranged,omitempty" jsonschema:"The attack is a ranged attack, for conditions such as prone when no distance is tracked"`
+	Advantage         bool   `json:"advantage,omitempty" jsonschema:"Other sources of advantage"`
+	Disadvantage      bool   `json:"disadvantage,omitempty" jsonschema:"Other sources of disadvantage"`
+	RollConcentration bool   `json:"roll_concentration,omitempty" jsonschema:"Roll a concentrating target's CON save automatically instead of reporting the DC to roll"`
+	Cover             string `json:"cover,omitempty" jsonschema:"Cover the target has: half (+2 AC), three_quarters (+5 AC), or total (the attack misses)"`
+	// Declared reasons are counted alongside the creatures' conditions
+	AdvantageSources    []string `json:"advantage_sources,omitempty" jsonschema:"Reasons the attack has advantage, e.g. flanking, help, or prone target within 5 feet"`
+	DisadvantageSources []string `json:"disadvantage_sources,omitempty" jsonschema:"Reasons the attack has disadvantage, e.g. long range or heavily obscured"`
+}
+
+type ResolveAttackOutput struct {
+	Attack             AttackResult        `json:"attack"`
+	Steps              []string            `json:"steps" jsonschema:"Each step of the resolution in order: to hit, damage roll, damage modifiers, HP"`
+	HPBefore           int                 `json:"hp_before"`
+	RemainingHP        int                 `json:"remaining_hp"`
+	InstantDeath       bool                `json:"instant_death,omitempty" jsonschema:"The damage past 0 HP equaled or exceeded the target's max HP, killing it outright"`
+	ConcentrationDC    int                 `json:"concentration_dc,omitempty" jsonschema:"DC of the CON save the target must make to keep concentrating"`
+	ConcentrationCheck *ConcentrationCheck `json:"concentration_check,omitempty" jsonschema:"The concentration save, when it was rolled"`
+	Message            string              `json:"message"`
+}
+
+func handleResolveAttack(ctx context.Context, req *mcp.CallToolRequest, input ResolveAttackInput) (*mcp.CallToolResult, ResolveAttackOutput, error) {
+	attacker := combatState.Entities[input.AttackerID]
+	if attacker == nil {
+		return nil, ResolveAttackOutput{}, fmt.Errorf("attacker not found: %s", input.AttackerID)
+	}
+	target := combatState.Entities[input.TargetID]
+	if target == nil {
+		return nil, ResolveAttackOutput{}, fmt.Errorf("target not found: %s", input.TargetID)
+	}
+	if attacker.IsIncapacitated() {
+		return nil, ResolveAttackOutput{}, fmt.Errorf("%s is incapacitated and can't take actions", attacker.Name)
+	}
+	cover, err := parseCover(input.Cover)
+	if err != nil {
+		return nil, ResolveAttackOutput{}, err
+	}
+
+	var action resources.MonsterAction
+	if input.DamageDice != "" {
+		if _, _, _, err := parseDice(input.DamageDice); err != nil {
+			return nil, ResolveAttackOutput{}, err
+		}
+		action = resources.MonsterAction{
+			Name:        input.ActionName,
+			AttackBonus: input.AttackBonus,
+			DamageDice:  input.DamageDice,
+			DamageType:  input.DamageType,
+		}
+		if action.Name == "" {
+			action.Name = "attack"
+		}
+	} else {
+		var err error
+		if action, err = attackAction(attacker, input.ActionName); err != nil {
+			return nil, ResolveAttackOutput{}, fmt.Errorf("%w; give attack_bonus and damage_dice for an attack without a stat block", err)
+		}
+	}
+	if input.Ranged {
+		action.Description = "Ranged Weapon Attack"
+	}
+
+	hpBefore := target.CurrentHP
+	result, err := resolveAttackWith(attacker, target, action, attackOptions{
+		Advantage:           input.Advantage,
+		Disadvantage:        input.Disadvantage,
+		Cover:               cover,
+		AdvantageSources:    input.AdvantageSources,
+		DisadvantageSources: input.DisadvantageSources,
+	})
+	if err != nil {
+		return nil, ResolveAttackOutput{}, err
+	}
+	if result.Revealed {
+		combatState.logEvent("%s attacks from hiding and is revealed", attacker.Name)
+	}
+
+	output := ResolveAttackOutput{
+		Attack:      result,
+		Steps:       result.steps(hpBefore, target.CurrentHP),
+		HPBefore:    hpBefore,
+		RemainingHP: target.CurrentHP,
+		Message:     result.Message + ".",
+	}
+	if result.Hit {
+		output.Message += fmt.Sprintf(" %s has %d HP left.", target.Name, target.CurrentHP)
+	}
+
+	if result.Hit {
+		after := combatState.afterDamage(target, hpBefore, result.Damage, false, input.RollConcentration)
+		output.InstantDeath = after.InstantDeath
+		output.ConcentrationDC, output.ConcentrationCheck = after.ConcentrationDC, after.ConcentrationCheck
+		output.Message += after.Note
+	}
+
+	return nil, output, nil
+}
+
+// steps breaks a resolved attack down into its to-hit roll, damage roll, damage
+// modifiers, and HP change for a step-by-step report
+func (r AttackResult) steps(hpBefore, hpAfter int) []string {
+	steps := []string{}
+	switch {
+	case r.SpecialRule != "":
+		steps = append(steps, "To hit: "+r.SpecialRule)
+	default:
+		toHit := fmt.Sprintf("To hit: d20 %d%+d=%d vs AC %d%s", r.Roll, r.Bonus, r.Total, r.TargetAC, r.coverNote())
+		if r.RollMode != "" {
+			toHit += fmt.Sprintf(" (%s, rolled %v)", r.RollReason, r.Rolls)
+		} else if r.RollReason != "" {
+			toHit += fmt.Sprintf(" (%s)", r.RollReason)
+		}
+		switch {
+		case r.Critical:
+			toHit += ": critical hit"
+		case r.Hit:
+			toHit += ": hit"
+		case r.Roll == 1:
+			toHit += ": natural 1, miss"
+		default:
+			toHit += ": miss"
+		}
+		steps = append(steps, toHit)
+	}
+	if !r.Hit || r.DamageRoll == nil {
+		return steps
+	}
+
+	damage := fmt.Sprintf("Damage roll: %s rolled %v", r.DamageRoll.Expression, r.DamageRoll.Rolls)
+	if r.DamageRoll.Modifier != 0 {
+		damage += fmt.Sprintf("%+d", r.DamageRoll.Modifier)
+	}
+	damage += fmt.Sprintf(" = %d", r.DamageRoll.Total)
+	if r.Critical {
+		damage += fmt.Sprintf(" (critical: %dx dice)", resources.SRDDamageRules.CriticalMultiplier)
+	}
+	steps = append(steps, damage)
+
+	for _, step := range r.DamageSteps {
+		if step.Note != "" {
+			steps = append(steps, fmt.Sprintf("%s: %d (%s)", step.Stage, step.Value, step.Note))
+		}
+	}
+	steps = append(steps, fmt.Sprintf("HP: %d -> %d (%d %s damage dealt)", hpBefore, hpAfter, r.Damage, r.DamageType))
+	return steps
+}
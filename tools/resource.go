@@ -0,0 +1,159 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// resourceMax returns the most an entity's resource can hold: the max set with
+// track_resource, or the size of the dice pool of that name
+func (e *Entity) resourceMax(name string) (int, bool) {
+	if limit, ok := e.ResourceMax[name]; ok {
+		return limit, true
+	}
+	if pool, ok := e.DicePools[name]; ok {
+		return pool.Max, true
+	}
+	return 0, false
+}
+
+// TrackResourceInput defines resource tracking
+type TrackResourceInput struct {
+	EncounterScope
+	EntityID     string `json:"entity_id"`
+	ResourceName string `json:"resource_name"`
+	CurrentValue *int   `json:"current_value,omitempty" jsonschema:"Value to set the resource to"`
+	Delta        int    `json:"delta,omitempty" jsonschema:"Amount to restore, or a negative amount to spend, instead of setting current_value"`
+	Max          *int   `json:"max,omitempty" jsonschema:"Most the resource can hold; 0 removes the cap"`
+}
+
+type TrackResourceOutput struct {
+	EntityID     string `json:"entity_id"`
+	ResourceName string `json:"resource_name"`
+	Previous     int    `json:"previous"`
+	Current      int    `json:"current"`
+	Max          int    `json:"max,omitempty"`
+	Overflow     int    `json:"overflow,omitempty" jsonschema:"Amount dropped by clamping: above the max when positive, below 0 when negative"`
+	Message      string `json:"message"`
+}
+
+func handleTrackResource(ctx context.Context, req *mcp.CallToolRequest, input TrackResourceInput) (*mcp.CallToolResult, TrackResourceOutput, error) {
+	entity := combatState.Entities[input.EntityID]
+	if entity == nil {
+		return nil, TrackResourceOutput{}, fmt.Errorf("entity not found: %s", input.EntityID)
+	}
+	if input.ResourceName == "" {
+		return nil, TrackResourceOutput{}, fmt.Errorf("resource_name is required")
+	}
+	if input.CurrentValue != nil && input.Delta != 0 {
+		return nil, TrackResourceOutput{}, fmt.Errorf("give current_value or delta, not both")
+	}
+	if input.CurrentValue == nil && input.Delta == 0 && input.Max == nil {
+		return nil, TrackResourceOutput{}, fmt.Errorf("give current_value, delta, or max")
+	}
+	if input.Max != nil && *input.Max < 0 {
+		return nil, TrackResourceOutput{}, fmt.Errorf("max can't be negative")
+	}
+
+	name := input.ResourceName
+	if input.Max != nil {
+		if entity.ResourceMax == nil {
+			entity.ResourceMax = make(map[string]int)
+		}
+		if *input.Max == 0 {
+			delete(entity.ResourceMax, name)
+		} else {
+			entity.ResourceMax[name] = *input.Max
+		}
+	}
+
+	previous, tracked := entity.Resources[name]
+	requested := previous + input.Delta
+	if input.CurrentValue != nil {
+		requested = *input.CurrentValue
+	} else if !tracked && input.Delta == 0 {
+		// Setting only the max starts an untracked resource full
+		requested = *input.Max
+	}
+	current := max(requested, 0)
+	limit, capped := entity.resourceMax(name)
+	if capped {
+		current = min(current, limit)
+	}
+	entity.Resources[name] = current
+
+	output := TrackResourceOutput{
+		EntityID:     entity.ID,
+		ResourceName: name,
+		Previous:     previous,
+		Current:      current,
+		Max:          limit,
+		Overflow:     requested - current,
+	}
+	message := fmt.Sprintf("%s now has %d %s", entity.Name, current, name)
+	if capped {
+		message = fmt.Sprintf("%s now has %d/%d %s", entity.Name, current, limit, name)
+	}
+	switch {
+	case output.Overflow > 0:
+		message += fmt.Sprintf(" (%d over the max dropped)", output.Overflow)
+	case output.Overflow < 0:
+		message += fmt.Sprintf(" (%d below 0 dropped)", -output.Overflow)
+	}
+	output.Message = message + "."
+
+	return nil, output, nil
+}
+
+// ResourceStatus is one resource an entity tracks
+type ResourceStatus struct {
+	Name    string `json:"name"`
+	Current int    `json:"current"`
+	Max     int    `json:"max,omitempty"`
+	Die     string `json:"die,omitempty" jsonschema:"Die of the dice pool, e.g. 1d8"`
+}
+
+// GetResourcesInput defines reading an entity's tracked resources
+type GetResourcesInput struct {
+	EncounterScope
+	EntityID string `json:"entity_id"`
+}
+
+type GetResourcesOutput struct {
+	EntityID  string           `json:"entity_id"`
+	Resources []ResourceStatus `json:"resources" jsonschema:"Every tracked resource, by name"`
+	Message   string           `json:"message"`
+}
+
+func handleGetResources(ctx context.Context, req *mcp.CallToolRequest, input GetResourcesInput) (*mcp.CallToolResult, GetResourcesOutput, error) {
+	entity := combatState.Entities[input.EntityID]
+	if entity == nil {
+		return nil, GetResourcesOutput{}, fmt.Errorf("entity not found: %s", input.EntityID)
+	}
+
+	output := GetResourcesOutput{EntityID: entity.ID, Resources: []ResourceStatus{}}
+	summary := []string{}
+	for _, name := range sortedKeys(entity.Resources) {
+		status := ResourceStatus{Name: name, Current: entity.Resources[name]}
+		status.Max, _ = entity.resourceMax(name)
+		if pool, ok := entity.DicePools[name]; ok {
+			status.Die = pool.die()
+		}
+		output.Resources = append(output.Resources, status)
+		if status.Max > 0 {
+			summary = append(summary, fmt.Sprintf("%s %d/%d", name, status.Current, status.Max))
+		} else {
+			summary = append(summary, fmt.Sprintf("%s %d", name, status.Current))
+		}
+	}
+
+	if len(summary) == 0 {
+		output.Message = fmt.Sprintf("%s tracks no resources.", entity.Name)
+	} else {
+		output.Message = fmt.Sprintf("%s: %s.", entity.Name, strings.Join(summary, ", "))
+	}
+	return nil, output, nil
+}
@@ -0,0 +1,167 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// RestSummary is what one entity regained from a rest
+type RestSummary struct {
+	EntityID string   `json:"entity_id"`
+	Name     string   `json:"name"`
+	Restored []string `json:"restored" jsonschema:"Everything the rest restored, empty when nothing was spent"`
+}
+
+// restingEntities looks up the entities taking a rest, defaulting to everyone
+func (cs *CombatState) restingEntities(ids []string) ([]*Entity, error) {
+	if len(ids) == 0 {
+		ids = sortedKeys(cs.Entities)
+	}
+	entities := make([]*Entity, 0, len(ids))
+	for _, id := range ids {
+		entity := cs.Entities[id]
+		if entity == nil {
+			return nil, fmt.Errorf("entity not found: %s", id)
+		}
+		entities = append(entities, entity)
+	}
+	return entities, nil
+}
+
+// restorePools refills the entity's dice pools that recharge on the given rest; a
+// long rest refills them all
+func (e *Entity) restorePools(longRest bool) []string {
+	restored := []string{}
+	for _, name := range sortedKeys(e.DicePools) {
+		pool := e.DicePools[name]
+		if (!longRest && pool.Recharge != "short_rest") || e.Resources[name] == pool.Max {
+			continue
+		}
+		e.Resources[name] = pool.Max
+		restored = append(restored, fmt.Sprintf("%s (%d)", name, pool.Max))
+	}
+	return restored
+}
+
+// shortRest restores what the entity regains on a short rest
+func (e *Entity) shortRest() []string {
+	restored := e.restorePools(false)
+	if e.PactMagic {
+		if n := e.restoreSpellSlots(0); n > 0 {
+			restored = append(restored, fmt.Sprintf("%d pact magic slots", n))
+		}
+	}
+	return restored
+}
+
+// longRest restores what the entity regains on a long rest. A creature needs at
+// least 1 hit point to benefit, and the rest only removes one level of exhaustion.
+func (e *Entity) longRest() []string {
+	restored := []string{}
+	if e.CurrentHP < e.MaxHP {
+		restored = append(restored, fmt.Sprintf("%d HP (now %d/%d)", e.MaxHP-e.CurrentHP, e.MaxHP, e.MaxHP))
+		e.CurrentHP = e.MaxHP
+	}
+	if n := e.restoreSpellSlots(0); n > 0 {
+		restored = append(restored, fmt.Sprintf("%d spell slots (%s)", n, e.spellSlotLine()))
+	}
+	restored = append(restored, e.restorePools(true)...)
+	for _, name := range sortedKeys(e.ResourceMax) {
+		if _, pool := e.DicePools[name]; pool || e.Resources[name] >= e.ResourceMax[name] {
+			continue
+		}
+		e.Resources[name] = e.ResourceMax[name]
+		restored = append(restored, fmt.Sprintf("%s (%d)", name, e.ResourceMax[name]))
+	}
+	for _, ability := range sortedKeys(e.RechargeAbilities) {
+		if !e.RechargeAbilities[ability] {
+			e.RechargeAbilities[ability] = true
+			restored = append(restored, ability+" recharged")
+		}
+	}
+	if e.LegendaryResistances < e.MaxLegendaryResistances {
+		restored = append(restored, fmt.Sprintf("legendary resistances (%d)", e.MaxLegendaryResistances))
+		e.LegendaryResistances = e.MaxLegendaryResistances
+	}
+	if e.ExhaustionLevel > 0 {
+		e.setExhaustion(e.ExhaustionLevel - 1)
+		restored = append(restored, fmt.Sprintf("one level of exhaustion (now %d)", e.ExhaustionLevel))
+	}
+	return restored
+}
+
+type RestOutput struct {
+	Restored []string      `json:"restored" jsonschema:"One line per entity that regained anything"`
+	Entities []RestSummary `json:"entities" jsonschema:"What each resting entity regained"`
+	Message  string        `json:"message"`
+}
+
+// ShortRestInput defines a short rest
+type ShortRestInput struct {
+	EncounterScope
+	EntityIDs []string `json:"entity_ids,omitempty" jsonschema:"Entities that rest (defaults to everyone)"`
+}
+
+func handleShortRest(ctx context.Context, req *mcp.CallToolRequest, input ShortRestInput) (*mcp.CallToolResult, RestOutput, error) {
+	entities, err := combatState.restingEntities(input.EntityIDs)
+	if err != nil {
+		return nil, RestOutput{}, err
+	}
+	output := restOutput(entities, "Short rest", func(e *Entity) ([]string, string) {
+		return e.shortRest(), ""
+	})
+	return nil, output, nil
+}
+
+// LongRestInput defines a long rest
+type LongRestInput struct {
+	EncounterScope
+	EntityIDs []string `json:"entity_ids,omitempty" jsonschema:"Entities that rest (defaults to everyone)"`
+}
+
+func handleLongRest(ctx context.Context, req *mcp.CallToolRequest, input LongRestInput) (*mcp.CallToolResult, RestOutput, error) {
+	entities, err := combatState.restingEntities(input.EntityIDs)
+	if err != nil {
+		return nil, RestOutput{}, err
+	}
+	output := restOutput(entities, "Long rest", func(e *Entity) ([]string, string) {
+		switch {
+		case e.Dead:
+			return nil, "dead"
+		case e.CurrentHP <= 0:
+			return nil, "at 0 HP, gains no benefit"
+		}
+		return e.longRest(), ""
+	})
+	return nil, output, nil
+}
+
+// restOutput applies a rest to each entity and summarizes what each regained, or
+// why it regained nothing
+func restOutput(entities []*Entity, rest string, apply func(*Entity) ([]string, string)) RestOutput {
+	output := RestOutput{Restored: []string{}, Entities: []RestSummary{}}
+	for _, entity := range entities {
+		restored, skipped := apply(entity)
+		if restored == nil {
+			restored = []string{}
+		}
+		output.Entities = append(output.Entities, RestSummary{EntityID: entity.ID, Name: entity.Name, Restored: restored})
+		switch {
+		case skipped != "":
+			output.Restored = append(output.Restored, fmt.Sprintf("%s: %s", entity.Name, skipped))
+		case len(restored) > 0:
+			output.Restored = append(output.Restored, fmt.Sprintf("%s regains %s", entity.Name, strings.Join(restored, ", ")))
+		}
+	}
+	combatState.logEvent("%s for %d entities", rest, len(entities))
+
+	if len(output.Restored) == 0 {
+		output.Message = fmt.Sprintf("%s complete; nothing needed restoring.", rest)
+	} else {
+		output.Message = fmt.Sprintf("%s complete.\n%s", rest, strings.Join(output.Restored, "\n"))
+	}
+	return output
+}
@@ -0,0 +1,119 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// SaveForDamageInput defines a saving throw against damage, such as a DEX save for half
+type SaveForDamageInput struct {
+	EncounterScope
+	EntityID          string `json:"entity_id"`
+	SaveType          string `json:"save_type" jsonschema:"STR, DEX, CON, INT, WIS, CHA"`
+	DC                int    `json:"dc" jsonschema:"Difficulty class"`
+	Damage            int    `json:"damage,omitempty" jsonschema:"Full damage before the save, e.g. one roll shared by every target of a fireball"`
+	DamageDice        string `json:"damage_dice,omitempty" jsonschema:"Dice to roll for the full damage instead of a pre-rolled amount, e.g. 8d6"`
+	DamageType        string `json:"damage_type"`
+	NoDamageOnSuccess bool   `json:"no_damage_on_success,omitempty" jsonschema:"A successful save negates the damage instead of halving it"`
+	SourceID          string `json:"source_id,omitempty" jsonschema:"Creature dealing the damage, credited on the damage leaderboard"`
+	RollConcentration bool   `json:"roll_concentration,omitempty" jsonschema:"Roll a concentrating target's CON save automatically instead of reporting the DC to roll"`
+	Cover             string `json:"cover,omitempty" jsonschema:"Cover from the effect: half (+2 to DEX saves), three_quarters (+5 to DEX saves), or total (no damage)"`
+}
+
+type SaveForDamageOutput struct {
+	EntityID                  string              `json:"entity_id"`
+	Save                      SavingThrowOutput   `json:"save"`
+	FullDamage                int                 `json:"full_damage" jsonschema:"Damage before the save and damage modifiers"`
+	DamageRoll                *DiceRoll           `json:"damage_roll,omitempty"`
+	FinalDamage               int                 `json:"final_damage" jsonschema:"Damage dealt after the save and damage modifiers"`
+	Steps                     []DamageStep        `json:"steps,omitempty" jsonschema:"Damage pipeline stages applied to the damage left after the save"`
+	HPBefore                  int                 `json:"hp_before"`
+	RemainingHP               int                 `json:"remaining_hp"`
+	IsUnconscious             bool                `json:"is_unconscious"`
+	InstantDeath              bool                `json:"instant_death,omitempty" jsonschema:"The damage past 0 HP equaled or exceeded the target's max HP, killing it outright"`
+	ConcentrationDC           int                 `json:"concentration_dc,omitempty" jsonschema:"DC of the CON save the target must make to keep concentrating"`
+	ConcentrationCheck        *ConcentrationCheck `json:"concentration_check,omitempty" jsonschema:"The concentration save, when it was rolled"`
+	RemainingLegendaryResists int                 `json:"remaining_legendary_resists"`
+	Message                   string              `json:"message"`
+}
+
+func handleSaveForDamage(ctx context.Context, req *mcp.CallToolRequest, input SaveForDamageInput) (*mcp.CallToolResult, SaveForDamageOutput, error) {
+	entity := combatState.Entities[input.EntityID]
+	if entity == nil {
+		return nil, SaveForDamageOutput{}, fmt.Errorf("entity not found: %s", input.EntityID)
+	}
+	source := combatState.Entities[input.SourceID]
+	if input.SourceID != "" && source == nil {
+		return nil, SaveForDamageOutput{}, fmt.Errorf("source not found: %s", input.SourceID)
+	}
+	cover, err := parseCover(input.Cover)
+	if err != nil {
+		return nil, SaveForDamageOutput{}, err
+	}
+
+	// Dice take precedence over a pre-rolled amount, as with apply_damage
+	damage := input.Damage
+	var damageRoll *DiceRoll
+	rolled := ""
+	if input.DamageDice != "" {
+		roll, err := rollDice(input.DamageDice, 1)
+		if err != nil {
+			return nil, SaveForDamageOutput{}, err
+		}
+		damage = roll.Total
+		damageRoll = &roll
+		rolled = fmt.Sprintf("Rolled %s: %v = %d. ", input.DamageDice, roll.Rolls, roll.Total)
+	}
+	if damage <= 0 {
+		return nil, SaveForDamageOutput{}, fmt.Errorf("give damage or damage_dice")
+	}
+
+	output := combatState.saveAgainstDamage(entity, source, input.SaveType, input.DC, damage, input.DamageType, input.NoDamageOnSuccess, input.RollConcentration, cover)
+	output.DamageRoll = damageRoll
+	output.Message = rolled + output.Message
+
+	return nil, output, nil
+}
+
+// saveAgainstDamage rolls the entity's save against full damage and applies all of
+// it on a failure and half, or none, on a success, through the damage pipeline. A
+// creature with total cover takes nothing.
+func (cs *CombatState) saveAgainstDamage(entity, source *Entity, saveType string, dc, fullDamage int, damageType string, noDamageOnSuccess, rollConcentration bool, cover string) SaveForDamageOutput {
+	output := SaveForDamageOutput{EntityID: entity.ID, FullDamage: fullDamage, HPBefore: entity.CurrentHP}
+	save := rollCoveredSave(entity, saveType, dc, cover, true)
+	output.Save = save.output(entity, dc)
+	output.RemainingLegendaryResists = entity.LegendaryResistances
+	message := fmt.Sprintf("%s save: %s.", save.Ability, output.Save.Message)
+
+	damage, taken := fullDamage, "full"
+	switch {
+	case save.Success && (noDamageOnSuccess || save.Cover == coverTotal):
+		damage, taken = 0, "no"
+	case save.Success:
+		damage, taken = damage/2, "half"
+	}
+	if damage == 0 {
+		output.RemainingHP = entity.CurrentHP
+		output.Message = message + fmt.Sprintf(" %s takes no damage. %d HP remaining.", entity.Name, entity.CurrentHP)
+		return output
+	}
+
+	hpBefore := entity.CurrentHP
+	var modifier string
+	output.FinalDamage, modifier, output.Steps = applyDamageSteps(entity, damage, damageType)
+	if source != nil {
+		source.DamageDealt += output.FinalDamage
+	}
+	output.RemainingHP = entity.CurrentHP
+
+	message += fmt.Sprintf(" %s takes %s damage: %d %s%s. %d HP remaining.",
+		entity.Name, taken, output.FinalDamage, damageType, modifier, entity.CurrentHP)
+
+	after := cs.afterDamage(entity, hpBefore, output.FinalDamage, false, rollConcentration)
+	output.IsUnconscious, output.InstantDeath = after.IsUnconscious, after.InstantDeath
+	output.ConcentrationDC, output.ConcentrationCheck = after.ConcentrationDC, after.ConcentrationCheck
+	output.Message = message + after.Note
+	return output
+}
@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMassiveDamageFromAttacksAndSaves(t *testing.T) {
+	ctx := context.Background()
+	hp := 1
+	startTestCombat(t,
+		EntityInit{ID: "squire", Name: "Squire", Initiative: 15, HP: 4, AC: 10, CurrentHP: &hp},
+		EntityInit{ID: "ogre", Name: "Ogre", Initiative: 10, HP: 59, AC: 11, IsMonster: true},
+	)
+	squire := combatState.Entities["squire"]
+	revive := func() {
+		squire.CurrentHP, squire.Dead = 1, false
+		delete(squire.Conditions, "unconscious")
+	}
+
+	// 10 damage leaves 9 past 0 HP, beyond the squire's max HP of 4. Only a natural 1
+	// misses, so retry until the attack lands.
+	hit := false
+	for range 20 {
+		revive()
+		_, output, err := handleResolveAttack(ctx, nil, ResolveAttackInput{AttackerID: "ogre", TargetID: "squire", AttackBonus: 20, DamageDice: "10", DamageType: "bludgeoning"})
+		if err != nil {
+			t.Fatalf("resolve_attack: %v", err)
+		}
+		if output.Attack.Hit {
+			hit = true
+			if !output.InstantDeath || !squire.Dead {
+				t.Errorf("resolve_attack: instant death = %v, dead = %v (%s)", output.InstantDeath, squire.Dead, output.Message)
+			}
+			break
+		}
+	}
+	if !hit {
+		t.Fatal("the ogre never hit")
+	}
+
+	// A paralyzed creature fails DEX saves, so it takes the full 10
+	revive()
+	squire.Conditions["paralyzed"] = -1
+	_, output, err := handleSaveForDamage(ctx, nil, SaveForDamageInput{EntityID: "squire", SaveType: "DEX", DC: 15, Damage: 10, DamageType: "fire"})
+	if err != nil {
+		t.Fatalf("save_for_damage: %v", err)
+	}
+	if !output.InstantDeath || !squire.Dead {
+		t.Errorf("save_for_damage: instant death = %v, dead = %v (%s)", output.InstantDeath, squire.Dead, output.Message)
+	}
+
+	revive()
+	_, aoe, err := handleResolveAOE(ctx, nil, ResolveAOEInput{TargetIDs: []string{"squire"}, SaveType: "DEX", DC: 15, Damage: 10, DamageType: "fire"})
+	if err != nil {
+		t.Fatalf("resolve_aoe: %v", err)
+	}
+	if !aoe.Results[0].InstantDeath || !squire.Dead || len(aoe.Downed) != 1 {
+		t.Errorf("resolve_aoe: instant death = %v, dead = %v, downed = %v (%s)", aoe.Results[0].InstantDeath, squire.Dead, aoe.Downed, aoe.Message)
+	}
+}
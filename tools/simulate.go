@@ -0,0 +1,186 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/kiriyms/dungeon-master-mcp/resources"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// targetStrategy picks which of the standing opponents a simulated combatant attacks
+type targetStrategy func(candidates []*Entity) *Entity
+
+// targetStrategies are the selectable AI heuristics for simulate_round
+var targetStrategies = map[string]targetStrategy{
+	"lowest_hp": func(candidates []*Entity) *Entity {
+		return minBy(candidates, func(e *Entity) int { return e.CurrentHP })
+	},
+	"highest_hp": func(candidates []*Entity) *Entity {
+		return minBy(candidates, func(e *Entity) int { return -e.CurrentHP })
+	},
+	"lowest_ac": func(candidates []*Entity) *Entity {
+		return minBy(candidates, func(e *Entity) int { return e.AC })
+	},
+	"random": func(candidates []*Entity) *Entity {
+		return candidates[rand.Intn(len(candidates))]
+	},
+}
+
+// minBy returns the candidate with the smallest key, breaking ties by ID
+func minBy(candidates []*Entity, key func(*Entity) int) *Entity {
+	best := candidates[0]
+	for _, e := range candidates[1:] {
+		if key(e) < key(best) || (key(e) == key(best) && e.ID < best.ID) {
+			best = e
+		}
+	}
+	return best
+}
+
+// HPChange is one combatant's net HP change over a simulated round
+type HPChange struct {
+	EntityID string `json:"entity_id"`
+	Name     string `json:"name"`
+	Before   int    `json:"before"`
+	After    int    `json:"after"`
+	Delta    int    `json:"delta"`
+}
+
+// SimulateRoundInput defines a simulated round of combat
+type SimulateRoundInput struct {
+	EncounterScope
+	MonsterStrategy string             `json:"monster_strategy,omitempty" jsonschema:"How monsters pick targets: lowest_hp, highest_hp, lowest_ac, or random (defaults to lowest_hp)"`
+	PartyStrategy   string             `json:"party_strategy,omitempty" jsonschema:"How characters pick targets (defaults to lowest_hp)"`
+	PartyDPR        map[string]float64 `json:"party_dpr,omitempty" jsonschema:"Average damage per round for each character ID (defaults to a flat estimate)"`
+	Apply           bool               `json:"apply,omitempty" jsonschema:"Write the simulated results to the live combat instead of a scratch copy"`
+}
+
+type SimulateRoundOutput struct {
+	Simulated bool       `json:"simulated" jsonschema:"Always true: results come from heuristics, not real play"`
+	Applied   bool       `json:"applied"`
+	Log       []string   `json:"log"`
+	HPChanges []HPChange `json:"hp_changes"`
+	Message   string     `json:"message"`
+}
+
+func handleSimulateRound(ctx context.Context, req *mcp.CallToolRequest, input SimulateRoundInput) (*mcp.CallToolResult, SimulateRoundOutput, error) {
+	monsterPick, err := lookupStrategy(input.MonsterStrategy)
+	if err != nil {
+		return nil, SimulateRoundOutput{}, err
+	}
+	partyPick, err := lookupStrategy(input.PartyStrategy)
+	if err != nil {
+		return nil, SimulateRoundOutput{}, err
+	}
+
+	// Simulate on a scratch copy unless the DM wants the results kept
+	cs := combatState
+	if !input.Apply {
+		if cs, err = combatState.clone(); err != nil {
+			return nil, SimulateRoundOutput{}, fmt.Errorf("copying combat state: %w", err)
+		}
+	}
+
+	before := make(map[string]int)
+	for id, e := range cs.Entities {
+		before[id] = e.CurrentHP
+	}
+
+	output := SimulateRoundOutput{Simulated: true, Applied: input.Apply, Log: []string{}, HPChanges: []HPChange{}}
+	for i := range cs.TurnOrder {
+		actor := cs.Entities[cs.TurnOrder[(cs.CurrentTurn+i)%len(cs.TurnOrder)]]
+		if actor.CurrentHP <= 0 {
+			continue
+		}
+		if actor.IsIncapacitated() {
+			output.Log = append(output.Log, fmt.Sprintf("%s is incapacitated and loses its turn", actor.Name))
+			continue
+		}
+
+		opponents := []*Entity{}
+		for _, e := range cs.Entities {
+			if e.IsMonster != actor.IsMonster && e.CurrentHP > 0 {
+				opponents = append(opponents, e)
+			}
+		}
+		if len(opponents) == 0 {
+			output.Log = append(output.Log, fmt.Sprintf("%s has no standing opponents", actor.Name))
+			break
+		}
+
+		if actor.IsMonster {
+			target := monsterPick(opponents)
+			monster, ok := resources.GetMonster(actor.MonsterName)
+			routine := attackRoutine(monster)
+			if !ok || len(routine) == 0 {
+				output.Log = append(output.Log, fmt.Sprintf("%s has no stat block attacks to simulate", actor.Name))
+				continue
+			}
+			for _, action := range routine {
+				if target.CurrentHP <= 0 {
+					break
+				}
+				result, err := resolveAttack(actor, target, action, false)
+				if err != nil {
+					return nil, SimulateRoundOutput{}, err
+				}
+				output.Log = append(output.Log, result.Message)
+			}
+			continue
+		}
+
+		target := partyPick(opponents)
+		dpr, ok := input.PartyDPR[actor.ID]
+		if !ok {
+			dpr = assumedCharacterDPR
+		}
+		dealt, modifier := applyDamage(target, int(math.Round(dpr)), "")
+		actor.DamageDealt += dealt
+		output.Log = append(output.Log, fmt.Sprintf("%s deals an average %d damage to %s%s", actor.Name, dealt, target.Name, modifier))
+	}
+
+	for id, e := range cs.Entities {
+		if e.CurrentHP == before[id] {
+			continue
+		}
+		output.HPChanges = append(output.HPChanges, HPChange{
+			EntityID: id,
+			Name:     e.Name,
+			Before:   before[id],
+			After:    e.CurrentHP,
+			Delta:    e.CurrentHP - before[id],
+		})
+	}
+	sort.Slice(output.HPChanges, func(i, j int) bool { return output.HPChanges[i].EntityID < output.HPChanges[j].EntityID })
+
+	changes := []string{}
+	for _, c := range output.HPChanges {
+		changes = append(changes, fmt.Sprintf("%s %d->%d", c.Name, c.Before, c.After))
+	}
+	output.Message = fmt.Sprintf("[SIMULATED] One round played out: %s.", strings.Join(changes, ", "))
+	if len(changes) == 0 {
+		output.Message = "[SIMULATED] One round played out with no HP changes."
+	}
+	if !input.Apply {
+		output.Message += " The live combat was not changed."
+	}
+
+	return nil, output, nil
+}
+
+// lookupStrategy resolves a targeting heuristic by name, defaulting to lowest_hp
+func lookupStrategy(name string) (targetStrategy, error) {
+	if name == "" {
+		name = "lowest_hp"
+	}
+	strategy, ok := targetStrategies[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown targeting strategy: %s", name)
+	}
+	return strategy, nil
+}
@@ -0,0 +1,119 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/kiriyms/dungeon-master-mcp/resources"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// PublishSnapshotInput defines publishing the current combat state
+type PublishSnapshotInput struct {
+	EncounterScope
+}
+
+type PublishSnapshotOutput struct {
+	SnapshotID string `json:"snapshot_id"`
+	URI        string `json:"uri" jsonschema:"Resource URI serving the snapshot read-only"`
+	Message    string `json:"message"`
+}
+
+func handlePublishSnapshot(ctx context.Context, req *mcp.CallToolRequest, input PublishSnapshotInput) (*mcp.CallToolResult, PublishSnapshotOutput, error) {
+	data, err := json.MarshalIndent(combatState, "", "  ")
+	if err != nil {
+		return nil, PublishSnapshotOutput{}, fmt.Errorf("serializing combat state: %w", err)
+	}
+
+	id, err := resources.PublishSnapshot(data)
+	if err != nil {
+		return nil, PublishSnapshotOutput{}, err
+	}
+
+	return nil, PublishSnapshotOutput{
+		SnapshotID: id,
+		URI:        "combat://snapshot/" + id,
+		Message:    fmt.Sprintf("Published snapshot %s with %d combatants at round %d.", id, len(combatState.Entities), combatState.RoundNumber),
+	}, nil
+}
+
+// CombatStateJSON serializes the active encounter's combat state, returning nil when
+// no combat is running. It backs the combat://state/current resource, and holds the
+// encounter lock while it reads so tool calls can't change the state mid-marshal.
+func CombatStateJSON() ([]byte, error) {
+	encounterMu.Lock()
+	defer encounterMu.Unlock()
+
+	cs := activeCombatState()
+	if !cs.active() {
+		return nil, nil
+	}
+	return json.MarshalIndent(cs, "", "  ")
+}
+
+// LoadSnapshotInput defines restoring a published snapshot
+type LoadSnapshotInput struct {
+	EncounterScope
+	SnapshotID string `json:"snapshot_id" jsonschema:"ID returned by publish_snapshot"`
+}
+
+type LoadSnapshotOutput struct {
+	TurnOrder   []string `json:"turn_order"`
+	RoundNumber int      `json:"round_number"`
+	Message     string   `json:"message"`
+}
+
+func handleLoadSnapshot(ctx context.Context, req *mcp.CallToolRequest, input LoadSnapshotInput) (*mcp.CallToolResult, LoadSnapshotOutput, error) {
+	data, ok := resources.GetSnapshot(input.SnapshotID)
+	if !ok {
+		return nil, LoadSnapshotOutput{}, fmt.Errorf("snapshot not found or expired: %s", input.SnapshotID)
+	}
+
+	restored, err := decodeCombatState(data)
+	if err != nil {
+		return nil, LoadSnapshotOutput{}, fmt.Errorf("decoding snapshot: %w", err)
+	}
+	if err := restored.validate(); err != nil {
+		return nil, LoadSnapshotOutput{}, fmt.Errorf("snapshot %s is corrupt: %w", input.SnapshotID, err)
+	}
+
+	*combatState = *restored
+
+	return nil, LoadSnapshotOutput{
+		TurnOrder:   combatState.TurnOrder,
+		RoundNumber: combatState.RoundNumber,
+		Message:     fmt.Sprintf("Loaded snapshot %s: %d combatants, round %d.", input.SnapshotID, len(combatState.Entities), combatState.RoundNumber),
+	}, nil
+}
+
+// decodeCombatState rebuilds a combat state from its JSON form, initializing the
+// maps that tools write to without checking
+func decodeCombatState(data []byte) (*CombatState, error) {
+	var restored CombatState
+	if err := json.Unmarshal(data, &restored); err != nil {
+		return nil, err
+	}
+	if restored.Entities == nil {
+		restored.Entities = make(map[string]*Entity)
+	}
+	for _, e := range restored.Entities {
+		if e.Conditions == nil {
+			e.Conditions = make(map[string]int)
+		}
+		if e.Resources == nil {
+			e.Resources = make(map[string]int)
+		}
+	}
+	return &restored, nil
+}
+
+// clone returns a deep copy of the combat state, for what-if calculations that
+// must not touch the live encounter
+func (cs *CombatState) clone() (*CombatState, error) {
+	data, err := json.Marshal(cs)
+	if err != nil {
+		return nil, err
+	}
+	return decodeCombatState(data)
+}
@@ -0,0 +1,94 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/kiriyms/dungeon-master-mcp/resources"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// TestCombatStateJSONDuringToolCalls reads the combat://state/current source while tool
+// calls change the encounter; run it with -race to catch unlocked reads
+func TestCombatStateJSONDuringToolCalls(t *testing.T) {
+	t.Cleanup(resetEncounters)
+	session := connectTools(t)
+	ctx := context.Background()
+
+	if data, err := CombatStateJSON(); data != nil || err != nil {
+		t.Fatalf("CombatStateJSON before start_combat = %s, %v, want nil", data, err)
+	}
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name: "start_combat",
+		Arguments: map[string]any{"entities": []map[string]any{
+			{"id": "fighter", "name": "Fighter", "initiative": 15, "hp": 30, "ac": 16, "is_monster": false},
+			{"id": "orc", "name": "Orc", "initiative": 10, "hp": 15, "ac": 13, "is_monster": true},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("start_combat: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range 50 {
+			data, err := CombatStateJSON()
+			if err != nil {
+				t.Errorf("CombatStateJSON: %v", err)
+				return
+			}
+			var state CombatState
+			if err := json.Unmarshal(data, &state); err != nil {
+				t.Errorf("combat state isn't valid JSON: %v", err)
+				return
+			}
+		}
+	}()
+
+	for range 50 {
+		for _, call := range []*mcp.CallToolParams{
+			{Name: "apply_damage", Arguments: map[string]any{"target_id": "orc", "damage": 0}},
+			{Name: "add_condition", Arguments: map[string]any{"target_id": "orc", "condition": "prone", "duration": 1}},
+			{Name: "next_turn", Arguments: map[string]any{}},
+		} {
+			result, err := session.CallTool(ctx, call)
+			if err != nil || result.IsError {
+				t.Fatalf("%s failed: %v %v", call.Name, err, result)
+			}
+		}
+	}
+	wg.Wait()
+}
+
+func TestLoadSnapshotRejectsCorruptState(t *testing.T) {
+	ctx := context.Background()
+	startTestCombat(t,
+		EntityInit{ID: "fighter", Name: "Fighter", Initiative: 15, HP: 30, AC: 16},
+		EntityInit{ID: "orc", Name: "Orc", Initiative: 10, HP: 15, AC: 13, IsMonster: true},
+	)
+
+	_, published, err := handlePublishSnapshot(ctx, nil, PublishSnapshotInput{})
+	if err != nil {
+		t.Fatalf("publish_snapshot: %v", err)
+	}
+	corrupt, err := resources.PublishSnapshot([]byte(`{"Entities": {}, "TurnOrder": ["ghost"], "RoundNumber": 3}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := handleLoadSnapshot(ctx, nil, LoadSnapshotInput{SnapshotID: corrupt}); err == nil {
+		t.Fatal("load_snapshot accepted a turn order naming an unknown entity")
+	}
+	if combatState.RoundNumber != 1 || len(combatState.Entities) != 2 {
+		t.Errorf("the rejected snapshot changed the encounter: round %d, %d entities", combatState.RoundNumber, len(combatState.Entities))
+	}
+
+	if _, _, err := handleLoadSnapshot(ctx, nil, LoadSnapshotInput{SnapshotID: published.SnapshotID}); err != nil {
+		t.Errorf("load_snapshot of a published state: %v", err)
+	}
+}
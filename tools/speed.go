@@ -0,0 +1,174 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// immobilizingConditions reduce a creature's speed to 0 while they last
+var immobilizingConditions = []string{"grappled", "paralyzed", "petrified", "restrained", "stunned", "unconscious"}
+
+// effectiveSpeed derives the entity's current walking speed from its base speed and
+// conditions, returning the reasons for any reduction
+func effectiveSpeed(e *Entity) (int, []string) {
+	reasons := []string{}
+	for _, condition := range immobilizingConditions {
+		if _, ok := e.Conditions[condition]; ok {
+			reasons = append(reasons, fmt.Sprintf("%s: speed 0", condition))
+		}
+	}
+	if len(reasons) > 0 {
+		return 0, reasons
+	}
+
+	if e.ExhaustionLevel >= exhaustionNoSpeed {
+		return 0, []string{fmt.Sprintf("exhaustion %d: speed 0", e.ExhaustionLevel)}
+	}
+
+	speed := e.Speed
+	if e.ExhaustionLevel >= exhaustionHalfSpeed {
+		speed /= 2
+		reasons = append(reasons, fmt.Sprintf("exhaustion %d: speed halved", e.ExhaustionLevel))
+	}
+	if _, ok := e.Conditions["prone"]; ok {
+		speed /= 2
+		reasons = append(reasons, "prone: crawling at half speed")
+	}
+	return speed, reasons
+}
+
+// GetEffectiveSpeedInput defines a speed lookup
+type GetEffectiveSpeedInput struct {
+	EncounterScope
+	EntityID string `json:"entity_id"`
+}
+
+type GetEffectiveSpeedOutput struct {
+	BaseSpeed         int      `json:"base_speed"`
+	EffectiveSpeed    int      `json:"effective_speed"`
+	MovementRemaining int      `json:"movement_remaining" jsonschema:"Effective speed minus movement already used this turn"`
+	Reasons           []string `json:"reasons" jsonschema:"Conditions reducing the speed"`
+	Message           string   `json:"message"`
+}
+
+func handleGetEffectiveSpeed(ctx context.Context, req *mcp.CallToolRequest, input GetEffectiveSpeedInput) (*mcp.CallToolResult, GetEffectiveSpeedOutput, error) {
+	entity := combatState.Entities[input.EntityID]
+	if entity == nil {
+		return nil, GetEffectiveSpeedOutput{}, fmt.Errorf("entity not found: %s", input.EntityID)
+	}
+
+	speed, reasons := effectiveSpeed(entity)
+	message := fmt.Sprintf("%s's speed is %d ft", entity.Name, speed)
+	if len(reasons) > 0 {
+		message += fmt.Sprintf(" (base %d ft; %s)", entity.Speed, strings.Join(reasons, ", "))
+	}
+
+	return nil, GetEffectiveSpeedOutput{
+		BaseSpeed:         entity.Speed,
+		EffectiveSpeed:    speed,
+		MovementRemaining: max(speed-entity.MovementUsed, 0),
+		Reasons:           reasons,
+		Message:           message + ".",
+	}, nil
+}
+
+// MoveInput defines an entity moving on its turn
+type MoveInput struct {
+	EncounterScope
+	EntityID    string  `json:"entity_id"`
+	Distance    int     `json:"distance,omitempty" jsonschema:"Feet moved (computed from the destination when one is given)"`
+	Destination *[2]int `json:"destination,omitempty" jsonschema:"Grid coordinates in feet to move to, for an entity with a position"`
+	Description string  `json:"description,omitempty" jsonschema:"Where the creature moves, e.g. toward the dragon"`
+	// Opportunity attacks provoked by leaving an enemy's reach are only reported unless asked for
+	ResolveOpportunityAttacks bool `json:"resolve_opportunity_attacks,omitempty" jsonschema:"Roll provoked opportunity attacks with each monster's stat block attack"`
+}
+
+type MoveOutput struct {
+	MovementUsed      int `json:"movement_used"`
+	MovementRemaining int `json:"movement_remaining"`
+	// Enemies whose reach the mover left, which may spend their reaction on an opportunity attack
+	ProvokedAttackers  []string       `json:"provoked_attackers,omitempty"`
+	OpportunityAttacks []AttackResult `json:"opportunity_attacks,omitempty" jsonschema:"Opportunity attacks rolled when resolution was requested"`
+	Message            string         `json:"message"`
+}
+
+func handleMove(ctx context.Context, req *mcp.CallToolRequest, input MoveInput) (*mcp.CallToolResult, MoveOutput, error) {
+	entity := combatState.Entities[input.EntityID]
+	if entity == nil {
+		return nil, MoveOutput{}, fmt.Errorf("entity not found: %s", input.EntityID)
+	}
+	if input.Destination != nil {
+		if entity.Position == nil {
+			return nil, MoveOutput{}, fmt.Errorf("%s has no position; place it with set_position first", entity.Name)
+		}
+		input.Distance = gridDistance(*entity.Position, *input.Destination)
+	}
+	if input.Distance <= 0 {
+		return nil, MoveOutput{}, fmt.Errorf("distance must be positive")
+	}
+
+	speed, reasons := effectiveSpeed(entity)
+	remaining := max(speed-entity.MovementUsed, 0)
+	if input.Distance > remaining {
+		message := fmt.Sprintf("%s can't move %d ft: only %d ft of movement left", entity.Name, input.Distance, remaining)
+		if len(reasons) > 0 {
+			message += fmt.Sprintf(" (%s)", strings.Join(reasons, ", "))
+		}
+		return nil, MoveOutput{}, fmt.Errorf("%s", message)
+	}
+
+	entity.MovementUsed += input.Distance
+	message := fmt.Sprintf("%s moves %d ft", entity.Name, input.Distance)
+	var provoked []*Entity
+	if input.Destination != nil {
+		provoked = combatState.provokedBy(entity, *entity.Position, *input.Destination)
+		destination := *input.Destination
+		entity.Position = &destination
+		message += fmt.Sprintf(" to (%d, %d)", destination[0], destination[1])
+	}
+	if input.Description != "" {
+		message += " " + input.Description
+	}
+	combatState.logEvent("%s", message)
+
+	output := MoveOutput{
+		MovementUsed:      entity.MovementUsed,
+		MovementRemaining: remaining - input.Distance,
+	}
+	message = fmt.Sprintf("%s; %d ft of movement left.", message, remaining-input.Distance)
+
+	if len(provoked) > 0 && entity.Disengaged {
+		message += fmt.Sprintf(" It leaves the reach of %d enemies but has disengaged, so no opportunity attacks.", len(provoked))
+		provoked = nil
+	}
+	names := []string{}
+	for _, attacker := range provoked {
+		output.ProvokedAttackers = append(output.ProvokedAttackers, attacker.ID)
+		names = append(names, attacker.Name)
+	}
+	switch {
+	case len(provoked) == 0:
+	case !input.ResolveOpportunityAttacks:
+		message += fmt.Sprintf(" Provokes opportunity attacks from %s; resolve them with opportunity_attack.", strings.Join(names, ", "))
+	default:
+		for _, attacker := range provoked {
+			action, err := attackAction(attacker, "")
+			if err != nil {
+				message += fmt.Sprintf(" %s may make an opportunity attack, but it can't be rolled automatically: %v.", attacker.Name, err)
+				continue
+			}
+			result, err := combatState.opportunityAttack(attacker, entity, action)
+			if err != nil {
+				return nil, MoveOutput{}, err
+			}
+			output.OpportunityAttacks = append(output.OpportunityAttacks, result)
+			message += fmt.Sprintf(" Opportunity attack: %s.", result.Message)
+		}
+	}
+	output.Message = message
+
+	return nil, output, nil
+}
@@ -0,0 +1,250 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// maxSpellLevel is the highest level of spell slot
+const maxSpellLevel = 9
+
+// fullCasterSlots is the SRD spell slot table for full casters, by caster level;
+// half and third casters use it at a fraction of their class level
+var fullCasterSlots = [20][]int{
+	{2},
+	{3},
+	{4, 2},
+	{4, 3},
+	{4, 3, 2},
+	{4, 3, 3},
+	{4, 3, 3, 1},
+	{4, 3, 3, 2},
+	{4, 3, 3, 3, 1},
+	{4, 3, 3, 3, 2},
+	{4, 3, 3, 3, 2, 1},
+	{4, 3, 3, 3, 2, 1},
+	{4, 3, 3, 3, 2, 1, 1},
+	{4, 3, 3, 3, 2, 1, 1},
+	{4, 3, 3, 3, 2, 1, 1, 1},
+	{4, 3, 3, 3, 2, 1, 1, 1},
+	{4, 3, 3, 3, 2, 1, 1, 1, 1},
+	{4, 3, 3, 3, 3, 1, 1, 1, 1},
+	{4, 3, 3, 3, 3, 2, 1, 1, 1},
+	{4, 3, 3, 3, 3, 2, 2, 1, 1},
+}
+
+// casterDivisor is how many class levels make one caster level, by class
+var casterDivisor = map[string]int{
+	"bard":             1,
+	"cleric":           1,
+	"druid":            1,
+	"sorcerer":         1,
+	"wizard":           1,
+	"paladin":          2,
+	"ranger":           2,
+	"eldritch knight":  3,
+	"arcane trickster": 3,
+}
+
+// classSpellSlots returns the slots per spell level, starting at level 1, that a
+// single-class character of the given class and level has
+func classSpellSlots(class string, level int) ([]int, error) {
+	if level < 1 || level > 20 {
+		return nil, fmt.Errorf("level must be between 1 and 20, got %d", level)
+	}
+	class = strings.ToLower(strings.TrimSpace(class))
+
+	// Pact magic: every slot is of the same level
+	if class == "warlock" {
+		count := 1
+		switch {
+		case level >= 17:
+			count = 4
+		case level >= 11:
+			count = 3
+		case level >= 2:
+			count = 2
+		}
+		slots := make([]int, min((level+1)/2, 5))
+		slots[len(slots)-1] = count
+		return slots, nil
+	}
+
+	divisor, ok := casterDivisor[class]
+	if !ok {
+		return nil, fmt.Errorf("unknown spellcasting class: %s", class)
+	}
+	// Half and third casters get their first slots at level 2 and 3
+	if divisor > 1 && level < divisor {
+		return nil, fmt.Errorf("a level %d %s has no spell slots yet", level, class)
+	}
+	casterLevel := (level + divisor - 1) / divisor
+	return fullCasterSlots[casterLevel-1], nil
+}
+
+// spellSlotLine describes the remaining slots by level, e.g. 4/3/2
+func (e *Entity) spellSlotLine() string {
+	top := 0
+	for level := 1; level <= maxSpellLevel; level++ {
+		if e.MaxSpellSlots[level] > 0 || e.SpellSlots[level] > 0 {
+			top = level
+		}
+	}
+	counts := []string{}
+	for level := 1; level <= top; level++ {
+		counts = append(counts, strconv.Itoa(e.SpellSlots[level]))
+	}
+	return strings.Join(counts, "/")
+}
+
+// restoreSpellSlots refills the entity's slots at one level, or at every level
+// when level is 0, and returns how many slots came back
+func (e *Entity) restoreSpellSlots(level int) int {
+	restored := 0
+	for l, limit := range e.MaxSpellSlots {
+		if level != 0 && l != level {
+			continue
+		}
+		restored += limit - e.SpellSlots[l]
+		e.SpellSlots[l] = limit
+	}
+	return restored
+}
+
+// SpellSlotLevel is the slots an entity has at one spell level
+type SpellSlotLevel struct {
+	Level     int `json:"level"`
+	Remaining int `json:"remaining"`
+	Max       int `json:"max"`
+}
+
+// spellSlotLevels lists the entity's slots from level 1 up
+func (e *Entity) spellSlotLevels() []SpellSlotLevel {
+	levels := []SpellSlotLevel{}
+	for level := 1; level <= maxSpellLevel; level++ {
+		if e.MaxSpellSlots[level] > 0 || e.SpellSlots[level] > 0 {
+			levels = append(levels, SpellSlotLevel{Level: level, Remaining: e.SpellSlots[level], Max: e.MaxSpellSlots[level]})
+		}
+	}
+	return levels
+}
+
+// SpellSlotsOutput reports an entity's spell slots after a change
+type SpellSlotsOutput struct {
+	EntityID string           `json:"entity_id"`
+	Slots    []SpellSlotLevel `json:"slots" jsonschema:"Slots by spell level"`
+	SlotLine string           `json:"slot_line" jsonschema:"Remaining slots from level 1 up, e.g. 4/3/2"`
+	Message  string           `json:"message"`
+}
+
+func spellSlotsOutput(e *Entity, message string) SpellSlotsOutput {
+	return SpellSlotsOutput{
+		EntityID: e.ID,
+		Slots:    e.spellSlotLevels(),
+		SlotLine: e.spellSlotLine(),
+		Message:  fmt.Sprintf("%s Slots remaining: %s.", message, e.spellSlotLine()),
+	}
+}
+
+// SetSpellSlotsInput defines giving an entity its spell slots
+type SetSpellSlotsInput struct {
+	EncounterScope
+	EntityID string `json:"entity_id"`
+	Class    string `json:"class,omitempty" jsonschema:"Spellcasting class to take the slots from: bard, cleric, druid, sorcerer, wizard, paladin, ranger, eldritch knight, arcane trickster, or warlock"`
+	Level    int    `json:"level,omitempty" jsonschema:"Class level, with class"`
+	Slots    []int  `json:"slots,omitempty" jsonschema:"Max slots per spell level starting at level 1, e.g. [4, 3, 2], instead of class and level"`
+}
+
+func handleSetSpellSlots(ctx context.Context, req *mcp.CallToolRequest, input SetSpellSlotsInput) (*mcp.CallToolResult, SpellSlotsOutput, error) {
+	entity := combatState.Entities[input.EntityID]
+	if entity == nil {
+		return nil, SpellSlotsOutput{}, fmt.Errorf("entity not found: %s", input.EntityID)
+	}
+
+	slots := input.Slots
+	source := "custom slots"
+	switch {
+	case input.Class != "" && len(input.Slots) > 0:
+		return nil, SpellSlotsOutput{}, fmt.Errorf("give class and level or slots, not both")
+	case input.Class != "":
+		var err error
+		if slots, err = classSpellSlots(input.Class, input.Level); err != nil {
+			return nil, SpellSlotsOutput{}, err
+		}
+		source = fmt.Sprintf("level %d %s", input.Level, strings.ToLower(input.Class))
+	case len(input.Slots) == 0:
+		return nil, SpellSlotsOutput{}, fmt.Errorf("give class and level, or slots")
+	}
+	if len(slots) > maxSpellLevel {
+		return nil, SpellSlotsOutput{}, fmt.Errorf("spell slots only go up to level %d", maxSpellLevel)
+	}
+
+	entity.SpellSlots = make(map[int]int)
+	entity.MaxSpellSlots = make(map[int]int)
+	entity.PactMagic = strings.EqualFold(strings.TrimSpace(input.Class), "warlock")
+	for i, count := range slots {
+		if count < 0 {
+			return nil, SpellSlotsOutput{}, fmt.Errorf("slot count can't be negative")
+		}
+		if count > 0 {
+			entity.MaxSpellSlots[i+1] = count
+			entity.SpellSlots[i+1] = count
+		}
+	}
+
+	return nil, spellSlotsOutput(entity, fmt.Sprintf("%s has the spell slots of a %s.", entity.Name, source)), nil
+}
+
+// ExpendSpellSlotInput defines spending a spell slot
+type ExpendSpellSlotInput struct {
+	EncounterScope
+	EntityID string `json:"entity_id"`
+	Level    int    `json:"level" jsonschema:"Level of the slot to spend, 1-9"`
+}
+
+func handleExpendSpellSlot(ctx context.Context, req *mcp.CallToolRequest, input ExpendSpellSlotInput) (*mcp.CallToolResult, SpellSlotsOutput, error) {
+	entity := combatState.Entities[input.EntityID]
+	if entity == nil {
+		return nil, SpellSlotsOutput{}, fmt.Errorf("entity not found: %s", input.EntityID)
+	}
+	if input.Level < 1 || input.Level > maxSpellLevel {
+		return nil, SpellSlotsOutput{}, fmt.Errorf("level must be between 1 and %d, got %d", maxSpellLevel, input.Level)
+	}
+	if entity.SpellSlots[input.Level] <= 0 {
+		return nil, SpellSlotsOutput{}, fmt.Errorf("%s has no level %d spell slots left (remaining: %s)", entity.Name, input.Level, entity.spellSlotLine())
+	}
+
+	entity.SpellSlots[input.Level]--
+	combatState.logEvent("%s expends a level %d spell slot", entity.Name, input.Level)
+
+	return nil, spellSlotsOutput(entity, fmt.Sprintf("%s expends a level %d spell slot.", entity.Name, input.Level)), nil
+}
+
+// RestoreSpellSlotsInput defines regaining spent spell slots
+type RestoreSpellSlotsInput struct {
+	EncounterScope
+	EntityID string `json:"entity_id"`
+	Level    int    `json:"level,omitempty" jsonschema:"Only restore slots of this level (defaults to every level)"`
+}
+
+func handleRestoreSpellSlots(ctx context.Context, req *mcp.CallToolRequest, input RestoreSpellSlotsInput) (*mcp.CallToolResult, SpellSlotsOutput, error) {
+	entity := combatState.Entities[input.EntityID]
+	if entity == nil {
+		return nil, SpellSlotsOutput{}, fmt.Errorf("entity not found: %s", input.EntityID)
+	}
+	if len(entity.MaxSpellSlots) == 0 {
+		return nil, SpellSlotsOutput{}, fmt.Errorf("%s has no spell slots; set them with set_spell_slots", entity.Name)
+	}
+	if input.Level < 0 || input.Level > maxSpellLevel {
+		return nil, SpellSlotsOutput{}, fmt.Errorf("level must be between 1 and %d, got %d", maxSpellLevel, input.Level)
+	}
+
+	restored := entity.restoreSpellSlots(input.Level)
+	combatState.logEvent("%s regains %d spell slots", entity.Name, restored)
+
+	return nil, spellSlotsOutput(entity, fmt.Sprintf("%s regains %d spell slots.", entity.Name, restored)), nil
+}
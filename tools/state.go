@@ -0,0 +1,120 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// InitiativeSlot is one place in the initiative order
+type InitiativeSlot struct {
+	EntityID   string `json:"entity_id"`
+	Name       string `json:"name"`
+	Initiative int    `json:"initiative"`
+	Current    bool   `json:"current" jsonschema:"Whether it is this entity's turn"`
+}
+
+// EntityStatus is the live state of one combatant
+type EntityStatus struct {
+	ID                   string         `json:"id"`
+	Name                 string         `json:"name"`
+	IsMonster            bool           `json:"is_monster"`
+	CurrentHP            int            `json:"current_hp"`
+	MaxHP                int            `json:"max_hp"`
+	AC                   int            `json:"ac"`
+	Bloodied             bool           `json:"bloodied"`
+	Dead                 bool           `json:"dead"`
+	Conditions           map[string]int `json:"conditions" jsonschema:"Condition -> turns remaining (-1 = until removed)"`
+	Concentrating        string         `json:"concentrating,omitempty"`
+	ExhaustionLevel      int            `json:"exhaustion_level,omitempty"`
+	Hidden               bool           `json:"hidden,omitempty"`
+	LegendaryActions     int            `json:"legendary_actions,omitempty" jsonschema:"Legendary actions remaining this round"`
+	MaxLegendaryActions  int            `json:"max_legendary_actions,omitempty"`
+	LegendaryResistances int            `json:"legendary_resistances,omitempty" jsonschema:"Legendary resistances remaining"`
+	Surprised            bool           `json:"surprised,omitempty" jsonschema:"Loses its turn in round 1"`
+	GroupID              string         `json:"group_id,omitempty" jsonschema:"Initiative group the entity acts with"`
+	Notes                []string       `json:"notes,omitempty" jsonschema:"DM notes attached with add_note"`
+	SpellSlots           string         `json:"spell_slots,omitempty" jsonschema:"Remaining spell slots from level 1 up, e.g. 4/3/2"`
+}
+
+// status reports the entity's live state
+func (e *Entity) status() EntityStatus {
+	conditions := make(map[string]int, len(e.Conditions))
+	for condition, turns := range e.Conditions {
+		conditions[condition] = turns
+	}
+	return EntityStatus{
+		ID:                   e.ID,
+		Name:                 e.Name,
+		IsMonster:            e.IsMonster,
+		CurrentHP:            e.CurrentHP,
+		MaxHP:                e.MaxHP,
+		AC:                   e.AC,
+		Bloodied:             e.IsBloodied(),
+		Dead:                 e.Dead,
+		Conditions:           conditions,
+		Concentrating:        e.Concentrating,
+		ExhaustionLevel:      e.ExhaustionLevel,
+		Hidden:               e.Hidden,
+		LegendaryActions:     e.LegendaryActions,
+		MaxLegendaryActions:  e.MaxLegendaryActions,
+		LegendaryResistances: e.LegendaryResistances,
+		Surprised:            e.Surprised,
+		GroupID:              e.GroupID,
+		Notes:                e.Notes,
+		SpellSlots:           e.spellSlotLine(),
+	}
+}
+
+// GetCombatStateInput defines querying the whole encounter
+type GetCombatStateInput struct {
+	EncounterScope
+}
+
+type GetCombatStateOutput struct {
+	RoundNumber     int              `json:"round_number"`
+	CurrentTurn     int              `json:"current_turn" jsonschema:"Index of the acting entity in the initiative order"`
+	CurrentEntityID string           `json:"current_entity_id,omitempty"`
+	InitiativeOrder []InitiativeSlot `json:"initiative_order"`
+	Entities        []EntityStatus   `json:"entities" jsonschema:"Every combatant, in initiative order"`
+	Message         string           `json:"message"`
+}
+
+func handleGetCombatState(ctx context.Context, req *mcp.CallToolRequest, input GetCombatStateInput) (*mcp.CallToolResult, GetCombatStateOutput, error) {
+	output := GetCombatStateOutput{
+		RoundNumber:     combatState.RoundNumber,
+		CurrentTurn:     combatState.CurrentTurn,
+		InitiativeOrder: []InitiativeSlot{},
+		Entities:        []EntityStatus{},
+	}
+	summary := []string{}
+	for i, id := range combatState.TurnOrder {
+		e := combatState.Entities[id]
+		current := i == combatState.CurrentTurn
+		if current {
+			output.CurrentEntityID = id
+		}
+		output.InitiativeOrder = append(output.InitiativeOrder, InitiativeSlot{
+			EntityID:   id,
+			Name:       e.Name,
+			Initiative: e.InitiativeRoll,
+			Current:    current,
+		})
+
+		output.Entities = append(output.Entities, e.status())
+		status := fmt.Sprintf("%s %d/%d", e.Name, e.CurrentHP, e.MaxHP)
+		if e.IsBloodied() {
+			status += " (bloodied)"
+		}
+		if len(e.Notes) > 0 {
+			status += fmt.Sprintf(" [%s]", strings.Join(e.Notes, "; "))
+		}
+		summary = append(summary, status)
+	}
+
+	output.Message = fmt.Sprintf("Round %d, %s's turn. %s.", output.RoundNumber,
+		combatState.Entities[output.CurrentEntityID].Name, strings.Join(summary, ", "))
+	return nil, output, nil
+}
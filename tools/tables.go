@@ -0,0 +1,69 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// TableEntry is one result on a random table, weighted by how many slots it covers
+type TableEntry struct {
+	Result string `json:"result"`
+	Weight int    `json:"weight,omitempty" jsonschema:"Relative chance of this result (defaults to 1)"`
+}
+
+// rollOnTable rolls across the table's total weight and returns the index of the
+// selected entry along with the raw roll
+func rollOnTable(entries []TableEntry) (int, int, error) {
+	total := 0
+	for _, entry := range entries {
+		total += max(entry.Weight, 1)
+	}
+	if total == 0 {
+		return 0, 0, fmt.Errorf("cannot roll on an empty table")
+	}
+
+	roll := rand.Intn(total) + 1
+	running := 0
+	for i, entry := range entries {
+		running += max(entry.Weight, 1)
+		if roll <= running {
+			return i, roll, nil
+		}
+	}
+	return len(entries) - 1, roll, nil
+}
+
+// RollTableInput defines a roll on a custom random table
+type RollTableInput struct {
+	Entries []TableEntry `json:"entries" jsonschema:"Table results; weights act like die ranges (a weight of 5 on a d100 table covers five numbers)"`
+}
+
+type RollTableOutput struct {
+	Roll    int    `json:"roll" jsonschema:"roll across the table's total weight"`
+	Die     int    `json:"die" jsonschema:"total weight of the table, i.e. the die rolled"`
+	Result  string `json:"result"`
+	Message string `json:"message"`
+}
+
+func handleRollTable(ctx context.Context, req *mcp.CallToolRequest, input RollTableInput) (*mcp.CallToolResult, RollTableOutput, error) {
+	index, roll, err := rollOnTable(input.Entries)
+	if err != nil {
+		return nil, RollTableOutput{}, err
+	}
+
+	die := 0
+	for _, entry := range input.Entries {
+		die += max(entry.Weight, 1)
+	}
+	result := input.Entries[index].Result
+
+	return nil, RollTableOutput{
+		Roll:    roll,
+		Die:     die,
+		Result:  result,
+		Message: fmt.Sprintf("Rolled %d on d%d: %s", roll, die, result),
+	}, nil
+}
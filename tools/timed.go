@@ -0,0 +1,172 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// roundsPerMinute is the number of 6-second combat rounds in a minute
+const roundsPerMinute = 10
+
+// TimedEffect is a duration tracked independently of concentration and conditions,
+// such as a 1-minute wall or a 1-hour summon, with optional cleanup when it expires
+type TimedEffect struct {
+	Name            string
+	OwnerID         string // entity that created the effect, if any
+	RoundsRemaining int
+	// Cleanup performed on expiry
+	RemoveEntityID    string // entity removed from combat, e.g. a summoned creature
+	RemoveCondition   string // condition removed, e.g. a buff tracked as a condition
+	ConditionTargetID string // entity the condition is removed from
+}
+
+// AddTimedEffectInput defines tracking a timed effect
+type AddTimedEffectInput struct {
+	EncounterScope
+	Name              string `json:"name" jsonschema:"Effect name, e.g. Wall of Fire or Summoned Wolf"`
+	OwnerID           string `json:"owner_id,omitempty" jsonschema:"Entity that created the effect"`
+	Duration          int    `json:"duration" jsonschema:"Duration in the given unit"`
+	Unit              string `json:"unit,omitempty" jsonschema:"rounds, minutes, or hours (defaults to rounds)"`
+	RemoveEntityID    string `json:"remove_entity_id,omitempty" jsonschema:"Entity removed from combat when the effect expires (e.g. a summon)"`
+	RemoveCondition   string `json:"remove_condition,omitempty" jsonschema:"Condition removed when the effect expires"`
+	ConditionTargetID string `json:"condition_target_id,omitempty" jsonschema:"Entity the condition is removed from"`
+}
+
+type AddTimedEffectOutput struct {
+	RoundsRemaining int    `json:"rounds_remaining"`
+	Message         string `json:"message"`
+}
+
+func handleAddTimedEffect(ctx context.Context, req *mcp.CallToolRequest, input AddTimedEffectInput) (*mcp.CallToolResult, AddTimedEffectOutput, error) {
+	if input.Name == "" {
+		return nil, AddTimedEffectOutput{}, fmt.Errorf("effect name is required")
+	}
+	if input.Duration <= 0 {
+		return nil, AddTimedEffectOutput{}, fmt.Errorf("duration must be positive")
+	}
+	for _, id := range []string{input.OwnerID, input.RemoveEntityID, input.ConditionTargetID} {
+		if id != "" && combatState.Entities[id] == nil {
+			return nil, AddTimedEffectOutput{}, fmt.Errorf("entity not found: %s", id)
+		}
+	}
+	if input.RemoveCondition != "" && input.ConditionTargetID == "" {
+		return nil, AddTimedEffectOutput{}, fmt.Errorf("remove_condition needs a condition_target_id")
+	}
+
+	rounds := input.Duration
+	switch strings.ToLower(input.Unit) {
+	case "", "round", "rounds":
+	case "minute", "minutes":
+		rounds *= roundsPerMinute
+	case "hour", "hours":
+		rounds *= 60 * roundsPerMinute
+	default:
+		return nil, AddTimedEffectOutput{}, fmt.Errorf("unknown duration unit: %s", input.Unit)
+	}
+
+	combatState.TimedEffects = append(combatState.TimedEffects, &TimedEffect{
+		Name:              input.Name,
+		OwnerID:           input.OwnerID,
+		RoundsRemaining:   rounds,
+		RemoveEntityID:    input.RemoveEntityID,
+		RemoveCondition:   input.RemoveCondition,
+		ConditionTargetID: input.ConditionTargetID,
+	})
+
+	return nil, AddTimedEffectOutput{
+		RoundsRemaining: rounds,
+		Message:         fmt.Sprintf("%s lasts %d rounds (until round %d).", input.Name, rounds, combatState.RoundNumber+rounds),
+	}, nil
+}
+
+// AdvanceTimeInput defines passing time outside of initiative
+type AdvanceTimeInput struct {
+	EncounterScope
+	Minutes int `json:"minutes" jsonschema:"Minutes of time that pass"`
+}
+
+type AdvanceTimeOutput struct {
+	Expired   []string `json:"expired"`
+	Remaining []string `json:"remaining"`
+	Message   string   `json:"message"`
+}
+
+func handleAdvanceTime(ctx context.Context, req *mcp.CallToolRequest, input AdvanceTimeInput) (*mcp.CallToolResult, AdvanceTimeOutput, error) {
+	if input.Minutes <= 0 {
+		return nil, AdvanceTimeOutput{}, fmt.Errorf("minutes must be positive")
+	}
+
+	output := AdvanceTimeOutput{
+		Expired:   combatState.tickTimedEffects(input.Minutes * roundsPerMinute),
+		Remaining: []string{},
+	}
+	for _, effect := range combatState.TimedEffects {
+		output.Remaining = append(output.Remaining, fmt.Sprintf("%s (%d rounds left)", effect.Name, effect.RoundsRemaining))
+	}
+	output.Message = fmt.Sprintf("%d minutes pass; %d timed effects expired.", input.Minutes, len(output.Expired))
+
+	return nil, output, nil
+}
+
+// tickTimedEffects counts down every timed effect by the given number of rounds,
+// running the cleanup of each one that expires and describing the expirations
+func (cs *CombatState) tickTimedEffects(rounds int) []string {
+	expired := []string{}
+	remaining := []*TimedEffect{}
+
+	for _, effect := range cs.TimedEffects {
+		effect.RoundsRemaining -= rounds
+		if effect.RoundsRemaining > 0 {
+			remaining = append(remaining, effect)
+			continue
+		}
+
+		line := fmt.Sprintf("%s expires", effect.Name)
+		// A concentration spell running out ends the owner's concentration and its linked conditions
+		if owner := cs.Entities[effect.OwnerID]; owner != nil && owner.Concentrating != "" && strings.EqualFold(owner.Concentrating, effect.Name) {
+			cs.endConcentration(owner)
+			line += fmt.Sprintf("; %s's concentration ends", owner.Name)
+		}
+		if target := cs.Entities[effect.ConditionTargetID]; target != nil && effect.RemoveCondition != "" {
+			if _, ok := target.Conditions[effect.RemoveCondition]; ok {
+				delete(target.Conditions, effect.RemoveCondition)
+				line += fmt.Sprintf("; %s is no longer %s", target.Name, effect.RemoveCondition)
+			}
+		}
+		if summon := cs.Entities[effect.RemoveEntityID]; summon != nil {
+			notes := cs.releaseReferences(summon)
+			cs.removeEntity(summon.ID)
+			line += fmt.Sprintf("; %s leaves combat", summon.Name)
+			if len(notes) > 0 {
+				line += "; " + strings.Join(notes, "; ")
+			}
+		}
+		cs.logEvent("%s", line)
+		expired = append(expired, line)
+	}
+
+	cs.TimedEffects = remaining
+	return expired
+}
+
+// removeEntity takes an entity out of combat, keeping the current turn pointed at
+// the same combatant
+func (cs *CombatState) removeEntity(id string) {
+	delete(cs.Entities, id)
+	for i, turnID := range cs.TurnOrder {
+		if turnID != id {
+			continue
+		}
+		cs.TurnOrder = append(cs.TurnOrder[:i], cs.TurnOrder[i+1:]...)
+		if i < cs.CurrentTurn {
+			cs.CurrentTurn--
+		}
+		break
+	}
+	if cs.CurrentTurn >= len(cs.TurnOrder) {
+		cs.CurrentTurn = 0
+	}
+}
@@ -0,0 +1,34 @@
+package tools
+
+import (
+	"context"
+	"testing"
+)
+
+func TestExpiringSummonReleasesReferences(t *testing.T) {
+	ctx := context.Background()
+	startTestCombat(t,
+		EntityInit{ID: "druid", Name: "Druid", Initiative: 15, HP: 30, AC: 14},
+		EntityInit{ID: "wolf", Name: "Wolf", Initiative: 12, HP: 11, AC: 13},
+		EntityInit{ID: "bandit", Name: "Bandit", Initiative: 10, HP: 11, AC: 12, IsMonster: true},
+	)
+	bandit := combatState.Entities["bandit"]
+	bandit.Conditions["grappled"] = -1
+	bandit.setConditionSource("grappled", "wolf")
+	bandit.Distances = map[string]int{"wolf": 5, "druid": 30}
+
+	if _, _, err := handleAddTimedEffect(ctx, nil, AddTimedEffectInput{Name: "Summoned Wolf", OwnerID: "druid", Duration: 1, RemoveEntityID: "wolf"}); err != nil {
+		t.Fatalf("add_timed_effect: %v", err)
+	}
+	expired := combatState.tickTimedEffects(1)
+
+	if combatState.Entities["wolf"] != nil {
+		t.Fatalf("the wolf is still in combat after %v", expired)
+	}
+	if _, ok := bandit.Conditions["grappled"]; ok {
+		t.Errorf("the bandit is still grappled by the departed wolf: %v", expired)
+	}
+	if _, ok := bandit.Distances["wolf"]; ok {
+		t.Errorf("the bandit still tracks its distance to the wolf: %v", bandit.Distances)
+	}
+}
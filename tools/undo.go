@@ -0,0 +1,116 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// UndoDepthEnv names the environment variable that sets how many actions can be undone
+const UndoDepthEnv = "DM_UNDO_DEPTH"
+
+// defaultUndoDepth is the number of actions kept for undo_last_action
+const defaultUndoDepth = 20
+
+// undoEntry is the serialized combat state from before one tool call changed it
+type undoEntry struct {
+	Tool  string
+	State []byte
+}
+
+var (
+	undoDepth   = defaultUndoDepth
+	undoHistory []undoEntry // oldest first
+)
+
+// SetUndoDepth sets how many state-changing actions undo_last_action can revert,
+// dropping the oldest saved states if the history is already longer
+func SetUndoDepth(depth int) error {
+	if depth < 0 {
+		return fmt.Errorf("undo depth can't be negative: %d", depth)
+	}
+	undoDepth = depth
+	trimUndoHistory()
+	return nil
+}
+
+// pushUndo adds a saved state to the history
+func pushUndo(entry undoEntry) {
+	undoHistory = append(undoHistory, entry)
+	trimUndoHistory()
+}
+
+// trimUndoHistory drops the oldest saved states beyond the configured depth
+func trimUndoHistory() {
+	if excess := len(undoHistory) - undoDepth; excess > 0 {
+		undoHistory = append([]undoEntry(nil), undoHistory[excess:]...)
+	}
+}
+
+// undoable wraps a tool handler so the combat state it changes can be restored
+// with undo_last_action. Calls that leave the state untouched aren't recorded.
+func undoable[In, Out any](h mcp.ToolHandlerFor[In, Out]) mcp.ToolHandlerFor[In, Out] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input In) (*mcp.CallToolResult, Out, error) {
+		before, err := json.Marshal(combatState)
+		if err != nil {
+			var zero Out
+			return nil, zero, fmt.Errorf("saving combat state for undo: %w", err)
+		}
+
+		result, output, err := h(ctx, req, input)
+
+		// A handler that fails partway may still have changed the state
+		if after, marshalErr := json.Marshal(combatState); marshalErr != nil || !bytes.Equal(before, after) {
+			tool := "unknown action"
+			if req != nil && req.Params != nil {
+				tool = req.Params.Name
+			}
+			pushUndo(undoEntry{Tool: tool, State: before})
+		}
+		return result, output, err
+	}
+}
+
+// UndoLastActionInput defines reverting the most recent state change
+type UndoLastActionInput struct {
+	EncounterScope
+}
+
+type UndoLastActionOutput struct {
+	UndoneTool      string `json:"undone_tool" jsonschema:"Tool whose changes were reverted"`
+	RoundNumber     int    `json:"round_number"`
+	CurrentEntityID string `json:"current_entity_id,omitempty"`
+	UndosRemaining  int    `json:"undos_remaining"`
+	Message         string `json:"message"`
+}
+
+func handleUndoLastAction(ctx context.Context, req *mcp.CallToolRequest, input UndoLastActionInput) (*mcp.CallToolResult, UndoLastActionOutput, error) {
+	if len(undoHistory) == 0 {
+		return nil, UndoLastActionOutput{}, fmt.Errorf("nothing to undo")
+	}
+	entry := undoHistory[len(undoHistory)-1]
+
+	restored, err := decodeCombatState(entry.State)
+	if err != nil {
+		return nil, UndoLastActionOutput{}, fmt.Errorf("decoding saved state: %w", err)
+	}
+	undoHistory = undoHistory[:len(undoHistory)-1]
+	*combatState = *restored
+
+	output := UndoLastActionOutput{
+		UndoneTool:     entry.Tool,
+		RoundNumber:    combatState.RoundNumber,
+		UndosRemaining: len(undoHistory),
+	}
+	message := fmt.Sprintf("Undid %s. Round %d", entry.Tool, combatState.RoundNumber)
+	if combatState.CurrentTurn < len(combatState.TurnOrder) {
+		output.CurrentEntityID = combatState.TurnOrder[combatState.CurrentTurn]
+		message += fmt.Sprintf(", %s's turn", combatState.Entities[output.CurrentEntityID].Name)
+	}
+	output.Message = fmt.Sprintf("%s. %d more actions can be undone.", message, output.UndosRemaining)
+
+	return nil, output, nil
+}